@@ -16,6 +16,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/entry"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/environment"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
@@ -124,6 +125,13 @@ func octoterraHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Plugins are local executables, so the service must never run them on behalf of a caller.
+	if len(webArgs.Plugins) != 0 {
+		w.WriteHeader(400)
+		w.Write([]byte("Plugins are not supported by the web service"))
+		return
+	}
+
 	results, err := entry.Entry(webArgs)
 
 	if err != nil {
@@ -131,7 +139,7 @@ func octoterraHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reporter := reporters.NewOctopusWebCheckReporter(checks.Warning)
+	reporter := reporters.NewOctopusWebCheckReporter(checks.Warning, scoring.NewWeights(webArgs))
 	report, err := reporter.Generate(results)
 
 	if err != nil {
@@ -182,8 +190,32 @@ func hostIsCloudOrLocal(host string) bool {
 		host == "127.0.0.1"
 }
 
+// serverSettings are the settings that run executables, write files, or send requests from the host of the
+// service. Callers of the service can not set them.
+var serverSettings = []string{
+	"plugins",
+	"pluginResources",
+	"pluginTimeout",
+	"webhookUrls",
+	"webhookStateFile",
+	"traceExporter",
+	"traceEndpoint",
+	"traceFile",
+	"issueTracker",
+	"issueUrl",
+	"issueToken",
+	"historyFile",
+	"logFile",
+	"incremental",
+	"incrementalCache",
+	"remediationPlan",
+	"fix",
+	"undoLog",
+	"oclDirectory",
+}
+
 // sanitizeConfig removes sensitive information from the config so it is not
-// persisted to the disk.
+// persisted to the disk, and removes the settings that only the host of the service can set.
 func sanitizeConfig(rawConfig []byte) ([]byte, error) {
 	if len(rawConfig) == 0 {
 		return rawConfig, nil
@@ -200,6 +232,16 @@ func sanitizeConfig(rawConfig []byte) ([]byte, error) {
 	delete(config, "redirectorHost")
 	delete(config, "useRedirector")
 	delete(config, "redirectorRedirections")
+
+	// Viper matches the keys of the config file regardless of case
+	for key := range config {
+		if lo.ContainsBy(serverSettings, func(setting string) bool {
+			return strings.EqualFold(setting, key)
+		}) {
+			delete(config, key)
+		}
+	}
+
 	return json.Marshal(config)
 }
 
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSanitizeConfigRemovesServerSettings(t *testing.T) {
+	rawConfig := []byte(`{"apiKey": "API-XXXX", "plugins": ["/bin/sh"], "PluginTimeout": 100, "pluginResources": ["projects"], "webhookUrls": ["https://example.org"], "webhookStateFile": "/etc/state", "traceExporter": "otlp", "traceEndpoint": "http://10.0.0.1", "traceFile": "/etc/trace", "issueTracker": "github", "issueUrl": "http://10.0.0.1", "issueToken": "token", "historyFile": "/etc/history", "logFile": "/etc/log", "Incremental": true, "incrementalCache": "/etc/cache", "remediationPlan": "/etc", "fix": true, "undoLog": "/etc/undo", "oclDirectory": "/etc", "maxEnvironments": 5}`)
+
+	sanitized, err := sanitizeConfig(rawConfig)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]any{}
+	if err := json.Unmarshal(sanitized, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"apiKey", "plugins", "PluginTimeout", "pluginResources", "webhookUrls", "webhookStateFile", "traceExporter", "traceEndpoint", "traceFile", "issueTracker", "issueUrl", "issueToken", "historyFile", "logFile", "Incremental", "incrementalCache", "remediationPlan", "fix", "undoLog", "oclDirectory"} {
+		if _, ok := config[key]; ok {
+			t.Fatalf("The %s setting must be removed from the config", key)
+		}
+	}
+
+	if config["maxEnvironments"] != float64(5) {
+		t.Fatal("The maxEnvironments setting must be kept in the config")
+	}
+}
@@ -2,28 +2,104 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"strings"
+
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/args"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/entry"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
-	"os"
 )
 
+const usage = `Usage: octolint <command> [arguments]
+
+Commands:
+  scan               Scan an Octopus space and print a report. This is the default command.
+  diff               Compare two JSON reports.
+  checks list        List the checks and what they look for.
+  explain <checkId>  Describe a check and the arguments that configure it.
+  config validate    Report unknown settings and invalid values in the config file and environment variables.
+  config print       Print the effective config with secrets redacted.
+  version            Print the version.
+
+Run "octolint scan -help" to see the arguments of a scan. Arguments passed without a command are passed to scan.`
+
 func main() {
-	octolintConfig, err := args.ParseArgs(os.Args[1:])
+	command := "scan"
+	commandArgs := os.Args[1:]
+
+	// Invocations from before the subcommands were added start with a flag, and are treated as a scan
+	if len(commandArgs) != 0 && !strings.HasPrefix(commandArgs[0], "-") {
+		command = commandArgs[0]
+		commandArgs = commandArgs[1:]
+	}
+
+	switch command {
+	case "scan":
+		scan(commandArgs)
+	case "diff":
+		diff(commandArgs)
+	case "checks":
+		listChecks(commandArgs)
+	case "explain":
+		explain(commandArgs)
+	case "config":
+		configCommand(commandArgs)
+	case "version":
+		fmt.Println("Version: " + entry.Version)
+	case "help":
+		fmt.Println(usage)
+	default:
+		entry.ErrorExit("Unknown command \"" + command + "\"\n\n" + usage)
+	}
+}
+
+func scan(commandArgs []string) {
+	octolintConfig, err := args.ParseArgs(commandArgs)
 
 	if err != nil {
 		entry.ErrorExit(err.Error())
 		return
 	}
 
+	if octolintConfig.Version {
+		fmt.Println("Version: " + entry.Version)
+		return
+	}
+
+	if octolintConfig.PrintRequiredPermissions {
+		report, err := entry.RequiredPermissions(octolintConfig)
+
+		if err != nil {
+			entry.ErrorExit(err.Error())
+		}
+
+		fmt.Println(report)
+		return
+	}
+
+	if octolintConfig.Trend {
+		report, err := entry.Trend(octolintConfig)
+
+		if err != nil {
+			entry.ErrorExit(err.Error())
+		}
+
+		fmt.Println(report)
+		return
+	}
+
 	results, err := entry.Entry(octolintConfig)
 
 	if err != nil {
 		entry.ErrorExit(err.Error())
 	}
 
-	reporter := reporters.NewOctopusPlainCheckReporter(checks.Warning)
+	reporter, err := reporters.BuildReporter(octolintConfig)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
 	report, err := reporter.Generate(results)
 
 	if err != nil {
@@ -31,4 +107,102 @@ func main() {
 	}
 
 	fmt.Println(report)
+
+	fail, err := entry.ShouldFail(octolintConfig, results)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	if fail {
+		fmt.Fprintln(os.Stderr, "One or more checks failed with a severity of "+octolintConfig.FailOnSeverity+" or higher")
+		os.Exit(1)
+	}
+}
+
+func diff(commandArgs []string) {
+	diffConfig, err := args.ParseDiffArgs(commandArgs)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	report, err := entry.Diff(diffConfig)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	fmt.Println(report)
+}
+
+func listChecks(commandArgs []string) {
+	if len(commandArgs) == 0 || commandArgs[0] != "list" {
+		entry.ErrorExit("Usage: octolint checks list [arguments]")
+	}
+
+	octolintConfig, err := args.ParseArgs(commandArgs[1:])
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	report, err := entry.ListChecks(octolintConfig)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	fmt.Print(report)
+}
+
+func explain(commandArgs []string) {
+	if len(commandArgs) == 0 || strings.HasPrefix(commandArgs[0], "-") {
+		entry.ErrorExit("Usage: octolint explain <checkId> [arguments]")
+	}
+
+	octolintConfig, err := args.ParseArgs(commandArgs[1:])
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	report, err := entry.ExplainCheck(octolintConfig, commandArgs[0])
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	fmt.Print(report)
+}
+
+func configCommand(commandArgs []string) {
+	if len(commandArgs) == 0 {
+		entry.ErrorExit("Usage: octolint config <validate|print> [arguments]")
+	}
+
+	switch commandArgs[0] {
+	case "validate":
+		problems, err := args.ValidateConfig(commandArgs[1:])
+
+		if err != nil {
+			entry.ErrorExit(err.Error())
+		}
+
+		if len(problems) != 0 {
+			entry.ErrorExit(strings.Join(problems, "\n"))
+		}
+
+		fmt.Println("The configuration is valid")
+	case "print":
+		effectiveConfig, err := args.EffectiveConfig(commandArgs[1:])
+
+		if err != nil {
+			entry.ErrorExit(err.Error())
+		}
+
+		fmt.Print(effectiveConfig)
+	default:
+		entry.ErrorExit("Usage: octolint config <validate|print> [arguments]")
+	}
 }
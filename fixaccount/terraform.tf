@@ -0,0 +1,60 @@
+terraform {
+  required_providers {
+    octopusdeploy = { source = "OctopusDeployLabs/octopusdeploy", version = "0.11.2" }
+  }
+}
+
+
+provider "octopusdeploy" {
+  address  = "${var.octopus_server}"
+  api_key  = "${var.octopus_apikey}"
+  space_id = "${var.octopus_space_id}"
+}
+
+variable "octopus_server" {
+  type        = string
+  nullable    = false
+  sensitive   = false
+  description = "The URL of the Octopus server e.g. https://myinstance.octopus.app."
+}
+variable "octopus_apikey" {
+  type        = string
+  nullable    = false
+  sensitive   = true
+  description = "The API key used to access the Octopus server. See https://octopus.com/docs/octopus-rest-api/how-to-create-an-api-key for details on creating an API key."
+}
+variable "octopus_space_id" {
+  type        = string
+  nullable    = false
+  sensitive   = false
+  description = "The space ID to populate"
+}
+
+resource "octopusdeploy_user_role" "octolintfixrole" {
+  can_be_deleted                = true
+  description                   = "A role used by the octolint fix service account to apply fixes with the -fix argument."
+  granted_space_permissions     = [
+    "LifecycleEdit", "LifecycleView", "ProcessEdit", "ProcessView", "ProjectView", "VariableEdit",
+    "VariableEditUnscoped", "VariableView", "VariableViewUnscoped"
+  ]
+  granted_system_permissions    = []
+  name                          = "Octolint Fix"
+  space_permission_descriptions = []
+}
+
+resource "octopusdeploy_user" "octolintfix" {
+  display_name  = "Octolint Fix"
+  email_address = "b@example.org"
+  is_active     = true
+  is_service    = true
+  username      = "octolintfix"
+}
+
+resource "octopusdeploy_team" "octolintfix" {
+  name = "Octolint Fix"
+  users = [octopusdeploy_user.octolintfix.id]
+  user_role {
+    space_id     = var.octopus_space_id
+    user_role_id = octopusdeploy_user_role.octolintfixrole.id
+  }
+}
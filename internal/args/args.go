@@ -14,17 +14,65 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/remediation"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/types"
 	"github.com/spf13/viper"
 )
 
 func ParseArgs(args []string) (*config.OctolintConfig, error) {
+	octolintConfig, _, err := parseArgs(args)
+	return octolintConfig, err
+}
+
+// parseArgs parses the command line arguments, and then applies the settings from the config file and environment
+// variables that were not passed on the command line. The flag set is returned so the effective settings can be read.
+func parseArgs(args []string) (*config.OctolintConfig, *flag.FlagSet, error) {
+	octolintConfig := config.OctolintConfig{}
+	flags := newFlagSet(&octolintConfig)
+
+	err := flags.Parse(args)
+
+	if octolintConfig.Help {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		flags.SetOutput(os.Stdout)
+		flags.PrintDefaults()
+		os.Exit(0)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = overrideArgs(flags, octolintConfig.ConfigPath, octolintConfig.ConfigFile)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if octolintConfig.Url == "" {
+		octolintConfig.Url = os.Getenv("OCTOPUS_CLI_SERVER")
+	}
+
+	if octolintConfig.ApiKey == "" && octolintConfig.AccessToken == "" {
+		octolintConfig.ApiKey = os.Getenv("OCTOPUS_CLI_API_KEY")
+	}
+
+	return &octolintConfig, flags, nil
+}
+
+// DefaultConfig returns the config of a scan run without any arguments, config file, or environment variables.
+func DefaultConfig() *config.OctolintConfig {
+	octolintConfig := config.OctolintConfig{}
+	newFlagSet(&octolintConfig)
+	return &octolintConfig
+}
+
+// newFlagSet defines the arguments of a scan, which are written to the config.
+func newFlagSet(octolintConfig *config.OctolintConfig) *flag.FlagSet {
 	flags := flag.NewFlagSet("octolint", flag.ContinueOnError)
 	var buf bytes.Buffer
 	flags.SetOutput(&buf)
 
-	octolintConfig := config.OctolintConfig{}
-
 	flags.BoolVar(&octolintConfig.Help, "help", false, "Print usage")
 
 	flags.StringVar(&octolintConfig.Url, "url", "", "The Octopus URL e.g. https://myinstance.octopus.app")
@@ -37,21 +85,48 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 	flags.StringVar(&octolintConfig.ConfigPath, "configPath", ".", "The path of the configuration file to use. Defaults to the current directory")
 	flags.BoolVar(&octolintConfig.Verbose, "verbose", false, "Print verbose logs")
 	flags.BoolVar(&octolintConfig.VerboseErrors, "verboseErrors", false, "Print error details as verbose logs in Octopus")
+	flags.StringVar(&octolintConfig.LogFormat, "logFormat", "console", "The encoding of the logs. Supported values are console and json")
+	flags.StringVar(&octolintConfig.LogLevel, "logLevel", "", "The minimum level of the logs. Supported values are debug, info, warn and error. Defaults to debug with the verbose argument, or info otherwise")
+	flags.StringVar(&octolintConfig.LogFile, "logFile", "", "The path of a file that logs are written to instead of stderr")
 	flags.BoolVar(&octolintConfig.Version, "version", false, "Print the version")
 	flags.BoolVar(&octolintConfig.Spinner, "spinner", true, "Display the spinner")
+	flags.BoolVar(&octolintConfig.PrintRequiredPermissions, "printRequiredPermissions", false, "Print the space permissions required by the selected checks instead of scanning the space")
+	flags.IntVar(&octolintConfig.PageSize, "pageSize", defaults.PageSize, "The number of resources requested from the Octopus API at a time. Collections are read one page at a time, so larger pages mean fewer requests and smaller pages use less memory.")
+	flags.StringVar(&octolintConfig.GitRef, "gitRef", "", "The git branch or tag to load the deployment processes, runbooks, and variables of Config-as-Code projects from. Defaults to the default branch of each project.")
+	flags.StringVar(&octolintConfig.OclDirectory, "oclDirectory", "", "Lint the Config-as-Code OCL files saved under this directory, such as the .octopus directory of a git repository, instead of scanning an Octopus space. Only checks that inspect deployment processes, runbooks, and project variables are run.")
+	flags.StringVar(&octolintConfig.ReportFormat, "reportFormat", "plain", "The format of the report. Supported values are plain, web, json, html, markdown, and octopus")
+	flags.StringVar(&octolintConfig.OctopusArtifactFile, "octopusArtifactFile", "octolint-report.txt", "The file that the full report is saved to and attached as an artifact when the report format is octopus")
+	flags.StringVar(&octolintConfig.FailOnSeverity, "failOnSeverity", "", "Exit with a non-zero exit code if any check fails with this severity or higher. Supported values are Error, Warning, and Info")
+	flags.StringVar(&octolintConfig.RemediationPlan, "remediationPlan", "", "The directory to save proposed fixes to, as JSON Patch documents and Terraform snippets. Nothing is applied to the Octopus instance.")
+	flags.IntVar(&octolintConfig.RemediationRetentionQuantity, "remediationRetentionQuantity", defaults.RemediationRetentionQuantity, "The number of releases or days to keep in retention policies proposed for the "+organization.OctoRecLifecycleRetention+" check")
+	flags.StringVar(&octolintConfig.RemediationRetentionUnit, "remediationRetentionUnit", "Days", "The unit of retention policies proposed for the "+organization.OctoRecLifecycleRetention+" check. Supported values are Days and Items")
+	flags.BoolVar(&octolintConfig.Fix, "fix", false, "Apply the proposed fixes for the "+strings.Join(remediation.FixableChecks, ", ")+" checks. Every change is displayed and must be confirmed before it is applied.")
+	flags.StringVar(&octolintConfig.FixApiKey, "fixApiKey", "", "The API key with write permissions used to apply fixes. This should be separate from the read only API key passed to -apiKey.")
+	flags.StringVar(&octolintConfig.UndoLog, "undoLog", "octolint-undo.jsonl", "The file that the previous state of resources modified by -fix is appended to")
+	flags.BoolVar(&octolintConfig.Yes, "yes", false, "Apply fixes without asking for confirmation")
+	flags.IntVar(&octolintConfig.MarkdownMaxLength, "markdownMaxLength", defaults.MarkdownMaxLength, "The maximum number of characters in a markdown report. Long lists of findings are truncated to fit. Set to 0 to disable truncation.")
+	flags.Float64Var(&octolintConfig.ScoreWeightSecurity, "scoreWeightSecurity", defaults.ScoreWeightSecurity, "The number of points each security check contributes to the health score")
+	flags.Float64Var(&octolintConfig.ScoreWeightOrganization, "scoreWeightOrganization", defaults.ScoreWeightOrganization, "The number of points each organization check contributes to the health score")
+	flags.Float64Var(&octolintConfig.ScoreWeightNaming, "scoreWeightNaming", defaults.ScoreWeightNaming, "The number of points each naming check contributes to the health score")
+	flags.Float64Var(&octolintConfig.ScoreWeightPerformance, "scoreWeightPerformance", defaults.ScoreWeightPerformance, "The number of points each performance check contributes to the health score")
+	flags.Float64Var(&octolintConfig.ScoreWeightError, "scoreWeightError", defaults.ScoreWeightError, "The fraction of a check's points, between 0 and 1, that are lost when the check fails with the Error severity")
+	flags.Float64Var(&octolintConfig.ScoreWeightWarning, "scoreWeightWarning", defaults.ScoreWeightWarning, "The fraction of a check's points, between 0 and 1, that are lost when the check fails with the Warning severity")
+	flags.Float64Var(&octolintConfig.ScoreWeightInfo, "scoreWeightInfo", defaults.ScoreWeightInfo, "The fraction of a check's points, between 0 and 1, that are lost when the check fails with the Info severity")
 	flags.IntVar(&octolintConfig.MaxEnvironments, "maxEnvironments", defaults.MaxEnvironments, "Maximum number of environments for the "+organization.OctopusEnvironmentCountCheckName+" check")
 	flags.IntVar(&octolintConfig.MaxDaysSinceLastTask, "maxDaysSinceLastTask", defaults.MaxTimeSinceLastTask, "Maximum number of days since the last project task for the "+organization.OctopusUnusedProjectsCheckName+" check")
 	flags.IntVar(&octolintConfig.MaxDuplicateVariables, "maxDuplicateVariables", defaults.MaxDuplicateVariables, "Maximum number of duplicate variables to report on for the "+organization.OctoLintDuplicatedVariables+" check. Set to 0 to report all duplicate variables.")
 	flags.IntVar(&octolintConfig.MaxDuplicateVariableProjects, "maxDuplicateVariableProjects", defaults.MaxDuplicateVariableProjects, "Maximum number of projects to check for duplicate variables for the "+organization.OctoLintDuplicatedVariables+" check. Set to 0 to check all projects.")
+	flags.IntVar(&octolintConfig.MaxOutputVariablesProjects, "maxOutputVariablesProjects", defaults.MaxOutputVariablesProjects, "Maximum number of projects to check for invalid output variable references for the "+organization.OctoLintInvalidOutputVariables+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxDeploymentsByAdminProjects, "maxDeploymentsByAdminProjects", defaults.MaxDeploymentsByAdminProjects, "Maximum number of projects to check for admin deployments for the "+security.OctoLintDeploymentQueuedByAdmin+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxInvalidVariableProjects, "maxInvalidVariableProjects", defaults.MaxInvalidVariableProjects, "Maximum number of projects to check for invalid variables for the "+naming.OctoLintInvalidVariableNames+" check. Set to 0 to check all projects.")
-	flags.IntVar(&octolintConfig.MaxInvalidWorkerPoolProjects, "maxInvalidWorkerPoolProjects", defaults.MaxInvalidWorkerPoolProjects, "Maximum number of projects to check for invalid worker pools for the  "+naming.OctoLintProjectWorkerPool+" check. Set to 0 to check all projects.")
+	flags.IntVar(&octolintConfig.MaxInvalidWorkerPoolProjects, "maxInvalidWorkerPoolProjects", defaults.MaxInvalidWorkerPoolProjects, "Maximum number of projects to check for invalid worker pools for the "+naming.OctoLintProjectWorkerPool+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxInvalidContainerImageProjects, "maxInvalidContainerImageProjects", defaults.MaxInvalidContainerImageProjects, "Maximum number of projects to check for invalid container images for the "+naming.OctoLintContainerImageName+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxDefaultStepNameProjects, "maxDefaultStepNameProjects", defaults.MaxDefaultStepNameProjects, "Maximum number of projects to check for default step names for the "+naming.OctoLintProjectDefaultStepNames+" check. Set to 0 to report all projects")
 	flags.IntVar(&octolintConfig.MaxInvalidReleaseTemplateProjects, "maxInvalidReleaseTemplateProjects", defaults.MaxInvalidReleaseTemplateProjects, "Maximum number of projects to check for invalid release templates for the "+naming.OctoLintProjectReleaseTemplate+" check. Set to 0 to report all projects.")
 	flags.IntVar(&octolintConfig.MaxProjectSpecificEnvironmentProjects, "maxProjectSpecificEnvironmentProjects", defaults.MaxProjectSpecificEnvironmentProjects, "Maximum number of projects to check for project specific environments for the "+organization.OctoLintProjectSpecificEnvs+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxProjectSpecificEnvironmentEnvironments, "maxProjectSpecificEnvironmentEnvironments", defaults.MaxProjectSpecificEnvironmentEnvironments, "Maximum number of environments to check for project specific environments for the "+organization.OctoLintProjectSpecificEnvs+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxUnusedVariablesProjects, "maxUnusedVariablesProjects", defaults.MaxUnusedVariablesProjects, "Maximum number of projects to check for project specific environments for the "+organization.OctoLintUnusedVariables+" check. Set to 0 to report all projects for specific environments.")
+	flags.IntVar(&octolintConfig.MaxUndefinedVariablesProjects, "maxUndefinedVariablesProjects", defaults.MaxUndefinedVariablesProjects, "Maximum number of projects to check for references to undefined variables for the "+organization.OctoLintUndefinedVariables+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxProjectStepsProjects, "maxProjectStepsProjects", defaults.MaxProjectStepsProjects, "Maximum number of projects to check for project step counts for the "+organization.OctoLintTooManySteps+" check. Set to 0 to report all projects for their step counts.")
 	flags.IntVar(&octolintConfig.MaxExclusiveEnvironmentsProjects, "maxExclusiveEnvironmentsProjects", defaults.MaxExclusiveEnvironmentsProjects, "Maximum number of projects to check for exclusive environments for the "+organization.OctoLintProjectGroupsWithExclusiveEnvironments+" check. Set to 0 to report all projects with exclusive environments.")
 	flags.IntVar(&octolintConfig.MaxEmptyProjectCheckProjects, "maxEmptyProjectCheckProjects", defaults.MaxEmptyProjectCheckProjects, "Maximum number of projects to check for no steps for the "+organization.OctoLintEmptyProject+" check. Set to 0 to report all empty projects.")
@@ -64,15 +139,64 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 	flags.IntVar(&octolintConfig.MaxTenantTagsTenants, "maxTenantTagsTenants", defaults.MaxTenantTagsTenants, "Maximum number of tenants to check for potential tenant tags for the "+organization.OctoLintDirectTenantReferences+" check. Set to 0 to check all targets.")
 	flags.IntVar(&octolintConfig.MaxInvalidNameTargets, "maxInvalidNameTargets", defaults.MaxInvalidNameTargets, "Maximum number of targets to check for invalid names for the "+naming.OctoLintInvalidTargetNames+" check. Set to 0 to check all targets.")
 	flags.IntVar(&octolintConfig.MaxInsecureK8sTargets, "maxInsecureK8sTargets", defaults.MaxInsecureK8sTargets, "Maximum number of targets to check for insecure k8s configuration for the "+security.OctoLintInsecureK8sTargets+" check. Set to 0 to check all targets.")
-	flags.IntVar(&octolintConfig.MaxDeploymentTasks, "maxDeploymentTasks", defaults.MaxDeploymentTasks, "Maximum number of deployment tasks to scan for the "+performance.OctoLintDeploymentQueuedTime+" check. Set to 0 to check all targets.")
+	flags.IntVar(&octolintConfig.MaxDeploymentTasks, "maxDeploymentTasks", defaults.MaxDeploymentTasks, "Maximum number of deployment queued and started events to scan for the "+performance.OctoLintDeploymentQueuedTime+" check. Set to 0 to check all events.")
 	flags.IntVar(&octolintConfig.MaxSha1CertificatesMachines, "maxSha1CertificatesMachines", defaults.MaxSha1CertificatesMachines, "Maximum number of machines to check for SHA1 certificates for the "+security.OctoLintSha1Certificates+" check. Set to 0 to check all targets and workers.")
 	flags.StringVar(&octolintConfig.ContainerImageRegex, "containerImageRegex", "", "The regular expression used to validate container images for the "+naming.OctoLintContainerImageName+" check")
 	flags.StringVar(&octolintConfig.VariableNameRegex, "variableNameRegex", "", "The regular expression used to validate variable names for the "+naming.OctoLintInvalidVariableNames+" check")
 	flags.StringVar(&octolintConfig.TargetNameRegex, "targetNameRegex", "", "The regular expression used to validate target names for the "+naming.OctoLintInvalidTargetNames+" check")
 	flags.StringVar(&octolintConfig.TargetRoleRegex, "targetRoleRegex", "", "The regular expression used to validate target roles for the "+naming.OctoLintInvalidTargetRoles+" check")
 	flags.StringVar(&octolintConfig.ProjectReleaseTemplateRegex, "projectReleaseTemplateRegex", "", "The regular expression used to validate project release templates for the "+naming.OctoLintProjectReleaseTemplate+" check")
-	flags.StringVar(&octolintConfig.ProjectStepWorkerPoolRegex, "projectStepWorkerPoolRegex", "", "The regular expression used to validate step worker pools for the  "+naming.OctoLintProjectReleaseTemplate+" check")
-	flags.StringVar(&octolintConfig.LifecycleNameRegex, "lifecycleNameRegex", "", "The regular expression used to validate lifecycle names for the  "+naming.OctoLintInvalidLifecycleNames+" check")
+	flags.StringVar(&octolintConfig.ProjectStepWorkerPoolRegex, "projectStepWorkerPoolRegex", "", "The regular expression used to validate step worker pools for the "+naming.OctoLintProjectWorkerPool+" check")
+	flags.StringVar(&octolintConfig.ProjectStepWorkerPoolSlugRegex, "projectStepWorkerPoolSlugRegex", "", "The regular expression used to validate the slugs of step worker pools in the Config-as-Code files linted with the oclDirectory argument for the "+naming.OctoLintProjectWorkerPool+" check")
+	flags.StringVar(&octolintConfig.LifecycleNameRegex, "lifecycleNameRegex", "", "The regular expression used to validate lifecycle names for the "+naming.OctoLintInvalidLifecycleNames+" check")
+	flags.StringVar(&octolintConfig.WorkerNameRegex, "workerNameRegex", "", "The regular expression used to validate worker names for the "+naming.OctoLintInvalidWorkerNames+" check")
+	flags.StringVar(&octolintConfig.WorkerPoolNameRegex, "workerPoolNameRegex", "", "The regular expression used to validate worker pool names for the "+naming.OctoLintInvalidWorkerPoolNames+" check")
+	flags.StringVar(&octolintConfig.SpaceNameRegex, "spaceNameRegex", "", "The regular expression used to validate space names for the "+naming.OctoLintInvalidSpaceNames+" check")
+	flags.StringVar(&octolintConfig.LibraryVariableSetNameRegex, "libraryVariableSetNameRegex", "", "The regular expression used to validate library variable set names for the "+naming.OctoLintInvalidLibraryVariableSetNames+" check")
+	flags.StringVar(&octolintConfig.TenantNameRegex, "tenantNameRegex", "", "The regular expression used to validate tenant names for the "+naming.OctoLintInvalidTenantNames+" check")
+	flags.StringVar(&octolintConfig.TagSetNameRegex, "tagSetNameRegex", "", "The regular expression used to validate tag set names for the "+naming.OctoLintInvalidTagSetNames+" check")
+	flags.StringVar(&octolintConfig.TagNameRegex, "tagNameRegex", "", "The regular expression used to validate tag names for the "+naming.OctoLintInvalidTagNames+" check")
+	flags.StringVar(&octolintConfig.FeedNameRegex, "feedNameRegex", "", "The regular expression used to validate feed names for the "+naming.OctoLintInvalidFeedNames+" check")
+	flags.StringVar(&octolintConfig.AccountNameRegex, "accountNameRegex", "", "The regular expression used to validate account names for the "+naming.OctoLintInvalidAccountNames+" check")
+	flags.StringVar(&octolintConfig.MachinePolicyNameRegex, "machinePolicyNameRegex", "", "The regular expression used to validate machine policy names for the "+naming.OctoLintInvalidMachinePolicyNames+" check")
+	flags.StringVar(&octolintConfig.CertificateNameRegex, "certificateNameRegex", "", "The regular expression used to validate certificate names for the "+naming.OctoLintInvalidCertificateNames+" check")
+	flags.StringVar(&octolintConfig.GitCredentialNameRegex, "gitCredentialNameRegex", "", "The regular expression used to validate git credential names for the "+naming.OctoLintInvalidGitCredentialNames+" check")
+	flags.StringVar(&octolintConfig.ScriptModuleNameRegex, "scriptModuleNameRegex", "", "The regular expression used to validate script module names for the "+naming.OctoLintInvalidScriptModuleNames+" check")
+	flags.StringVar(&octolintConfig.ProjectGroupNameRegex, "projectGroupNameRegex", "", "The regular expression used to validate project group names for the "+naming.OctoLintInvalidProjectGroupNames+" check")
+	flags.StringVar(&octolintConfig.ProjectNameRegex, "projectNameRegex", "", "The regular expression used to validate project names for the "+naming.OctoLintInvalidProjectNames+" check")
+
+	flags.StringVar(&octolintConfig.HistoryFile, "historyFile", "", "The path of a file used to record the results of each scan. Leave blank to disable the scan history.")
+	flags.BoolVar(&octolintConfig.Trend, "trend", false, "Print the findings recorded in the historyFile over time instead of scanning the space")
+	flags.StringVar(&octolintConfig.TrendFormat, "trendFormat", "text", "The format of the trend report printed with the trend argument. Supported values are text, csv and json")
+
+	flags.BoolVar(&octolintConfig.Incremental, "incremental", false, "Only re-run the project, target, tenant, and library variable set checks against the resources that changed since the last scan, and reuse the findings saved in the incrementalCache for everything else. Space-wide checks are always run in full.")
+	flags.StringVar(&octolintConfig.IncrementalCache, "incrementalCache", defaults.IncrementalCache, "The file used to save the results of each scan for the incremental argument")
+
+	flags.StringVar(&octolintConfig.TraceExporter, "traceExporter", "", "Record an OpenTelemetry trace of the scan, with a span for each check and API request. Supported values are otlp, to send the spans to an OTLP/HTTP collector, and file, to write them to the traceFile. Leave blank to disable tracing.")
+	flags.StringVar(&octolintConfig.TraceFile, "traceFile", defaults.TraceFile, "The file that spans are written to when traceExporter is file")
+	flags.StringVar(&octolintConfig.TraceEndpoint, "traceEndpoint", "", "The URL of the OTLP/HTTP traces endpoint used when traceExporter is otlp. Defaults to the OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT environment variables, or http://localhost:4318/v1/traces.")
+
+	flags.Var(&octolintConfig.Plugins, "plugins", "The path of an executable that is run as an additional check. The plugin reads a JSON description of the scan from stdin and writes a JSON check result to stdout. The ID of the check is the file name of the plugin without the extension.")
+	flags.IntVar(&octolintConfig.PluginTimeout, "pluginTimeout", defaults.PluginTimeout, "The number of seconds a plugin can run before it is stopped and reported as an error")
+	flags.Var(&octolintConfig.PluginResources, "pluginResources", "A type of resource read from the space and passed to the plugins. Supported values are projects, environments, tenants, targets and workers.")
+
+	flags.Var(&octolintConfig.WebhookUrls, "webhookUrls", "A URL that a summary of the scan is posted to. Prefix the URL with json=, slack= or teams= to override the webhookFormat for that URL.")
+	flags.StringVar(&octolintConfig.WebhookFormat, "webhookFormat", "json", "The format of the webhook body. Supported values are json, slack and teams")
+	flags.StringVar(&octolintConfig.WebhookSeverity, "webhookSeverity", "", "Only post to the webhooks when a check fails with this severity or higher. Supported values are Error, Warning, and Info")
+	flags.BoolVar(&octolintConfig.WebhookOnChange, "webhookOnChange", false, "Only post to the webhooks when the findings changed since the last scan saved in the webhookStateFile. Combined with webhookSeverity, a post is sent when either condition is met.")
+	flags.StringVar(&octolintConfig.WebhookSecret, "webhookSecret", "", "The secret used to sign the webhook body. The HMAC-SHA256 signature is sent in the X-Octolint-Signature header.")
+	flags.IntVar(&octolintConfig.WebhookRetries, "webhookRetries", defaults.WebhookRetries, "The number of times a failed webhook post is retried")
+	flags.StringVar(&octolintConfig.WebhookStateFile, "webhookStateFile", defaults.WebhookStateFile, "The file that the results of the last scan are saved to for the webhookOnChange argument")
+
+	flags.StringVar(&octolintConfig.IssueTracker, "issueTracker", "", "Open an issue for each failed check, update it when the findings change, and close it when the check passes. Supported values are github and jira. Leave blank to disable the issue sync.")
+	flags.StringVar(&octolintConfig.IssueUrl, "issueUrl", "", "The base URL of the issue tracker REST API, e.g. https://api.github.com or https://example.atlassian.net")
+	flags.StringVar(&octolintConfig.IssueToken, "issueToken", "", "The token used to authenticate with the issue tracker")
+	flags.StringVar(&octolintConfig.IssueUsername, "issueUsername", "", "The username sent with the issueToken using basic authentication, as required by Jira Cloud. Leave blank to send the token as a bearer token.")
+	flags.StringVar(&octolintConfig.IssueRepository, "issueRepository", "", "The GitHub repository that issues are opened in, e.g. owner/repo")
+	flags.StringVar(&octolintConfig.IssueProject, "issueProject", "", "The key of the Jira project that issues are opened in")
+	flags.StringVar(&octolintConfig.IssueType, "issueType", defaults.IssueType, "The type of the Jira issues that are opened")
+	flags.StringVar(&octolintConfig.IssueGrouping, "issueGrouping", defaults.IssueGrouping, "Open an issue for each failed check, or for each project with findings. Supported values are check and project")
+	flags.Var(&octolintConfig.IssueLabels, "issueLabels", "A label added to each issue opened by octolint")
 
 	flags.Var(&octolintConfig.ExcludeProjects, "excludeProjects", "Exclude a project from being scanned.")
 	flags.Var(&octolintConfig.ExcludeProjectsRegex, "excludeProjectsRegex", "Exclude a project from being scanned.")
@@ -84,39 +208,24 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 	flags.StringVar(&octolintConfig.RedirecrtorApiKey, "redirecrtorApiKey", "", "The user api key of the redirector service")
 	flags.StringVar(&octolintConfig.RedirectorRedirections, "redirectorRedirections", "", "The redirection rules for the redirector service")
 
-	err := flags.Parse(args)
-
-	if octolintConfig.Help {
-		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
-		flags.SetOutput(os.Stdout)
-		flags.PrintDefaults()
-		os.Exit(0)
-	}
-
-	if err != nil {
-		return nil, err
-	}
+	return flags
+}
 
-	err = overrideArgs(flags, octolintConfig.ConfigPath, octolintConfig.ConfigFile)
+// Inspired by https://github.com/carolynvs/stingoftheviper
+// Viper needs manual handling to implement reading settings from env vars, config files, and from the command line
+func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) error {
+	v, err := readConfig(configPath, configFile)
 
 	if err != nil {
-		return nil, err
-	}
-
-	if octolintConfig.Url == "" {
-		octolintConfig.Url = os.Getenv("OCTOPUS_CLI_SERVER")
-	}
-
-	if octolintConfig.ApiKey == "" && octolintConfig.AccessToken == "" {
-		octolintConfig.ApiKey = os.Getenv("OCTOPUS_CLI_API_KEY")
+		return err
 	}
 
-	return &octolintConfig, nil
+	// Bind the current command's flags to viper
+	return bindFlags(flags, v)
 }
 
-// Inspired by https://github.com/carolynvs/stingoftheviper
-// Viper needs manual handling to implement reading settings from env vars, config files, and from the command line
-func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) error {
+// readConfig loads the settings from the config file and environment variables.
+func readConfig(configPath string, configFile string) (*viper.Viper, error) {
 	v := viper.New()
 
 	// Set the base name of the config file, without the file extension.
@@ -132,7 +241,7 @@ func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) err
 	if err := v.ReadInConfig(); err != nil {
 		// It's okay if there isn't a config file
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return err
+			return nil, err
 		}
 	}
 
@@ -151,8 +260,7 @@ func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) err
 	// like --favorite-color which we fix in the bindFlags function
 	v.AutomaticEnv()
 
-	// Bind the current command's flags to viper
-	return bindFlags(flags, v)
+	return v, nil
 }
 
 // Bind each flag to its associated viper configuration (config file and environment variable)
@@ -172,17 +280,68 @@ func bindFlags(flags *flag.FlagSet, v *viper.Viper) (funErr error) {
 
 			anyValue := v.Get(configName)
 
+			// Name the setting in the error, as the value may have come from the config file or an environment variable
+			setFlag := func(value string) {
+				if err := flags.Set(allFlags.Name, value); err != nil {
+					funcError = errors.Join(funcError, errors.New("The value \""+value+"\" of the "+allFlags.Name+" setting is invalid: "+err.Error()))
+				}
+			}
+
 			if types.IsArrayOrSlice(anyValue) {
 				for _, value := range v.GetStringSlice(configName) {
-					err := flags.Set(allFlags.Name, value)
-					funcError = errors.Join(funcError, err)
+					setFlag(value)
 				}
 			} else {
-				err := flags.Set(allFlags.Name, v.GetString(configName))
-				funcError = errors.Join(funcError, err)
+				setFlag(v.GetString(configName))
 			}
 		}
 	})
 
 	return funcError
 }
+
+// ParseDiffArgs parses the arguments passed to the diff command. The old and new reports can be passed
+// as positional arguments after any flags.
+func ParseDiffArgs(args []string) (*config.DiffConfig, error) {
+	flags := flag.NewFlagSet("octolint diff", flag.ContinueOnError)
+	var buf bytes.Buffer
+	flags.SetOutput(&buf)
+
+	diffConfig := config.DiffConfig{}
+
+	flags.BoolVar(&diffConfig.Help, "help", false, "Print usage")
+	flags.StringVar(&diffConfig.OldReport, "old", "", "The path to the JSON report to compare from")
+	flags.StringVar(&diffConfig.NewReport, "new", "", "The path to the JSON report to compare to")
+	flags.StringVar(&diffConfig.Format, "format", "text", "The format of the diff. Supported values are text and json")
+	flags.StringVar(&diffConfig.MatchBy, "matchBy", "id", "How findings are matched between reports. Use id to compare reports from the same instance, or name to compare reports from different instances")
+
+	err := flags.Parse(args)
+
+	if diffConfig.Help {
+		fmt.Fprintf(os.Stderr, "Usage of %s diff [flags] [old report] [new report]:\n", os.Args[0])
+		flags.SetOutput(os.Stdout)
+		flags.PrintDefaults()
+		os.Exit(0)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	positional := flags.Args()
+
+	if diffConfig.OldReport == "" && len(positional) > 0 {
+		diffConfig.OldReport = positional[0]
+		positional = positional[1:]
+	}
+
+	if diffConfig.NewReport == "" && len(positional) > 0 {
+		diffConfig.NewReport = positional[0]
+	}
+
+	if diffConfig.OldReport == "" || diffConfig.NewReport == "" {
+		return nil, errors.New("You must specify the two JSON reports to compare e.g. octolint diff old.json new.json")
+	}
+
+	return &diffConfig, nil
+}
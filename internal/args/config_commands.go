@@ -0,0 +1,163 @@
+package args
+
+import (
+	"flag"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// SecretArguments are the arguments whose values are redacted when the config is printed.
+var SecretArguments = []string{
+	"apiKey",
+	"accessToken",
+	"fixApiKey",
+	"redirectorServiceApiKey",
+	"redirecrtorApiKey",
+	"webhookSecret",
+	"webhookUrls",
+	"issueToken",
+}
+
+// redacted replaces the value of secret arguments in the printed config.
+const redacted = "*****"
+
+// ignoredEnvironmentVariables are environment variables with the octolint prefix that are not arguments.
+var ignoredEnvironmentVariables = []string{
+	"OCTOLINT_FUNCTIONS_CUSTOMHANDLER_PORT",
+}
+
+// CheckArguments returns the arguments that configure a check, which are the arguments whose usage names the check.
+func CheckArguments(checkId string) []*flag.Flag {
+	arguments := []*flag.Flag{}
+
+	newFlagSet(&config.OctolintConfig{}).VisitAll(func(f *flag.Flag) {
+		if strings.Contains(f.Usage, " "+checkId+" check") {
+			arguments = append(arguments, f)
+		}
+	})
+
+	return arguments
+}
+
+// ValidateConfig loads the settings from the command line, config file and environment variables, and returns a
+// description of each problem found. Settings that are not arguments are reported, as they are silently ignored by a
+// scan, along with values that can not be parsed and regular expressions that do not compile.
+func ValidateConfig(args []string) ([]string, error) {
+	octolintConfig := config.OctolintConfig{}
+	flags := newFlagSet(&octolintConfig)
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	v, err := readConfig(octolintConfig.ConfigPath, octolintConfig.ConfigFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{}
+
+	arguments := map[string]bool{}
+	flags.VisitAll(func(f *flag.Flag) {
+		arguments[strings.ToLower(f.Name)] = true
+	})
+
+	for _, key := range v.AllKeys() {
+		if !arguments[strings.Split(key, ".")[0]] {
+			problems = append(problems, "The setting \""+key+"\" in "+v.ConfigFileUsed()+" is not a known argument")
+		}
+	}
+
+	for _, variable := range os.Environ() {
+		name, _, _ := strings.Cut(variable, "=")
+
+		if !strings.HasPrefix(strings.ToUpper(name), "OCTOLINT_") || lo.Contains(ignoredEnvironmentVariables, strings.ToUpper(name)) {
+			continue
+		}
+
+		if !arguments[strings.ToLower(strings.TrimPrefix(strings.ToUpper(name), "OCTOLINT_"))] {
+			problems = append(problems, "The environment variable "+name+" is not a known argument")
+		}
+	}
+
+	if err := bindFlags(flags, v); err != nil {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+
+	flags.VisitAll(func(f *flag.Flag) {
+		if !strings.HasSuffix(f.Name, "Regex") {
+			return
+		}
+
+		for _, value := range argumentValues(f) {
+			if _, err := regexp.Compile(value); err != nil {
+				problems = append(problems, "The "+f.Name+" regular expression \""+value+"\" does not compile: "+err.Error())
+			}
+		}
+	})
+
+	return problems, nil
+}
+
+// EffectiveConfig returns the settings used by a scan with the supplied arguments as YAML, which can be saved as an
+// octolint.yaml config file. The values of secret arguments are redacted.
+func EffectiveConfig(args []string) (string, error) {
+	_, flags, err := parseArgs(args)
+
+	if err != nil {
+		return "", err
+	}
+
+	settings := map[string]any{}
+
+	flags.VisitAll(func(f *flag.Flag) {
+		if f.Name == "help" {
+			return
+		}
+
+		values := argumentValues(f)
+
+		if lo.Contains(SecretArguments, f.Name) && len(values) != 0 {
+			settings[f.Name] = redacted
+			return
+		}
+
+		if _, ok := f.Value.(*config.StringSliceArgs); ok {
+			settings[f.Name] = values
+			return
+		}
+
+		if getter, ok := f.Value.(flag.Getter); ok {
+			settings[f.Name] = getter.Get()
+		}
+	})
+
+	output, err := yaml.Marshal(settings)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// argumentValues returns the non-empty string values of an argument.
+func argumentValues(f *flag.Flag) []string {
+	if slice, ok := f.Value.(*config.StringSliceArgs); ok {
+		return append([]string{}, *slice...)
+	}
+
+	if getter, ok := f.Value.(flag.Getter); ok {
+		if value, ok := getter.Get().(string); ok && value != "" {
+			return []string{value}
+		}
+	}
+
+	return []string{}
+}
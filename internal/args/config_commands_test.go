@@ -0,0 +1,92 @@
+package args
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+)
+
+// writeConfig saves an octolint.yaml file to a temporary directory, and returns the arguments that load it.
+func writeConfig(t *testing.T, contents string) []string {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "octolint.yaml"), []byte(contents), 0644); err != nil {
+		t.Fatal("Should have written the config file: " + err.Error())
+	}
+
+	return []string{"-configPath", dir}
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Setenv("OCTOLINT_UNKNOWNSETTING", "true")
+	t.Setenv("OCTOLINT_MAXUNUSEDTARGETS", "5")
+
+	problems, err := ValidateConfig(writeConfig(t, "maxEnvironments: ten\nbogus: 1\nvariableNameRegex: \"[a-\"\nexcludeProjectsRegex:\n  - \"(\"\ntargetNameRegex: \"^[A-Z]\"\n"))
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	expected := []string{
+		"bogus",
+		"OCTOLINT_UNKNOWNSETTING",
+		"maxEnvironments",
+		"variableNameRegex",
+		"excludeProjectsRegex",
+	}
+
+	if len(problems) != len(expected) {
+		t.Fatal("Should have reported " + strings.Join(expected, ", ") + ", got:\n" + strings.Join(problems, "\n"))
+	}
+
+	for _, setting := range expected {
+		if !slices.ContainsFunc(problems, func(problem string) bool { return strings.Contains(problem, setting) }) {
+			t.Fatal("Should have reported the problem with " + setting)
+		}
+	}
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	problems, err := ValidateConfig(writeConfig(t, "maxEnvironments: 5\nvariableNameRegex: \"^[A-Z]\"\n"))
+
+	if err != nil || len(problems) != 0 {
+		t.Fatal("Should not have reported any problems, got:\n" + strings.Join(problems, "\n"))
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	effectiveConfig, err := EffectiveConfig(append(writeConfig(t, "apiKey: API-SECRET\nmaxEnvironments: 5\n"), "-space", "Spaces-2"))
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if strings.Contains(effectiveConfig, "API-SECRET") || !strings.Contains(effectiveConfig, "apiKey: '*****'") {
+		t.Fatal("Should have redacted the API key")
+	}
+
+	if !strings.Contains(effectiveConfig, "maxEnvironments: 5\n") || !strings.Contains(effectiveConfig, "space: Spaces-2\n") {
+		t.Fatal("Should have merged the config file and command line arguments")
+	}
+
+	if !strings.Contains(effectiveConfig, "fixApiKey: \"\"\n") {
+		t.Fatal("Should not have redacted empty secrets")
+	}
+}
+
+func TestCheckArguments(t *testing.T) {
+	arguments := CheckArguments(naming.OctoLintProjectWorkerPool)
+
+	names := []string{}
+	for _, argument := range arguments {
+		names = append(names, argument.Name)
+	}
+
+	if !slices.Equal(names, []string{"maxInvalidWorkerPoolProjects", "projectStepWorkerPoolRegex", "projectStepWorkerPoolSlugRegex"}) {
+		t.Fatal("Should have found the arguments that configure the check, got " + strings.Join(names, ", "))
+	}
+}
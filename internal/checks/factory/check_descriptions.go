@@ -0,0 +1,85 @@
+package factory
+
+import (
+	"sort"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+)
+
+// CheckDescription explains what a check looks for, and is displayed by the checks and explain commands. The full
+// documentation for each check is in the wiki.
+type CheckDescription struct {
+	Id          string
+	Category    string
+	Description string
+}
+
+var checkDescriptions = []CheckDescription{
+	{"OctoLintUnrotatedAccounts", checks.Security, "Reports accounts that have not been updated in 90 days. The credentials of accounts should be rotated regularly."},
+	{security.OctoLintDeploymentQueuedByAdmin, checks.Security, "Reports projects that were deployed by members of the administrator teams. Deployments should be performed by users with limited permissions."},
+	{"OctoLintPerpetualApiKeys", checks.Security, "Reports API keys that never expire. API keys should have an expiry date so leaked keys can not be used forever."},
+	{"OctoLintSharedGitUsername", checks.Security, "Reports Git usernames that are used by more than one Config-as-Code project. Sharing credentials between projects makes them hard to rotate and audit."},
+	{security.OctoLintInsecureK8sTargets, checks.Security, "Reports Kubernetes targets that skip TLS validation or connect to an insecure HTTP endpoint."},
+	{security.OctoLintInsecureFeeds, checks.Security, "Reports feeds that use an insecure HTTP endpoint."},
+	{"OctoLintInsecureWebhookUrls", checks.Security, "Reports subscriptions that send webhooks to an insecure HTTP URL."},
+	{security.OctoLintSha1Certificates, checks.Security, "Reports targets, workers and the Octopus Server that use a certificate signed with the deprecated SHA1 algorithm."},
+	{organization.OctopusEnvironmentCountCheckName, checks.Organization, "Reports spaces with more environments than the maxEnvironments argument. Many environments often indicate environments are being used to model tenants or regions."},
+	{"OctoLintDefaultProjectGroupChildCount", checks.Organization, "Reports spaces where many projects are left in the default project group. Project groups make large spaces easier to navigate."},
+	{organization.OctoLintEmptyProject, checks.Organization, "Reports projects that have no deployment process and no runbooks."},
+	{organization.OctoLintUnusedVariables, checks.Organization, "Reports project variables that are not referenced by any step or other variable. There are edge cases that can not be detected, so confirm the variables are unused before deleting them."},
+	{organization.OctoLintUndefinedVariables, checks.Organization, "Reports steps and variables that reference variables that are not defined. Octopus leaves references to undefined variables unsubstituted."},
+	{organization.OctoLintInvalidOutputVariables, checks.Organization, "Reports references to output variables of steps that do not exist, or that run after the step that references them."},
+	{organization.OctoLintDuplicatedVariables, checks.Organization, "Reports variables with the same name and value in more than one project. Shared variables are better defined once in a library variable set."},
+	{organization.OctoLintTooManySteps, checks.Organization, "Reports projects with 20 or more steps. Large deployment processes are hard to maintain and can often be split up."},
+	{organization.OctoRecLifecycleRetention, checks.Organization, "Reports lifecycles with retention policies that keep releases or files forever, which consumes disk space over time."},
+	{organization.OctoLintUnusedTargets, checks.Organization, "Reports targets that have not performed a deployment in the last 30 days."},
+	{organization.OctoLintProjectSpecificEnvs, checks.Organization, "Reports environments that are only used by a single project. Environments are usually shared by many projects."},
+	{organization.OctoLintDirectTenantReferences, checks.Organization, "Reports groups of tenants that are referenced directly by more than one resource. Tenant tags are easier to maintain than lists of tenants."},
+	{organization.OctoLintProjectGroupsWithExclusiveEnvironments, checks.Organization, "Reports project groups containing projects whose default lifecycles deploy to completely different environments, which suggests the projects belong in separate groups."},
+	{organization.OctoLintUnhealthyTargets, checks.Organization, "Reports targets that have not been healthy at any point in the last 30 days."},
+	{organization.OctopusUnusedProjectsCheckName, checks.Organization, "Reports projects that have not run a deployment or runbook in the number of days set with the maxDaysSinceLastTask argument."},
+	{organization.OctopusUnusedTenantsCheckName, checks.Organization, "Reports tenants that have not run a deployment or runbook in the number of days set with the maxDaysSinceLastTask argument."},
+	{performance.OctoLintDeploymentQueuedTime, checks.Performance, "Reports deployments that were queued for a long time before they started. Long queue times suggest the task cap should be increased or another node added."},
+	{naming.OctoLintContainerImageName, checks.Naming, "Reports steps that run in a container image that does not match the containerImageRegex argument."},
+	{naming.OctoLintInvalidVariableNames, checks.Naming, "Reports project variables with names that do not match the variableNameRegex argument."},
+	{naming.OctoLintInvalidTargetNames, checks.Naming, "Reports targets with names that do not match the targetNameRegex argument."},
+	{naming.OctoLintInvalidTargetRoles, checks.Naming, "Reports targets with roles that do not match the targetRoleRegex argument."},
+	{naming.OctoLintProjectReleaseTemplate, checks.Naming, "Reports projects with a release versioning template that does not match the projectReleaseTemplateRegex argument."},
+	{naming.OctoLintProjectWorkerPool, checks.Naming, "Reports steps that run on a worker pool with a name that does not match the projectStepWorkerPoolRegex argument, or a slug that does not match the projectStepWorkerPoolSlugRegex argument when linting OCL files."},
+	{naming.OctoLintInvalidLifecycleNames, checks.Naming, "Reports lifecycles with names that do not match the lifecycleNameRegex argument."},
+	{naming.OctoLintProjectDefaultStepNames, checks.Naming, "Reports steps that still use the default name of their step template, like \"Run a Script\". Descriptive step names make deployment logs easier to read."},
+}
+
+// CheckDescriptions returns the descriptions of all the checks, sorted by ID.
+func CheckDescriptions() []CheckDescription {
+	descriptions := append([]CheckDescription{}, checkDescriptions...)
+
+	for _, rule := range naming.ResourceNamingRules {
+		descriptions = append(descriptions, CheckDescription{
+			Id:          rule.Id,
+			Category:    checks.Naming,
+			Description: "Reports " + rule.Description + " with names that do not match the naming convention regex.",
+		})
+	}
+
+	sort.Slice(descriptions, func(i, j int) bool {
+		return descriptions[i].Id < descriptions[j].Id
+	})
+
+	return descriptions
+}
+
+// DescribeCheck returns the description of a check, and false if the check does not exist.
+func DescribeCheck(checkId string) (CheckDescription, bool) {
+	for _, description := range CheckDescriptions() {
+		if description.Id == checkId {
+			return description, true
+		}
+	}
+
+	return CheckDescription{}, false
+}
@@ -0,0 +1,25 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+func TestEveryCheckIsDescribed(t *testing.T) {
+	checkCollection, err := NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	for _, check := range checkCollection {
+		if description, ok := DescribeCheck(check.Id()); !ok || description.Category == "" || description.Description == "" {
+			t.Fatal("Should have described the check " + check.Id())
+		}
+	}
+
+	if len(CheckDescriptions()) != len(checkCollection) {
+		t.Fatal("Should not have described checks that do not exist")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/plugins"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/samber/lo"
@@ -38,6 +39,32 @@ func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]ch
 		return itemTrimmed, len(itemTrimmed) != 0
 	})
 
+	allChecks := o.builtInChecks(config)
+
+	pluginChecks, err := plugins.NewOctopusPluginChecks(o.client, config, o.url, o.space, o.errorHandler)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allChecks = append(allChecks, pluginChecks...)
+
+	return lo.Filter(allChecks, func(item checks.OctopusCheck, index int) bool {
+		return slices.Index(skipChecksSlice, item.Id()) == -1 &&
+			(len(onlyChecksSlice) == 0 || slices.Index(onlyChecksSlice, item.Id()) != -1)
+	}), nil
+}
+
+// BuildCheck creates a new instance of the built-in check with the ID, or returns false if there is no built-in check
+// with the ID. Plugin checks share the resources they load, so they are not built individually.
+func (o OctopusCheckFactory) BuildCheck(id string, config *config.OctolintConfig) (checks.OctopusCheck, bool) {
+	return lo.Find(o.builtInChecks(config), func(item checks.OctopusCheck) bool {
+		return item.Id() == id
+	})
+}
+
+// builtInChecks creates new instances of all the checks other than the plugin checks.
+func (o OctopusCheckFactory) builtInChecks(config *config.OctolintConfig) []checks.OctopusCheck {
 	allChecks := []checks.OctopusCheck{
 		security.NewOctopusUnrotatedAccountsCheck(o.client, config, o.errorHandler),
 		security.NewOctopusDeploymentQueuedByAdminCheck(o.client, config, o.errorHandler),
@@ -51,6 +78,8 @@ func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]ch
 		organization.NewOctopusDefaultProjectGroupCountCheck(o.client, config, o.errorHandler),
 		organization.NewOctopusEmptyProjectCheck(o.client, config, o.errorHandler),
 		organization.NewOctopusUnusedVariablesCheck(o.client, config, o.errorHandler),
+		organization.NewOctopusUndefinedVariablesCheck(o.client, config, o.errorHandler),
+		organization.NewOctopusOutputVariableReferencesCheck(o.client, config, o.errorHandler),
 		organization.NewOctopusDuplicatedVariablesCheck(o.client, config, o.errorHandler),
 		organization.NewOctopusProjectTooManyStepsCheck(o.client, config, o.errorHandler),
 		organization.NewOctopusLifecycleRetentionPolicyCheck(o.client, config, o.errorHandler),
@@ -72,8 +101,22 @@ func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]ch
 		naming.NewOctopusProjectDefaultStepNames(o.client, config, o.errorHandler),
 	}
 
-	return lo.Filter(allChecks, func(item checks.OctopusCheck, index int) bool {
-		return slices.Index(skipChecksSlice, item.Id()) == -1 &&
-			(len(onlyChecksSlice) == 0 || slices.Index(onlyChecksSlice, item.Id()) != -1)
+	allChecks = append(allChecks, naming.NewOctopusInvalidResourceNameChecks(o.client, config, o.errorHandler)...)
+
+	return allChecks
+}
+
+// BuildOfflineChecks creates new instances of the checks that can inspect Config-as-Code files without a
+// connection to an Octopus instance.
+func (o OctopusCheckFactory) BuildOfflineChecks(config *config.OctolintConfig) ([]checks.OctopusOfflineCheck, error) {
+	allChecks, err := o.BuildAllChecks(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.FilterMap(allChecks, func(item checks.OctopusCheck, index int) (checks.OctopusOfflineCheck, bool) {
+		offlineCheck, ok := item.(checks.OctopusOfflineCheck)
+		return offlineCheck, ok
 	}), nil
 }
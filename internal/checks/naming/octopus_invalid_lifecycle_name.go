@@ -7,9 +7,11 @@ import (
 	"strings"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 const OctoLintInvalidLifecycleNames = "OctoLintInvalidLifecycleNames"
@@ -33,15 +35,19 @@ func (o OctopusInvalidLifecycleName) Id() string {
 	return OctoLintInvalidLifecycleNames
 }
 
+func (o OctopusInvalidLifecycleName) RequiredPermissions() []string {
+	return []string{checks.PermissionLifecycleView}
+}
+
 func (o OctopusInvalidLifecycleName) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	if strings.TrimSpace(o.config.LifecycleNameRegex) == "" {
@@ -59,28 +65,36 @@ func (o OctopusInvalidLifecycleName) Execute(concurrency int) (checks.OctopusChe
 			checks.Naming), nil
 	}
 
-	lifecycles, err := o.client.Lifecycles.GetAll()
+	pager := client_wrapper.NewSpacePager[lifecycles.Lifecycle](o.client, o.client.GetSpaceID(), "lifecycles", o.config.PageSize, 0)
 
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
-	}
+	responses := []checks.OctopusCheckFinding{}
+	for l, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
+		}
 
-	responses := []string{}
-	for i, l := range lifecycles {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(lifecycles))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
 		if !regex.Match([]byte(l.Name)) {
-			responses = append(responses, l.Name)
+			responses = append(responses, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeLifecycle,
+				ResourceId:   l.ID,
+				ResourceName: l.Name,
+				Description:  l.Name,
+			})
 		}
 	}
 
+	coverage := checks.NewFullResourceCoverage(checks.ResourceTypeLifecycle, pager.Read())
+
 	if len(responses) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following lifecycle names do not match the regex "+o.config.LifecycleNameRegex+":\n"+strings.Join(responses, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following lifecycle names do not match the regex "+o.config.LifecycleNameRegex+":\n"+strings.Join(checks.FindingDescriptions(responses), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			responses).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -88,5 +102,5 @@ func (o OctopusInvalidLifecycleName) Execute(concurrency int) (checks.OctopusChe
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Naming), nil
+		checks.Naming).WithCoverage(coverage), nil
 }
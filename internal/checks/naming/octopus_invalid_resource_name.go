@@ -0,0 +1,444 @@
+package naming
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/samber/lo"
+)
+
+const OctoLintInvalidWorkerNames = "OctoLintInvalidWorkerNames"
+const OctoLintInvalidWorkerPoolNames = "OctoLintInvalidWorkerPoolNames"
+const OctoLintInvalidSpaceNames = "OctoLintInvalidSpaceNames"
+const OctoLintInvalidLibraryVariableSetNames = "OctoLintInvalidLibraryVariableSetNames"
+const OctoLintInvalidTenantNames = "OctoLintInvalidTenantNames"
+const OctoLintInvalidTagSetNames = "OctoLintInvalidTagSetNames"
+const OctoLintInvalidTagNames = "OctoLintInvalidTagNames"
+const OctoLintInvalidFeedNames = "OctoLintInvalidFeedNames"
+const OctoLintInvalidAccountNames = "OctoLintInvalidAccountNames"
+const OctoLintInvalidMachinePolicyNames = "OctoLintInvalidMachinePolicyNames"
+const OctoLintInvalidCertificateNames = "OctoLintInvalidCertificateNames"
+const OctoLintInvalidGitCredentialNames = "OctoLintInvalidGitCredentialNames"
+const OctoLintInvalidScriptModuleNames = "OctoLintInvalidScriptModuleNames"
+const OctoLintInvalidProjectGroupNames = "OctoLintInvalidProjectGroupNames"
+const OctoLintInvalidProjectNames = "OctoLintInvalidProjectNames"
+
+// NamedResource is a resource whose name is validated against a naming convention.
+type NamedResource struct {
+	Id         string
+	Name       string
+	ParentId   string
+	ParentName string
+}
+
+// ResourceNamingRule describes how the names of one type of resource are validated.
+type ResourceNamingRule struct {
+	// Id is the ID of the check that validates the resource names
+	Id string
+	// ResourceType is the type assigned to the findings
+	ResourceType string
+	// Description is the plural name of the resources used in the check results, for example "worker pools"
+	Description string
+	// Regex returns the regular expression that the resource names must match
+	Regex func(config *config.OctolintConfig) string
+	// Load returns the resources to validate
+	Load func(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error)
+	// Permissions are the space permissions needed to load the resources
+	Permissions []string
+}
+
+// ResourceNamingRules lists the resource types that are validated by OctopusInvalidResourceName checks.
+var ResourceNamingRules = []ResourceNamingRule{
+	{
+		Id:           OctoLintInvalidWorkerNames,
+		ResourceType: checks.ResourceTypeWorker,
+		Description:  "workers",
+		Regex:        func(config *config.OctolintConfig) string { return config.WorkerNameRegex },
+		Load:         loadWorkers,
+		Permissions:  []string{checks.PermissionWorkerView},
+	},
+	{
+		Id:           OctoLintInvalidWorkerPoolNames,
+		ResourceType: checks.ResourceTypeWorkerPool,
+		Description:  "worker pools",
+		Regex:        func(config *config.OctolintConfig) string { return config.WorkerPoolNameRegex },
+		Load:         loadWorkerPools,
+		Permissions:  []string{checks.PermissionWorkerView},
+	},
+	{
+		Id:           OctoLintInvalidSpaceNames,
+		ResourceType: checks.ResourceTypeSpace,
+		Description:  "spaces",
+		Regex:        func(config *config.OctolintConfig) string { return config.SpaceNameRegex },
+		Load:         loadSpace,
+		Permissions:  []string{},
+	},
+	{
+		Id:           OctoLintInvalidLibraryVariableSetNames,
+		ResourceType: checks.ResourceTypeLibraryVariableSet,
+		Description:  "library variable sets",
+		Regex:        func(config *config.OctolintConfig) string { return config.LibraryVariableSetNameRegex },
+		Load:         loadLibraryVariableSets,
+		Permissions:  []string{checks.PermissionLibraryVariableSetView},
+	},
+	{
+		Id:           OctoLintInvalidTenantNames,
+		ResourceType: checks.ResourceTypeTenant,
+		Description:  "tenants",
+		Regex:        func(config *config.OctolintConfig) string { return config.TenantNameRegex },
+		Load:         loadTenants,
+		Permissions:  []string{checks.PermissionTenantView},
+	},
+	{
+		Id:           OctoLintInvalidTagSetNames,
+		ResourceType: checks.ResourceTypeTagSet,
+		Description:  "tag sets",
+		Regex:        func(config *config.OctolintConfig) string { return config.TagSetNameRegex },
+		Load:         loadTagSets,
+		Permissions:  []string{},
+	},
+	{
+		Id:           OctoLintInvalidTagNames,
+		ResourceType: checks.ResourceTypeTag,
+		Description:  "tags",
+		Regex:        func(config *config.OctolintConfig) string { return config.TagNameRegex },
+		Load:         loadTags,
+		Permissions:  []string{},
+	},
+	{
+		Id:           OctoLintInvalidFeedNames,
+		ResourceType: checks.ResourceTypeFeed,
+		Description:  "feeds",
+		Regex:        func(config *config.OctolintConfig) string { return config.FeedNameRegex },
+		Load:         loadFeeds,
+		Permissions:  []string{checks.PermissionFeedView},
+	},
+	{
+		Id:           OctoLintInvalidAccountNames,
+		ResourceType: checks.ResourceTypeAccount,
+		Description:  "accounts",
+		Regex:        func(config *config.OctolintConfig) string { return config.AccountNameRegex },
+		Load:         loadAccounts,
+		Permissions:  []string{checks.PermissionAccountView},
+	},
+	{
+		Id:           OctoLintInvalidMachinePolicyNames,
+		ResourceType: checks.ResourceTypeMachinePolicy,
+		Description:  "machine policies",
+		Regex:        func(config *config.OctolintConfig) string { return config.MachinePolicyNameRegex },
+		Load:         loadMachinePolicies,
+		Permissions:  []string{checks.PermissionMachinePolicyView},
+	},
+	{
+		Id:           OctoLintInvalidCertificateNames,
+		ResourceType: checks.ResourceTypeCertificate,
+		Description:  "certificates",
+		Regex:        func(config *config.OctolintConfig) string { return config.CertificateNameRegex },
+		Load:         loadCertificates,
+		Permissions:  []string{checks.PermissionCertificateView},
+	},
+	{
+		Id:           OctoLintInvalidGitCredentialNames,
+		ResourceType: checks.ResourceTypeGitCredential,
+		Description:  "git credentials",
+		Regex:        func(config *config.OctolintConfig) string { return config.GitCredentialNameRegex },
+		Load:         loadGitCredentials,
+		Permissions:  []string{checks.PermissionGitCredentialView},
+	},
+	{
+		Id:           OctoLintInvalidScriptModuleNames,
+		ResourceType: checks.ResourceTypeScriptModule,
+		Description:  "script modules",
+		Regex:        func(config *config.OctolintConfig) string { return config.ScriptModuleNameRegex },
+		Load:         loadScriptModules,
+		Permissions:  []string{checks.PermissionLibraryVariableSetView},
+	},
+	{
+		Id:           OctoLintInvalidProjectGroupNames,
+		ResourceType: checks.ResourceTypeProjectGroup,
+		Description:  "project groups",
+		Regex:        func(config *config.OctolintConfig) string { return config.ProjectGroupNameRegex },
+		Load:         loadProjectGroups,
+		Permissions:  []string{checks.PermissionProjectGroupView},
+	},
+	{
+		Id:           OctoLintInvalidProjectNames,
+		ResourceType: checks.ResourceTypeProject,
+		Description:  "projects",
+		Regex:        func(config *config.OctolintConfig) string { return config.ProjectNameRegex },
+		Load:         loadProjects,
+		Permissions:  []string{checks.PermissionProjectView},
+	},
+}
+
+// OctopusInvalidResourceName checks if any resource of the type described by a ResourceNamingRule is named
+// incorrectly, according to a specified regular expression.
+type OctopusInvalidResourceName struct {
+	client       *client.Client
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	rule         ResourceNamingRule
+}
+
+func NewOctopusInvalidResourceName(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler, rule ResourceNamingRule) OctopusInvalidResourceName {
+	return OctopusInvalidResourceName{
+		client:       client,
+		errorHandler: errorHandler,
+		config:       config,
+		rule:         rule,
+	}
+}
+
+// NewOctopusInvalidResourceNameChecks creates a check for each of the ResourceNamingRules.
+func NewOctopusInvalidResourceNameChecks(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) []checks.OctopusCheck {
+	resourceNameChecks := []checks.OctopusCheck{}
+	for _, rule := range ResourceNamingRules {
+		resourceNameChecks = append(resourceNameChecks, NewOctopusInvalidResourceName(client, config, errorHandler, rule))
+	}
+	return resourceNameChecks
+}
+
+func (o OctopusInvalidResourceName) Id() string {
+	return o.rule.Id
+}
+
+func (o OctopusInvalidResourceName) RequiredPermissions() []string {
+	return o.rule.Permissions
+}
+
+// ScopedResourceType returns the resource type of the rule if incremental scans can limit the check to the resources
+// that changed since the last scan.
+func (o OctopusInvalidResourceName) ScopedResourceType() string {
+	switch o.rule.ResourceType {
+	case checks.ResourceTypeProject, checks.ResourceTypeTenant, checks.ResourceTypeLibraryVariableSet:
+		return o.rule.ResourceType
+	}
+
+	return ""
+}
+
+func (o OctopusInvalidResourceName) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
+
+	defer func() {
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
+	}()
+
+	regexString := o.rule.Regex(o.config)
+
+	if strings.TrimSpace(regexString) == "" {
+		return nil, nil
+	}
+
+	regex, err := regexp.Compile(regexString)
+
+	if err != nil {
+		return checks.NewOctopusCheckResultImpl(
+			"The supplied regex "+regexString+" does not compile",
+			o.Id(),
+			"",
+			checks.Error,
+			checks.Naming), nil
+	}
+
+	resources, err := o.rule.Load(o.client, o.config)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
+	}
+
+	resources = lo.Filter(resources, func(item NamedResource, index int) bool {
+		return o.inScope(item)
+	})
+
+	coverage := checks.NewFullResourceCoverage(o.rule.ResourceType, len(resources))
+
+	responses := []checks.OctopusCheckFinding{}
+	for i, r := range resources {
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(resources))*100) + "% complete")
+
+		if !regex.Match([]byte(r.Name)) {
+			description := r.Name
+			if r.ParentName != "" {
+				description = r.ParentName + "/" + r.Name
+			}
+
+			responses = append(responses, checks.OctopusCheckFinding{
+				ResourceType: o.rule.ResourceType,
+				ResourceId:   r.Id,
+				ResourceName: r.Name,
+				ParentId:     r.ParentId,
+				ParentName:   r.ParentName,
+				Description:  description,
+			})
+		}
+	}
+
+	if len(responses) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following "+o.rule.Description+" do not match the regex "+regexString+":\n"+strings.Join(checks.FindingDescriptions(responses), "\n"),
+			o.Id(),
+			"",
+			checks.Warning,
+			checks.Naming,
+			responses).WithCoverage(coverage), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"All "+o.rule.Description+" match the regex "+regexString,
+		o.Id(),
+		"",
+		checks.Ok,
+		checks.Naming).WithCoverage(coverage), nil
+}
+
+// inScope returns true if the resource should be inspected by an incremental scan. Resource types that are not
+// scoped are always inspected.
+func (o OctopusInvalidResourceName) inScope(resource NamedResource) bool {
+	switch o.rule.ResourceType {
+	case checks.ResourceTypeProject:
+		return o.config.Scope.IncludesProject(resource.Id)
+	case checks.ResourceTypeTenant:
+		return o.config.Scope.IncludesTenant(resource.Id)
+	case checks.ResourceTypeLibraryVariableSet:
+		return o.config.Scope.IncludesLibraryVariableSet(resource.Id)
+	}
+
+	return true
+}
+
+// namedResource is the subset of a resource returned by the API that the naming checks inspect. Every resource has
+// an ID and name, so any collection can be decoded as named resources, including collections like feeds and accounts
+// that contain several resource types.
+type namedResource struct {
+	Id   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+// namedTagSet is a tag set and the tags it contains.
+type namedTagSet struct {
+	namedResource
+	Tags []namedResource `json:"Tags"`
+}
+
+// loadNamedResources reads the ID and name of each resource in a collection of the space, one page at a time.
+func loadNamedResources(client *client.Client, config *config.OctolintConfig, collection string) ([]NamedResource, error) {
+	pager := client_wrapper.NewSpacePager[namedResource](client, client.GetSpaceID(), collection, config.PageSize, 0)
+
+	resources := []NamedResource{}
+	for resource, err := range pager.Items() {
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, NamedResource{Id: resource.Id, Name: resource.Name})
+	}
+	return resources, nil
+}
+
+func loadWorkers(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "workers")
+}
+
+func loadWorkerPools(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "workerpools")
+}
+
+// loadSpace returns the space being scanned, as the other spaces on the instance are out of scope.
+func loadSpace(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	space, err := client.Spaces.GetByID(client.GetSpaceID())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []NamedResource{{Id: space.ID, Name: space.Name}}, nil
+}
+
+func loadLibraryVariableSets(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	// Script modules are returned as library variable sets, but are validated by their own check
+	return loadNamedResources(client, config, "libraryvariablesets?contentType=Variables")
+}
+
+func loadTenants(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "tenants")
+}
+
+func loadTagSets(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "tagsets")
+}
+
+func loadTags(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	pager := client_wrapper.NewSpacePager[namedTagSet](client, client.GetSpaceID(), "tagsets", config.PageSize, 0)
+
+	resources := []NamedResource{}
+	for tagSet, err := range pager.Items() {
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tagSet.Tags {
+			resources = append(resources, NamedResource{Id: tag.Id, Name: tag.Name, ParentId: tagSet.Id, ParentName: tagSet.Name})
+		}
+	}
+	return resources, nil
+}
+
+func loadFeeds(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "feeds")
+}
+
+func loadAccounts(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "accounts")
+}
+
+func loadMachinePolicies(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "machinepolicies")
+}
+
+func loadCertificates(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "certificates")
+}
+
+func loadGitCredentials(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "git-credentials")
+}
+
+func loadScriptModules(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "libraryvariablesets?contentType=ScriptModule")
+}
+
+func loadProjectGroups(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	return loadNamedResources(client, config, "projectgroups")
+}
+
+func loadProjects(client *client.Client, config *config.OctolintConfig) ([]NamedResource, error) {
+	projects, _, err := client_wrapper.GetProjectsWithFilter(
+		client,
+		client.GetSpaceID(),
+		config.ExcludeProjectsExcept,
+		config.ExcludeProjects,
+		0,
+		config.PageSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []NamedResource{}
+	for _, project := range projects {
+		resources = append(resources, NamedResource{Id: project.ID, Name: project.Name})
+	}
+	return resources, nil
+}
@@ -0,0 +1,280 @@
+package naming
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/octoclient"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/test"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func namingRule(id string) ResourceNamingRule {
+	for _, rule := range ResourceNamingRules {
+		if rule.Id == id {
+			return rule
+		}
+	}
+	panic("no naming rule for " + id)
+}
+
+func TestTagSetsInvalidName(t *testing.T) {
+	testFramework := test.OctopusContainerTest{}
+	testFramework.ArrangeTest(t, func(t *testing.T, container *test.OctopusContainer, client *client.Client) error {
+		// Act
+		newSpaceId, err := testFramework.Act(
+			t,
+			container,
+			filepath.Join("..", "..", "..", "test", "terraform"), "28-tenanttags", []string{})
+
+		if err != nil {
+			return err
+		}
+
+		newSpaceClient, err := octoclient.CreateClient(container.URI, newSpaceId, test.ApiKey)
+
+		if err != nil {
+			return err
+		}
+
+		octolintConfig := &config.OctolintConfig{
+			TagSetNameRegex: "thiswontmatch",
+			TagNameRegex:    ".*",
+		}
+
+		tagSetCheck := NewOctopusInvalidResourceName(newSpaceClient, octolintConfig, checks.OctopusClientPermissiveErrorHandler{}, namingRule(OctoLintInvalidTagSetNames))
+
+		result, err := tagSetCheck.Execute(2)
+
+		// Assert
+		if err != nil {
+			return err
+		}
+
+		if result == nil || result.Severity() != checks.Warning {
+			return errors.New("check should have produced a warning")
+		}
+
+		tagCheck := NewOctopusInvalidResourceName(newSpaceClient, octolintConfig, checks.OctopusClientPermissiveErrorHandler{}, namingRule(OctoLintInvalidTagNames))
+
+		result, err = tagCheck.Execute(2)
+
+		if err != nil {
+			return err
+		}
+
+		if result == nil || result.Severity() != checks.Ok {
+			return errors.New("check should have succeeded")
+		}
+
+		return nil
+	})
+}
+
+func TestResourceNameRegexNotSet(t *testing.T) {
+	testFramework := test.OctopusContainerTest{}
+	testFramework.ArrangeTest(t, func(t *testing.T, container *test.OctopusContainer, client *client.Client) error {
+		for _, check := range NewOctopusInvalidResourceNameChecks(client, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{}) {
+			result, err := check.Execute(2)
+
+			if err != nil {
+				return err
+			}
+
+			if result != nil {
+				return errors.New("check " + check.Id() + " should not have run without a regex")
+			}
+		}
+
+		return nil
+	})
+}
+
+// newNamedResourceServer returns a server that responds to the requests made when a client is created, and returns
+// the resources for each collection path. The status code is returned for the collection paths in failures.
+func newNamedResourceServer(t *testing.T, collections map[string]any, failures map[string]int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if contentType := r.URL.Query().Get("contentType"); contentType != "" {
+			path += "?contentType=" + contentType
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if status, ok := failures[path]; ok {
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]any{"ErrorMessage": "The request failed"})
+			return
+		}
+
+		switch path {
+		case "/api", "/api/", "/api/Spaces-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Links": map[string]string{"Spaces": "/api/spaces{/id}{?skip,ids,take,partialName}"}})
+		case "/api/spaces/Spaces-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Id": "Spaces-1", "Name": "default"})
+		default:
+			items, ok := collections[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalResults": 2})
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// namedResources returns a resource that matches the regex used by the tests, and one that does not.
+func namedResources(prefix string) []map[string]any {
+	return []map[string]any{{"Id": prefix + "-1", "Name": "Valid"}, {"Id": prefix + "-2", "Name": "invalid"}}
+}
+
+func TestResourceNamingRuleLoaders(t *testing.T) {
+	regex := "^[A-Z]"
+	octolintConfig := &config.OctolintConfig{
+		WorkerNameRegex:             regex,
+		WorkerPoolNameRegex:         regex,
+		SpaceNameRegex:              regex,
+		LibraryVariableSetNameRegex: regex,
+		TenantNameRegex:             regex,
+		TagSetNameRegex:             regex,
+		TagNameRegex:                regex,
+		FeedNameRegex:               regex,
+		AccountNameRegex:            regex,
+		MachinePolicyNameRegex:      regex,
+		CertificateNameRegex:        regex,
+		GitCredentialNameRegex:      regex,
+		ScriptModuleNameRegex:       regex,
+		ProjectGroupNameRegex:       regex,
+		ProjectNameRegex:            regex,
+		PageSize:                    30,
+	}
+
+	server := newNamedResourceServer(t, map[string]any{
+		"/api/Spaces-1/workers":                                      namedResources("Workers"),
+		"/api/Spaces-1/workerpools":                                  namedResources("WorkerPools"),
+		"/api/Spaces-1/libraryvariablesets?contentType=Variables":    namedResources("LibraryVariableSets"),
+		"/api/Spaces-1/libraryvariablesets?contentType=ScriptModule": namedResources("ScriptModules"),
+		"/api/Spaces-1/tenants":                                      namedResources("Tenants"),
+		"/api/Spaces-1/feeds":                                        namedResources("Feeds"),
+		"/api/Spaces-1/accounts":                                     namedResources("Accounts"),
+		"/api/Spaces-1/machinepolicies":                              namedResources("MachinePolicies"),
+		"/api/Spaces-1/certificates":                                 namedResources("Certificates"),
+		"/api/Spaces-1/git-credentials":                              namedResources("GitCredentials"),
+		"/api/Spaces-1/projectgroups":                                namedResources("ProjectGroups"),
+		"/api/Spaces-1/projects": []map[string]any{
+			{"Id": "Projects-1", "Name": "Valid", "LifecycleId": "Lifecycles-1", "ProjectGroupId": "ProjectGroups-1"},
+			{"Id": "Projects-2", "Name": "invalid", "LifecycleId": "Lifecycles-1", "ProjectGroupId": "ProjectGroups-1"},
+		},
+		"/api/Spaces-1/tagsets": []map[string]any{
+			{"Id": "TagSets-1", "Name": "Valid", "Tags": namedResources("TagSets-1/Tags")},
+			{"Id": "TagSets-2", "Name": "invalid", "Tags": []map[string]any{}},
+		},
+	}, nil)
+
+	newSpaceClient, err := octoclient.CreateClient(server.URL, "Spaces-1", test.ApiKey)
+
+	if err != nil {
+		t.Fatal("Should have created the client: " + err.Error())
+	}
+
+	tests := []struct {
+		id         string
+		resourceId string
+	}{
+		{OctoLintInvalidWorkerNames, "Workers-2"},
+		{OctoLintInvalidWorkerPoolNames, "WorkerPools-2"},
+		{OctoLintInvalidSpaceNames, "Spaces-1"},
+		{OctoLintInvalidLibraryVariableSetNames, "LibraryVariableSets-2"},
+		{OctoLintInvalidTenantNames, "Tenants-2"},
+		{OctoLintInvalidTagSetNames, "TagSets-2"},
+		{OctoLintInvalidTagNames, "TagSets-1/Tags-2"},
+		{OctoLintInvalidFeedNames, "Feeds-2"},
+		{OctoLintInvalidAccountNames, "Accounts-2"},
+		{OctoLintInvalidMachinePolicyNames, "MachinePolicies-2"},
+		{OctoLintInvalidCertificateNames, "Certificates-2"},
+		{OctoLintInvalidGitCredentialNames, "GitCredentials-2"},
+		{OctoLintInvalidScriptModuleNames, "ScriptModules-2"},
+		{OctoLintInvalidProjectGroupNames, "ProjectGroups-2"},
+		{OctoLintInvalidProjectNames, "Projects-2"},
+	}
+
+	if len(tests) != len(ResourceNamingRules) {
+		t.Fatal("Should have a test for each naming rule")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			check := NewOctopusInvalidResourceName(newSpaceClient, octolintConfig, checks.OctopusClientPermissiveErrorHandler{}, namingRule(tt.id))
+
+			result, err := check.Execute(2)
+
+			if err != nil {
+				t.Fatal("Should not have returned an error: " + err.Error())
+			}
+
+			if result == nil || result.Severity() != checks.Warning {
+				t.Fatal("Check should have produced a warning")
+			}
+
+			if len(result.Findings()) != 1 || result.Findings()[0].ResourceId != tt.resourceId {
+				t.Fatal("Check should have reported " + tt.resourceId + ", got " + result.Description())
+			}
+		})
+	}
+}
+
+func TestResourceNamingRuleLoadErrors(t *testing.T) {
+	server := newNamedResourceServer(t, map[string]any{}, map[string]int{
+		"/api/Spaces-1/feeds":    http.StatusForbidden,
+		"/api/Spaces-1/accounts": http.StatusInternalServerError,
+	})
+
+	newSpaceClient, err := octoclient.CreateClient(server.URL, "Spaces-1", test.ApiKey)
+
+	if err != nil {
+		t.Fatal("Should have created the client: " + err.Error())
+	}
+
+	octolintConfig := &config.OctolintConfig{FeedNameRegex: ".*", AccountNameRegex: ".*", PageSize: 30}
+
+	tests := []struct {
+		id       string
+		severity int
+		err      bool
+	}{
+		{OctoLintInvalidFeedNames, checks.Permission, false},
+		{OctoLintInvalidAccountNames, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			check := NewOctopusInvalidResourceName(newSpaceClient, octolintConfig, checks.OctopusClientPermissiveErrorHandler{}, namingRule(tt.id))
+
+			result, err := check.Execute(2)
+
+			if tt.err {
+				if err == nil {
+					t.Fatal("Should have returned the error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Should not have returned an error: " + err.Error())
+			}
+
+			if result == nil || result.Severity() != tt.severity {
+				t.Fatal("Check should have reported the permission error")
+			}
+		})
+	}
+}
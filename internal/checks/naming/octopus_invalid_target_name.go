@@ -10,7 +10,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 const OctoLintInvalidTargetNames = "OctoLintInvalidTargetNames"
@@ -34,15 +34,23 @@ func (o OctopusInvalidTargetName) Id() string {
 	return OctoLintInvalidTargetNames
 }
 
+func (o OctopusInvalidTargetName) RequiredPermissions() []string {
+	return []string{checks.PermissionMachineView}
+}
+
+func (o OctopusInvalidTargetName) ScopedResourceType() string {
+	return checks.ResourceTypeTarget
+}
+
 func (o OctopusInvalidTargetName) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	if strings.TrimSpace(o.config.TargetNameRegex) == "" {
@@ -60,28 +68,33 @@ func (o OctopusInvalidTargetName) Execute(concurrency int) (checks.OctopusCheckR
 			checks.Naming), nil
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidNameTargets, o.client, o.client.GetSpaceID())
+	allMachines, availableMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidNameTargets, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	responses := []string{}
+	allMachines = client_wrapper.ScopedMachines(allMachines, o.config.Scope)
+
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeTarget, availableMachines, len(allMachines), 0)
+
+	responses := []checks.OctopusCheckFinding{}
 	for i, m := range allMachines {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
 
 		if !regex.Match([]byte(m.Name)) {
-			responses = append(responses, m.Name)
+			responses = append(responses, checks.NewTargetFinding(m))
 		}
 	}
 
 	if len(responses) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following target names do not match the regex "+o.config.TargetNameRegex+":\n"+strings.Join(responses, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following target names do not match the regex "+o.config.TargetNameRegex+":\n"+strings.Join(checks.FindingDescriptions(responses), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			responses).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -89,5 +102,5 @@ func (o OctopusInvalidTargetName) Execute(concurrency int) (checks.OctopusCheckR
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Naming), nil
+		checks.Naming).WithCoverage(coverage), nil
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"regexp"
 	"strings"
 )
@@ -33,15 +33,23 @@ func (o OctopusInvalidTargetRole) Id() string {
 	return OctoLintInvalidTargetRoles
 }
 
+func (o OctopusInvalidTargetRole) RequiredPermissions() []string {
+	return []string{checks.PermissionMachineView}
+}
+
+func (o OctopusInvalidTargetRole) ScopedResourceType() string {
+	return checks.ResourceTypeTarget
+}
+
 func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	if strings.TrimSpace(o.config.TargetRoleRegex) == "" {
@@ -59,15 +67,19 @@ func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckR
 			checks.Naming), nil
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidRoleTargets, o.client, o.client.GetSpaceID())
+	allMachines, availableMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidRoleTargets, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	responses := []string{}
+	allMachines = client_wrapper.ScopedMachines(allMachines, o.config.Scope)
+
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeTarget, availableMachines, len(allMachines), 0)
+
+	responses := []checks.OctopusCheckFinding{}
 	for i, m := range allMachines {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
 
 		invalidRoles := []string{}
 		for _, r := range m.Roles {
@@ -77,17 +89,20 @@ func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckR
 		}
 
 		if len(invalidRoles) != 0 {
-			responses = append(responses, m.Name+" - "+strings.Join(invalidRoles, ","))
+			finding := checks.NewTargetFinding(m)
+			finding.Description = m.Name + " - " + strings.Join(invalidRoles, ",")
+			responses = append(responses, finding)
 		}
 	}
 
 	if len(responses) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following target roles do not match the regex "+o.config.TargetRoleRegex+":\n"+strings.Join(responses, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following target roles do not match the regex "+o.config.TargetRoleRegex+":\n"+strings.Join(checks.FindingDescriptions(responses), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			responses).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -95,5 +110,5 @@ func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckR
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Naming), nil
+		checks.Naming).WithCoverage(coverage), nil
 }
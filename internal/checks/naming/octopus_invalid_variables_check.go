@@ -6,24 +6,19 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
-var linkOptions = regexp.MustCompile(`\{.*?}`)
-
 const OctoLintInvalidVariableNames = "OctoLintInvalidVariableNames"
 
 // OctopusInvalidVariableNameCheck checks to see if any project variables are named incorrectly, according to a specified regular expression.
@@ -45,44 +40,51 @@ func (o OctopusInvalidVariableNameCheck) Id() string {
 	return OctoLintInvalidVariableNames
 }
 
+func (o OctopusInvalidVariableNameCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionVariableView}
+}
+
+func (o OctopusInvalidVariableNameCheck) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidVariableProjects)
+		o.config.MaxInvalidVariableProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	regex, err := regexp.Compile(o.config.VariableNameRegex)
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
 
-	if err != nil {
-		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.VariableNameRegex+" does not compile",
-			o.Id(),
-			"",
-			checks.Error,
-			checks.Naming), nil
+	regex, result := o.compileRegex()
+
+	if regex == nil {
+		return result, nil
 	}
 
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	messages := threadsafe.NewSlice[string]()
+	messages := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 
@@ -90,26 +92,21 @@ func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.Octopu
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			variableSet, err := o.client.Variables.GetAll(p.ID)
+			variableSet, err := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize).GetVariables(p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			for _, v := range variableSet.Variables {
-				if checks.IgnoreVariable(v.Name) {
-					continue
-				}
-
-				if !regex.Match([]byte(v.Name)) {
-					messages.Append(p.Name + ": " + v.Name)
-				}
-
+			for _, finding := range o.findings(regex, p, variableSet) {
+				messages.Append(finding)
 			}
 
 			return nil
@@ -125,98 +122,75 @@ func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.Octopu
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, goroutineErrors.Values()[0])
 	}
 
-	if messages.Length() > 0 {
+	return o.result(messages.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following variables do not match the regex "+o.config.VariableNameRegex+":\n"+strings.Join(messages.Values(), "\n"),
-			o.Id(),
-			"",
-			checks.Warning,
-			checks.Naming), nil
+func (o OctopusInvalidVariableNameCheck) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	regex, result := o.compileRegex()
+
+	if regex == nil {
+		return result, nil
 	}
 
-	return checks.NewOctopusCheckResultImpl(
-		"There are no unused variables",
-		o.Id(),
-		"",
-		checks.Ok,
-		checks.Naming), nil
+	messages := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		messages = append(messages, p.Locate(o.findings(regex, p.Project, p.Variables))...)
+	}
+
+	return o.result(messages, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
 }
 
-func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(p *projects2.Project) ([]*deployments.DeploymentStep, error) {
-	deploymentProcesses := []*deployments.DeploymentStep{}
-	deploymentProcess, err := o.client.DeploymentProcesses.GetByID(p.DeploymentProcessID)
+// compileRegex returns the variable name regex, or nil and the result to return if the regex does not compile.
+func (o OctopusInvalidVariableNameCheck) compileRegex() (*regexp.Regexp, checks.OctopusCheckResult) {
+	regex, err := regexp.Compile(o.config.VariableNameRegex)
 
 	if err != nil {
-		if !o.errorHandler.ShouldContinue(err) {
-			return nil, err
-		}
-	} else {
-		if deploymentProcess != nil && deploymentProcess.Steps != nil {
-			deploymentProcesses = append(deploymentProcesses, deploymentProcess.Steps...)
-		}
+		return nil, checks.NewOctopusCheckResultImpl(
+			"The supplied regex "+o.config.VariableNameRegex+" does not compile",
+			o.Id(),
+			"",
+			checks.Error,
+			checks.Naming)
 	}
 
-	if link, ok := p.Links["Runbooks"]; ok {
-		runbooks, err := newclient.Get[resources.Resources[runbooks.Runbook]](o.client.HttpSession(), linkOptions.ReplaceAllString(link, ""))
-
-		if err != nil {
-			if !o.errorHandler.ShouldContinue(err) {
-				return nil, err
-			}
-		}
+	return regex, nil
+}
 
-		for _, runbook := range runbooks.Items {
-			runbookProcess, err := o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID)
+func (o OctopusInvalidVariableNameCheck) findings(regex *regexp.Regexp, project *projects.Project, variableSet *variables.VariableSet) []checks.OctopusCheckFinding {
+	findings := []checks.OctopusCheckFinding{}
 
-			if err != nil {
-				if !o.errorHandler.ShouldContinue(err) {
-					return nil, err
-				}
-				continue
-			} else {
-				if runbookProcess != nil && runbookProcess.Steps != nil {
-					deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
-				}
-			}
-		}
+	if variableSet == nil {
+		return findings
 	}
 
-	return deploymentProcesses, nil
-}
-
-// naiveStepVariableScan does a simple text search for the variable in a steps properties. This does lead to false positives as simple variables names, like "a",
-// will almost certainly appear in a step property text without necessarily being referenced as a variable.
-func (o OctopusInvalidVariableNameCheck) naiveStepVariableScan(deploymentSteps []*deployments.DeploymentStep, variable *variables.Variable) bool {
-	if deploymentSteps != nil {
-		for _, s := range deploymentSteps {
-			for _, a := range s.Actions {
-				for _, p := range a.Properties {
-					if strings.Index(p.Value, variable.Name) != -1 {
-						return true
-					}
-				}
+	for _, v := range variableSet.Variables {
+		if checks.IgnoreVariable(v.Name) {
+			continue
+		}
 
-				// Packages and feeds can use variables
-				for _, p := range a.Packages {
-					if strings.Index(p.FeedID, variable.Name) != -1 || strings.Index(p.PackageID, variable.Name) != -1 {
-						return true
-					}
-				}
-			}
+		if !regex.Match([]byte(v.Name)) {
+			findings = append(findings, checks.NewProjectVariableFinding(project, v))
 		}
 	}
 
-	return false
+	return findings
 }
 
-// naiveVariableSetVariableScan does a simple text search for the variable in the value of other variables
-func (o OctopusInvalidVariableNameCheck) naiveVariableSetVariableScan(variables variables.VariableSet, variable *variables.Variable) bool {
-	for _, v := range variables.Variables {
-		if strings.Index(v.Value, variable.Name) != -1 {
-			return true
-		}
+func (o OctopusInvalidVariableNameCheck) result(messages []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(messages) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following variables do not match the regex "+o.config.VariableNameRegex+":\n"+strings.Join(checks.FindingDescriptions(messages), "\n"),
+			o.Id(),
+			"",
+			checks.Warning,
+			checks.Naming,
+			messages).WithCoverage(coverage)
 	}
 
-	return false
+	return checks.NewOctopusCheckResultImpl(
+		"There are no unused variables",
+		o.Id(),
+		"",
+		checks.Ok,
+		checks.Naming).WithCoverage(coverage)
 }
@@ -7,12 +7,10 @@ import (
 	"strings"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 const OctoLintProjectReleaseTemplate = "OctoLintProjectReleaseTemplate"
@@ -36,15 +34,23 @@ func (o OctopusProjectReleaseTemplateRegex) Id() string {
 	return OctoLintProjectReleaseTemplate
 }
 
+func (o OctopusProjectReleaseTemplateRegex) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView}
+}
+
+func (o OctopusProjectReleaseTemplateRegex) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o OctopusProjectReleaseTemplateRegex) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	if strings.TrimSpace(o.config.ProjectReleaseTemplateRegex) == "" {
@@ -63,33 +69,41 @@ func (o OctopusProjectReleaseTemplateRegex) Execute(concurrency int) (checks.Oct
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidReleaseTemplateProjects)
+		o.config.MaxInvalidReleaseTemplateProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	results := []string{}
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), 0)
+
+	results := []checks.OctopusCheckFinding{}
 	for i, p := range projects {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
 		if p.VersioningStrategy != nil && !regex.Match([]byte(p.VersioningStrategy.Template)) {
-			results = append(results, p.Name+" - "+p.VersioningStrategy.Template)
+			finding := checks.NewProjectFinding(p)
+			finding.Description = p.Name + " - " + p.VersioningStrategy.Template
+			results = append(results, finding)
 		}
 	}
 
 	if len(results) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project release templates do not match the regex "+o.config.ProjectReleaseTemplateRegex+":\n"+strings.Join(results, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following project release templates do not match the regex "+o.config.ProjectReleaseTemplateRegex+":\n"+strings.Join(checks.FindingDescriptions(results), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			results).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -97,23 +111,5 @@ func (o OctopusProjectReleaseTemplateRegex) Execute(concurrency int) (checks.Oct
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Naming), nil
-}
-
-func (o OctopusProjectReleaseTemplateRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
-		return nil, nil
-	}
-
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
-
-	if err != nil {
-		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
-			return nil, nil
-		}
-		return nil, err
-	}
-
-	return resource, nil
+		checks.Naming).WithCoverage(coverage), nil
 }
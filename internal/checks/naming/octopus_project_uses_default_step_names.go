@@ -5,16 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	"strings"
+	"sync/atomic"
 )
 
 const OctoLintProjectDefaultStepNames = "OctoLintProjectDefaultStepNames"
@@ -38,60 +39,66 @@ func (o OctopusProjectDefaultStepNames) Id() string {
 	return OctoLintProjectDefaultStepNames
 }
 
+func (o OctopusProjectDefaultStepNames) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView}
+}
+
+func (o OctopusProjectDefaultStepNames) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxDefaultStepNameProjects)
+		o.config.MaxDefaultStepNameProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	actionsWithDefaultNames := threadsafe.NewSlice[string]()
+	actionsWithDefaultNames := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 		i := i
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			steps, runbookProcesses, err := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize).GetAllSteps(p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			if deploymentProcess == nil {
-				return nil
-			}
-
-			for _, s := range deploymentProcess.Steps {
-				for _, a := range s.Actions {
-					if slices.Index(checks.DefaultStepNames, a.Name) != -1 {
-						actionsWithDefaultNames.Append(p.Name + "/" + a.Name)
-					}
-				}
+			for _, finding := range checks.RunbookActionFindings(o.findings(p, steps), runbookProcesses) {
+				actionsWithDefaultNames.Append(finding)
 			}
 
 			return nil
@@ -107,13 +114,42 @@ func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.Octopus
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, goroutineErrors.Values()[0])
 	}
 
-	if actionsWithDefaultNames.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project actions use the default step names:\n"+strings.Join(actionsWithDefaultNames.Values(), "\n"),
+	return o.result(actionsWithDefaultNames.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
+
+func (o OctopusProjectDefaultStepNames) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	actionsWithDefaultNames := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		actionsWithDefaultNames = append(actionsWithDefaultNames, p.Locate(o.findings(p.Project, p.AllSteps()))...)
+	}
+
+	return o.result(actionsWithDefaultNames, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
+}
+
+// findings returns the actions in the deployment process and runbook steps that use a default step name.
+func (o OctopusProjectDefaultStepNames) findings(project *projects.Project, steps []*deployments.DeploymentStep) []checks.OctopusCheckFinding {
+	findings := []checks.OctopusCheckFinding{}
+
+	for _, s := range steps {
+		for _, a := range s.Actions {
+			if slices.Index(checks.DefaultStepNames, a.Name) != -1 {
+				findings = append(findings, checks.NewProjectActionFinding(project, a, ""))
+			}
+		}
+	}
+
+	return findings
+}
+
+func (o OctopusProjectDefaultStepNames) result(actionsWithDefaultNames []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(actionsWithDefaultNames) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following project actions use the default step names:\n"+strings.Join(checks.FindingDescriptions(actionsWithDefaultNames), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			actionsWithDefaultNames).WithCoverage(coverage)
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -121,23 +157,5 @@ func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.Octopus
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Naming), nil
-}
-
-func (o OctopusProjectDefaultStepNames) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
-		return nil, nil
-	}
-
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
-
-	if err != nil {
-		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
-			return nil, nil
-		}
-		return nil, err
-	}
-
-	return resource, nil
+		checks.Naming).WithCoverage(coverage)
 }
@@ -3,6 +3,9 @@ package naming
 import (
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/octoclient"
@@ -49,3 +52,36 @@ func TestDefaultStepNames(t *testing.T) {
 		return nil
 	})
 }
+
+// runbookProject returns an offline project whose only action is in a runbook.
+func runbookProject(action *deployments.DeploymentAction) checks.OctopusOfflineProject {
+	project := projects.NewProject("Runbook Project", "", "")
+	project.ID = "Projects-1"
+
+	step := deployments.NewDeploymentStep(action.Name)
+	step.Actions = append(step.Actions, action)
+
+	runbookProcess := runbooks.NewRunbookProcess()
+	runbookProcess.Steps = append(runbookProcess.Steps, step)
+
+	return checks.OctopusOfflineProject{
+		Project:           project,
+		DeploymentProcess: deployments.NewDeploymentProcess(project.ID),
+		RunbookProcesses:  []*runbooks.RunbookProcess{runbookProcess},
+	}
+}
+
+func TestRunbookDefaultStepNames(t *testing.T) {
+	check := NewOctopusProjectDefaultStepNames(nil, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+
+	result, err := check.ExecuteOffline([]checks.OctopusOfflineProject{
+		runbookProject(deployments.NewDeploymentAction("Run a Script", "Octopus.Script"))})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result.Severity() != checks.Warning || len(result.Findings()) != 1 {
+		t.Fatal("Check should have reported the runbook step, got " + result.Description())
+	}
+}
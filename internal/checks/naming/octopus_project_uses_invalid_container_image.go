@@ -6,15 +6,16 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -39,49 +40,51 @@ func (o OctopusProjectContainerImageRegex) Id() string {
 	return OctoLintContainerImageName
 }
 
+func (o OctopusProjectContainerImageRegex) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView}
+}
+
+func (o OctopusProjectContainerImageRegex) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.ContainerImageRegex) == "" {
-		return nil, nil
-	}
-
-	regex, err := regexp.Compile(o.config.ContainerImageRegex)
-
-	if err != nil {
+	regex, result := o.compileRegex()
 
-		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.ContainerImageRegex+" does not compile",
-			o.Id(),
-			"",
-			checks.Error,
-			checks.Naming), nil
+	if regex == nil {
+		return result, nil
 	}
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidContainerImageProjects)
+		o.config.MaxInvalidContainerImageProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	actionsWithInvalidImages := threadsafe.NewSlice[string]()
+	actionsWithInvalidImages := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 
@@ -90,31 +93,21 @@ func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.Octo
 
 		g.Go(func() error {
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			steps, runbookProcesses, err := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize).GetAllSteps(p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			if deploymentProcess == nil {
-				return nil
-			}
-
-			for _, s := range deploymentProcess.Steps {
-				for _, a := range s.Actions {
-					if a.Container == nil || strings.TrimSpace(a.Container.Image) == "" {
-						continue
-					}
-
-					if !regex.Match([]byte(a.Container.Image)) {
-						actionsWithInvalidImages.Append(p.Name + "/" + a.Name + ": " + a.Container.Image)
-					}
-				}
+			for _, finding := range checks.RunbookActionFindings(o.findings(regex, p, steps), runbookProcesses) {
+				actionsWithInvalidImages.Append(finding)
 			}
 
 			return nil
@@ -131,37 +124,80 @@ func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.Octo
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, goroutineErrors.Values()[0])
 	}
 
-	if actionsWithInvalidImages.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project actions do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(actionsWithInvalidImages.Values(), "\n"),
-			o.Id(),
-			"",
-			checks.Warning,
-			checks.Naming), nil
+	return o.result(actionsWithInvalidImages.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
+
+func (o OctopusProjectContainerImageRegex) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	regex, result := o.compileRegex()
+
+	if regex == nil {
+		return result, nil
 	}
 
-	return checks.NewOctopusCheckResultImpl(
-		"There are no project actions with invalid container images",
-		o.Id(),
-		"",
-		checks.Ok,
-		checks.Naming), nil
+	actionsWithInvalidImages := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		actionsWithInvalidImages = append(actionsWithInvalidImages, p.Locate(o.findings(regex, p.Project, p.AllSteps()))...)
+	}
+
+	return o.result(actionsWithInvalidImages, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
 }
 
-func (o OctopusProjectContainerImageRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
+// compileRegex returns the container image regex, or nil and the result to return if the regex was not
+// supplied or does not compile.
+func (o OctopusProjectContainerImageRegex) compileRegex() (*regexp.Regexp, checks.OctopusCheckResult) {
+	if strings.TrimSpace(o.config.ContainerImageRegex) == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	regex, err := regexp.Compile(o.config.ContainerImageRegex)
 
 	if err != nil {
-		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
-			return nil, nil
+		return nil, checks.NewOctopusCheckResultImpl(
+			"The supplied regex "+o.config.ContainerImageRegex+" does not compile",
+			o.Id(),
+			"",
+			checks.Error,
+			checks.Naming)
+	}
+
+	return regex, nil
+}
+
+// findings returns the actions in the deployment process and runbook steps whose container image does not match the
+// regex.
+func (o OctopusProjectContainerImageRegex) findings(regex *regexp.Regexp, project *projects.Project, steps []*deployments.DeploymentStep) []checks.OctopusCheckFinding {
+	findings := []checks.OctopusCheckFinding{}
+
+	for _, s := range steps {
+		for _, a := range s.Actions {
+			if a.Container == nil || strings.TrimSpace(a.Container.Image) == "" {
+				continue
+			}
+
+			if !regex.Match([]byte(a.Container.Image)) {
+				findings = append(findings, checks.NewProjectActionFinding(project, a, a.Container.Image))
+			}
 		}
-		return nil, err
 	}
 
-	return resource, nil
+	return findings
+}
+
+func (o OctopusProjectContainerImageRegex) result(actionsWithInvalidImages []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(actionsWithInvalidImages) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following project actions do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(checks.FindingDescriptions(actionsWithInvalidImages), "\n"),
+			o.Id(),
+			"",
+			checks.Warning,
+			checks.Naming,
+			actionsWithInvalidImages).WithCoverage(coverage)
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are no project actions with invalid container images",
+		o.Id(),
+		"",
+		checks.Ok,
+		checks.Naming).WithCoverage(coverage)
 }
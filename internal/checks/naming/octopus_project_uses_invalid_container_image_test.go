@@ -3,6 +3,7 @@ package naming
 import (
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/octoclient"
@@ -51,3 +52,22 @@ func TestInvalidContainerImage(t *testing.T) {
 		return nil
 	})
 }
+
+func TestRunbookInvalidContainerImage(t *testing.T) {
+	check := NewOctopusProjectContainerImageRegex(nil, &config.OctolintConfig{
+		ContainerImageRegex: "^octopusdeploy/.*",
+	}, checks.OctopusClientPermissiveErrorHandler{})
+
+	action := deployments.NewDeploymentAction("Run a Container", "Octopus.Script")
+	action.Container = &deployments.DeploymentActionContainer{FeedID: "Feeds-1", Image: "ubuntu:latest"}
+
+	result, err := check.ExecuteOffline([]checks.OctopusOfflineProject{runbookProject(action)})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result.Severity() != checks.Warning || len(result.Findings()) != 1 {
+		t.Fatal("Check should have reported the runbook step, got " + result.Description())
+	}
+}
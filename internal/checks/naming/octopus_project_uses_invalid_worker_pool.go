@@ -6,17 +6,17 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"github.com/samber/lo"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -41,64 +41,63 @@ func (o OctopusProjectWorkerPoolRegex) Id() string {
 	return OctoLintProjectWorkerPool
 }
 
+func (o OctopusProjectWorkerPoolRegex) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView, checks.PermissionWorkerView}
+}
+
 func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.ProjectStepWorkerPoolRegex) == "" {
-		return nil, nil
-	}
-
-	regex, err := regexp.Compile(o.config.ProjectStepWorkerPoolRegex)
+	regex, result := o.compileRegex(o.config.ProjectStepWorkerPoolRegex)
 
-	if err != nil {
-
-		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.ProjectStepWorkerPoolRegex+" does not compile",
-			o.Id(),
-			"",
-			checks.Error,
-			checks.Naming), nil
+	if regex == nil {
+		return result, nil
 	}
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidWorkerPoolProjects)
+		o.config.MaxInvalidWorkerPoolProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	workerPools, err := o.client.WorkerPools.GetAll()
+	workerPoolNames, defaultWorkerPool, err := o.workerPools()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	defaultWorkerPools := lo.Filter(workerPools, func(item *workerpools.WorkerPoolListResult, index int) bool {
-		return item.IsDefault
-	})
+	workerPoolName := func(action *deployments.DeploymentAction) (string, bool) {
+		if action.WorkerPool == "" {
+			return defaultWorkerPool, true
+		}
+
+		if regex.Match([]byte(action.WorkerPool)) {
+			return "", false
+		}
 
-	defaultWorkerPool := ""
-	if len(defaultWorkerPools) == 1 {
-		defaultWorkerPool = defaultWorkerPools[0].Name
+		return workerPoolNames[action.WorkerPool], false
 	}
 
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	actionsWithInvalidWorkerPools := threadsafe.NewSlice[string]()
+	actionsWithInvalidWorkerPools := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 		i := i
@@ -106,43 +105,21 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 
 		g.Go(func() error {
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			steps, runbookProcesses, err := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize).GetAllSteps(p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			if deploymentProcess == nil {
-				return nil
-			}
-
-			for _, s := range deploymentProcess.Steps {
-				for _, a := range s.Actions {
-
-					if a.WorkerPoolVariable != "" {
-						continue
-					}
-
-					if a.WorkerPool == "" {
-						if defaultWorkerPool != "" && !regex.Match([]byte(defaultWorkerPool)) {
-
-							actionsWithInvalidWorkerPools.Append(p.Name + "/" + a.Name + ": " + defaultWorkerPool + " (default)")
-						}
-					} else if !regex.Match([]byte(a.WorkerPool)) {
-						workerPool := lo.Filter(workerPools, func(item *workerpools.WorkerPoolListResult, index int) bool {
-							return item.ID == a.WorkerPool
-						})
-
-						if len(workerPool) == 1 && !regex.Match([]byte(workerPool[0].Name)) {
-							actionsWithInvalidWorkerPools.Append(p.Name + "/" + a.Name + ": " + workerPool[0].Name)
-						}
-					}
-				}
+			for _, finding := range checks.RunbookActionFindings(o.findings(regex, p, steps, workerPoolName), runbookProcesses) {
+				actionsWithInvalidWorkerPools.Append(finding)
 			}
 
 			return nil
@@ -159,37 +136,124 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, goroutineErrors.Values()[0])
 	}
 
-	if actionsWithInvalidWorkerPools.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project actions use worker pools that do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(actionsWithInvalidWorkerPools.Values(), "\n"),
+	return o.result(o.config.ProjectStepWorkerPoolRegex, actionsWithInvalidWorkerPools.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
+
+// ExecuteOffline checks the worker pools referenced in Config-as-Code files. The files reference worker pools by slug,
+// like "hosted-windows", rather than by name, so the slugs are matched against ProjectStepWorkerPoolSlugRegex. The
+// default worker pool is not known without an Octopus connection, so actions that use the default worker pool are
+// not checked.
+func (o OctopusProjectWorkerPoolRegex) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	regex, result := o.compileRegex(o.config.ProjectStepWorkerPoolSlugRegex)
+
+	if regex == nil {
+		return result, nil
+	}
+
+	workerPoolName := func(action *deployments.DeploymentAction) (string, bool) {
+		return action.WorkerPool, false
+	}
+
+	actionsWithInvalidWorkerPools := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		actionsWithInvalidWorkerPools = append(actionsWithInvalidWorkerPools, p.Locate(o.findings(regex, p.Project, p.AllSteps(), workerPoolName))...)
+	}
+
+	return o.result(o.config.ProjectStepWorkerPoolSlugRegex, actionsWithInvalidWorkerPools, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
+}
+
+// compileRegex returns the compiled worker pool regex, or nil and the result to return if the regex was not
+// supplied or does not compile.
+func (o OctopusProjectWorkerPoolRegex) compileRegex(regexString string) (*regexp.Regexp, checks.OctopusCheckResult) {
+	if strings.TrimSpace(regexString) == "" {
+		return nil, nil
+	}
+
+	regex, err := regexp.Compile(regexString)
+
+	if err != nil {
+		return nil, checks.NewOctopusCheckResultImpl(
+			"The supplied regex "+regexString+" does not compile",
+			o.Id(),
+			"",
+			checks.Error,
+			checks.Naming)
+	}
+
+	return regex, nil
+}
+
+// findings returns the actions in the deployment process and runbook steps whose worker pool does not match the
+// regex. The workerPoolName function returns the name of the worker pool used by an action, or an empty string if
+// the worker pool can not be checked, and whether the worker pool is the default worker pool.
+func (o OctopusProjectWorkerPoolRegex) findings(regex *regexp.Regexp, project *projects.Project, steps []*deployments.DeploymentStep, workerPoolName func(action *deployments.DeploymentAction) (string, bool)) []checks.OctopusCheckFinding {
+	findings := []checks.OctopusCheckFinding{}
+
+	for _, s := range steps {
+		for _, a := range s.Actions {
+
+			if a.WorkerPoolVariable != "" {
+				continue
+			}
+
+			name, isDefault := workerPoolName(a)
+
+			if name == "" || regex.Match([]byte(name)) {
+				continue
+			}
+
+			if isDefault {
+				findings = append(findings, checks.NewProjectActionFinding(project, a, name+" (default)"))
+			} else {
+				findings = append(findings, checks.NewProjectActionFinding(project, a, name))
+			}
+		}
+	}
+
+	return findings
+}
+
+func (o OctopusProjectWorkerPoolRegex) result(regexString string, actionsWithInvalidWorkerPools []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(actionsWithInvalidWorkerPools) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following project actions use worker pools that do not match the regex "+regexString+":\n"+strings.Join(checks.FindingDescriptions(actionsWithInvalidWorkerPools), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			actionsWithInvalidWorkerPools).WithCoverage(coverage)
 	}
 
 	return checks.NewOctopusCheckResultImpl(
-		"There are no actions that use worker pools that do not match the regex "+o.config.ContainerImageRegex,
+		"There are no actions that use worker pools that do not match the regex "+regexString,
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Naming), nil
+		checks.Naming).WithCoverage(coverage)
 }
 
-func (o OctopusProjectWorkerPoolRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
-		return nil, nil
-	}
+// workerPools returns the names of the worker pools indexed by ID, and the name of the default worker pool, or an
+// empty string if there is no single default worker pool.
+func (o OctopusProjectWorkerPoolRegex) workerPools() (map[string]string, string, error) {
+	pager := client_wrapper.NewSpacePager[workerpools.WorkerPoolListResult](o.client, o.client.GetSpaceID(), "workerpools", o.config.PageSize, 0)
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	names := map[string]string{}
+	defaultWorkerPools := []string{}
+	for workerPool, err := range pager.Items() {
+		if err != nil {
+			return nil, "", err
+		}
 
-	if err != nil {
-		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
-			return nil, nil
+		names[workerPool.ID] = workerPool.Name
+
+		if workerPool.IsDefault {
+			defaultWorkerPools = append(defaultWorkerPools, workerPool.Name)
 		}
-		return nil, err
 	}
 
-	return resource, nil
+	if len(defaultWorkerPools) != 1 {
+		return names, "", nil
+	}
+
+	return names, defaultWorkerPools[0], nil
 }
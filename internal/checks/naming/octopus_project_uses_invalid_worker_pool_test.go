@@ -3,6 +3,7 @@ package naming
 import (
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/octoclient"
@@ -51,3 +52,41 @@ func TestInvalidWorkerPool(t *testing.T) {
 		return nil
 	})
 }
+
+func TestRunbookInvalidWorkerPool(t *testing.T) {
+	check := NewOctopusProjectWorkerPoolRegex(nil, &config.OctolintConfig{
+		ProjectStepWorkerPoolSlugRegex: "^linux-",
+	}, checks.OctopusClientPermissiveErrorHandler{})
+
+	action := deployments.NewDeploymentAction("Run a Script", "Octopus.Script")
+	action.WorkerPool = "windows-workers"
+
+	result, err := check.ExecuteOffline([]checks.OctopusOfflineProject{runbookProject(action)})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result.Severity() != checks.Warning || len(result.Findings()) != 1 {
+		t.Fatal("Check should have reported the runbook step, got " + result.Description())
+	}
+}
+
+func TestOfflineWorkerPoolIgnoresNameRegex(t *testing.T) {
+	check := NewOctopusProjectWorkerPoolRegex(nil, &config.OctolintConfig{
+		ProjectStepWorkerPoolRegex: "^Hosted Windows$",
+	}, checks.OctopusClientPermissiveErrorHandler{})
+
+	action := deployments.NewDeploymentAction("Run a Script", "Octopus.Script")
+	action.WorkerPool = "hosted-windows"
+
+	result, err := check.ExecuteOffline([]checks.OctopusOfflineProject{runbookProject(action)})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result != nil {
+		t.Fatal("Check should not have matched the worker pool name regex against slugs, got " + result.Description())
+	}
+}
@@ -7,3 +7,31 @@ type OctopusCheck interface {
 	// Id returns the unique ID of the check, used to cross-reference with documentation
 	Id() string
 }
+
+// OctopusOfflineCheck is implemented by checks that can also inspect projects loaded from Config-as-Code files,
+// without a connection to an Octopus instance
+type OctopusOfflineCheck interface {
+	OctopusCheck
+	// ExecuteOffline runs the check against the supplied projects
+	ExecuteOffline(projects []OctopusOfflineProject) (OctopusCheckResult, error)
+}
+
+// OctopusIncrementalCheck is implemented by checks where each finding relates to a single project, target, tenant,
+// or library variable set. Incremental scans run these checks against the resources that changed since the last
+// scan, and keep the cached findings of the other resources.
+type OctopusIncrementalCheck interface {
+	OctopusCheck
+	// ScopedResourceType returns the type of resource the check inspects, like ResourceTypeProject, or an empty
+	// string if the check must inspect the whole space
+	ScopedResourceType() string
+}
+
+// ScopedResourceType returns the type of resource an incremental check inspects, or an empty string if the check
+// does not support incremental scans.
+func ScopedResourceType(check OctopusCheck) string {
+	if incrementalCheck, ok := check.(OctopusIncrementalCheck); ok {
+		return incrementalCheck.ScopedResourceType()
+	}
+
+	return ""
+}
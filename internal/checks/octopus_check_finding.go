@@ -0,0 +1,133 @@
+package checks
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+// OctopusCheckFinding describes an individual resource that was identified by a check.
+type OctopusCheckFinding struct {
+	// ResourceType is the type of the resource, for example "Project" or "Target"
+	ResourceType string
+	// ResourceId is the Octopus ID of the resource, if it is known
+	ResourceId string
+	// ResourceName is the name of the resource
+	ResourceName string
+	// ParentId is the Octopus ID of the resource that owns this resource, for example the project that
+	// owns a variable or a step
+	ParentId string
+	// ParentName is the name of the resource that owns this resource
+	ParentName string
+	// RunbookId is the Octopus ID of the runbook that owns a step action, if the action is not in the deployment
+	// process
+	RunbookId string
+	// Description is the human-readable description of the finding
+	Description string
+	// File is the Config-as-Code file that the resource was defined in, if the finding was made offline
+	File string
+	// Line is the line of the File that the resource was defined on
+	Line int
+}
+
+// Identity returns a value that uniquely identifies the resource a finding relates to. This is used to match
+// findings between reports without relying on the description text.
+func (o OctopusCheckFinding) Identity() string {
+	if o.ResourceId != "" {
+		return o.ResourceType + "/" + o.ParentId + "/" + o.ResourceId
+	}
+
+	return o.NameIdentity()
+}
+
+// NameIdentity returns a value that identifies the resource a finding relates to by name. Resource IDs differ
+// between Octopus instances, so this is used to match findings between reports generated from different instances.
+func (o OctopusCheckFinding) NameIdentity() string {
+	return o.ResourceType + "/" + o.ParentName + "/" + o.ResourceName
+}
+
+// NewProjectFinding creates a finding that identifies a project.
+func NewProjectFinding(project *projects.Project) OctopusCheckFinding {
+	return OctopusCheckFinding{
+		ResourceType: ResourceTypeProject,
+		ResourceId:   project.ID,
+		ResourceName: project.Name,
+		Description:  project.Name,
+	}
+}
+
+// NewProjectVariableFinding creates a finding that identifies a project variable.
+func NewProjectVariableFinding(project *projects.Project, variable *variables.Variable) OctopusCheckFinding {
+	return OctopusCheckFinding{
+		ResourceType: ResourceTypeVariable,
+		ResourceId:   variable.ID,
+		ResourceName: variable.Name,
+		ParentId:     project.ID,
+		ParentName:   project.Name,
+		Description:  project.Name + ": " + variable.Name,
+	}
+}
+
+// NewProjectActionFinding creates a finding that identifies a step action in a project. The description is
+// the project and action name, optionally followed by some additional details.
+func NewProjectActionFinding(project *projects.Project, action *deployments.DeploymentAction, details string) OctopusCheckFinding {
+	description := project.Name + "/" + action.Name
+	if details != "" {
+		description += ": " + details
+	}
+
+	return OctopusCheckFinding{
+		ResourceType: ResourceTypeAction,
+		ResourceId:   action.ID,
+		ResourceName: action.Name,
+		ParentId:     project.ID,
+		ParentName:   project.Name,
+		Description:  description,
+	}
+}
+
+// RunbookActionFindings sets the RunbookId of the action findings that identify an action in one of the runbook
+// processes, so the findings can be traced back to the runbook rather than the deployment process.
+func RunbookActionFindings(findings []OctopusCheckFinding, runbookProcesses []*runbooks.RunbookProcess) []OctopusCheckFinding {
+	runbookIds := map[string]string{}
+	for _, runbookProcess := range runbookProcesses {
+		for _, s := range runbookProcess.Steps {
+			for _, a := range s.Actions {
+				if a.ID != "" {
+					runbookIds[a.ID] = runbookProcess.RunbookID
+				}
+			}
+		}
+	}
+
+	located := make([]OctopusCheckFinding, len(findings))
+	for i, finding := range findings {
+		if runbookId, ok := runbookIds[finding.ResourceId]; ok && finding.ResourceType == ResourceTypeAction {
+			finding.RunbookId = runbookId
+		}
+		located[i] = finding
+	}
+	return located
+}
+
+// NewTargetFinding creates a finding that identifies a deployment target.
+func NewTargetFinding(target *machines.DeploymentTarget) OctopusCheckFinding {
+	return OctopusCheckFinding{
+		ResourceType: ResourceTypeTarget,
+		ResourceId:   target.ID,
+		ResourceName: target.Name,
+		Description:  target.Name,
+	}
+}
+
+// FindingDescriptions returns the descriptions of the findings, which is typically used to build the description
+// of the check result.
+func FindingDescriptions(findings []OctopusCheckFinding) []string {
+	descriptions := make([]string, len(findings))
+	for i, f := range findings {
+		descriptions[i] = f.Description
+	}
+	return descriptions
+}
@@ -0,0 +1,40 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+)
+
+func TestRunbookActionFindings(t *testing.T) {
+	project := projects.NewProject("Project", "", "")
+	project.ID = "Projects-1"
+
+	deploymentAction := deployments.NewDeploymentAction("Deploy", "Octopus.Script")
+	deploymentAction.ID = "action-1"
+
+	runbookAction := deployments.NewDeploymentAction("Run", "Octopus.Script")
+	runbookAction.ID = "action-2"
+
+	step := deployments.NewDeploymentStep(runbookAction.Name)
+	step.Actions = append(step.Actions, runbookAction)
+
+	runbookProcess := runbooks.NewRunbookProcess()
+	runbookProcess.RunbookID = "Runbooks-1"
+	runbookProcess.Steps = append(runbookProcess.Steps, step)
+
+	findings := RunbookActionFindings([]OctopusCheckFinding{
+		NewProjectActionFinding(project, deploymentAction, ""),
+		NewProjectActionFinding(project, runbookAction, ""),
+	}, []*runbooks.RunbookProcess{runbookProcess})
+
+	if findings[0].RunbookId != "" {
+		t.Fatal("Should not have set the runbook of the deployment process action")
+	}
+
+	if findings[1].RunbookId != "Runbooks-1" {
+		t.Fatal("Should have set the runbook of the runbook action")
+	}
+}
@@ -1,5 +1,10 @@
 package checks
 
+import (
+	"errors"
+	"strings"
+)
+
 const (
 	Error      int = 20
 	Warning        = 15
@@ -17,6 +22,33 @@ const (
 	GeneralError        = "GeneralError"
 )
 
+// SeverityName returns the display name of a severity level.
+func SeverityName(severity int) string {
+	switch {
+	case severity >= Error:
+		return "Error"
+	case severity >= Warning:
+		return "Warning"
+	case severity >= Info:
+		return "Info"
+	case severity >= Permission:
+		return "Permission"
+	default:
+		return "Ok"
+	}
+}
+
+// ParseSeverity converts a severity name, as returned by SeverityName, to a severity level.
+func ParseSeverity(name string) (int, error) {
+	for _, severity := range []int{Error, Warning, Info, Permission, Ok} {
+		if strings.EqualFold(strings.TrimSpace(name), SeverityName(severity)) {
+			return severity, nil
+		}
+	}
+
+	return 0, errors.New("the severity " + name + " is not supported. Supported values are Error, Warning, Info, Permission, and Ok")
+}
+
 // OctopusCheckResult describes the result of an OctopusCheck
 type OctopusCheckResult interface {
 	Description() string
@@ -24,6 +56,17 @@ type OctopusCheckResult interface {
 	Link() string
 	Severity() int
 	Category() string
+	// Findings returns the individual resources that caused the check to fail. Checks that report on the
+	// space as a whole, rather than individual resources, may return an empty slice.
+	Findings() []OctopusCheckFinding
+}
+
+// OctopusScannedCheckResult is implemented by results that record how many resources the check inspected, which
+// allows the number of findings to be compared to the size of the space.
+type OctopusScannedCheckResult interface {
+	OctopusCheckResult
+	// ScannedResources returns the number of resources inspected by the check, or 0 if the number is not known
+	ScannedResources() int
 }
 
 type OctopusCheckResultImpl struct {
@@ -32,15 +75,22 @@ type OctopusCheckResultImpl struct {
 	link        string
 	severity    int
 	category    string
+	findings    []OctopusCheckFinding
+	coverage    []ResourceCoverage
 }
 
 func NewOctopusCheckResultImpl(description string, code string, link string, severity int, category string) OctopusCheckResultImpl {
+	return NewOctopusCheckResultImplWithFindings(description, code, link, severity, category, []OctopusCheckFinding{})
+}
+
+func NewOctopusCheckResultImplWithFindings(description string, code string, link string, severity int, category string, findings []OctopusCheckFinding) OctopusCheckResultImpl {
 	return OctopusCheckResultImpl{
 		description: description,
 		code:        code,
 		link:        link,
 		severity:    severity,
 		category:    category,
+		findings:    findings,
 	}
 }
 
@@ -63,3 +113,39 @@ func (o OctopusCheckResultImpl) Severity() int {
 func (o OctopusCheckResultImpl) Category() string {
 	return o.category
 }
+
+func (o OctopusCheckResultImpl) Findings() []OctopusCheckFinding {
+	return o.findings
+}
+
+func (o OctopusCheckResultImpl) Coverage() []ResourceCoverage {
+	return o.coverage
+}
+
+func (o OctopusCheckResultImpl) ScannedResources() int {
+	scanned := 0
+	for _, coverage := range o.coverage {
+		scanned += coverage.Scanned
+	}
+	return scanned
+}
+
+// WithCoverage returns a copy of the result that records the number of resources inspected by the check.
+func (o OctopusCheckResultImpl) WithCoverage(coverage ...ResourceCoverage) OctopusCheckResultImpl {
+	o.coverage = coverage
+	return o
+}
+
+// FindingCount returns the number of issues reported by a result. Results that failed without identifying
+// individual resources are counted as a single finding.
+func FindingCount(result OctopusCheckResult) int {
+	if result == nil || result.Severity() < Warning {
+		return 0
+	}
+
+	if len(result.Findings()) == 0 {
+		return 1
+	}
+
+	return len(result.Findings())
+}
@@ -0,0 +1,60 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+// SourceLocation identifies the line of a file that a resource was defined in.
+type SourceLocation struct {
+	File string
+	Line int
+}
+
+func (o SourceLocation) String() string {
+	return o.File + ":" + fmt.Sprint(o.Line)
+}
+
+// OctopusOfflineProject is a project loaded from Config-as-Code files rather than from an Octopus instance. Any of
+// the deployment process, runbook processes, and variables may be missing if the matching files do not exist.
+type OctopusOfflineProject struct {
+	Project           *projects.Project
+	DeploymentProcess *deployments.DeploymentProcess
+	RunbookProcesses  []*runbooks.RunbookProcess
+	Variables         *variables.VariableSet
+	// Locations maps the IDs of the project, actions, and variables to the place they were defined
+	Locations map[string]SourceLocation
+}
+
+// AllSteps returns the steps of the deployment process and all runbooks.
+func (o OctopusOfflineProject) AllSteps() []*deployments.DeploymentStep {
+	steps := []*deployments.DeploymentStep{}
+
+	if o.DeploymentProcess != nil {
+		steps = append(steps, o.DeploymentProcess.Steps...)
+	}
+
+	for _, runbookProcess := range o.RunbookProcesses {
+		steps = append(steps, runbookProcess.Steps...)
+	}
+
+	return steps
+}
+
+// Locate adds the file and line number that each finding was defined in, when it is known.
+func (o OctopusOfflineProject) Locate(findings []OctopusCheckFinding) []OctopusCheckFinding {
+	located := make([]OctopusCheckFinding, len(findings))
+	for i, finding := range findings {
+		if location, ok := o.Locations[finding.ResourceId]; ok {
+			finding.File = location.File
+			finding.Line = location.Line
+			finding.Description = location.String() + ": " + finding.Description
+		}
+		located[i] = finding
+	}
+	return located
+}
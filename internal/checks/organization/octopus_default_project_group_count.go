@@ -7,7 +7,7 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 const maxProjectsInDefaultGroup = 10
@@ -28,15 +28,19 @@ func (o OctopusDefaultProjectGroupCountCheck) Id() string {
 	return "OctoLintDefaultProjectGroupChildCount"
 }
 
+func (o OctopusDefaultProjectGroupCountCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectGroupView, checks.PermissionProjectView}
+}
+
 func (o OctopusDefaultProjectGroupCountCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	resource, err := o.client.ProjectGroups.GetByName("Default Project Group")
@@ -54,6 +58,8 @@ func (o OctopusDefaultProjectGroupCountCheck) Execute(concurrency int) (checks.O
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
+	coverage := []checks.ResourceCoverage{}
+
 	if resource != nil {
 
 		projects, err := o.client.ProjectGroups.GetProjects(resource)
@@ -62,13 +68,22 @@ func (o OctopusDefaultProjectGroupCountCheck) Execute(concurrency int) (checks.O
 			return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 		}
 
+		coverage = append(coverage, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects)))
+
 		if len(projects) > maxProjectsInDefaultGroup {
-			return checks.NewOctopusCheckResultImpl(
-				"The default project group contains "+fmt.Sprint(len(projects))+" projects. You may want to organize these projects into additional project groups.",
+			message := "The default project group contains " + fmt.Sprint(len(projects)) + " projects. You may want to organize these projects into additional project groups."
+			return checks.NewOctopusCheckResultImplWithFindings(
+				message,
 				o.Id(),
 				"",
 				checks.Warning,
-				checks.Organization), nil
+				checks.Organization,
+				[]checks.OctopusCheckFinding{{
+					ResourceType: checks.ResourceTypeProjectGroup,
+					ResourceId:   resource.ID,
+					ResourceName: resource.Name,
+					Description:  message,
+				}}).WithCoverage(coverage...), nil
 		}
 	}
 
@@ -77,5 +92,5 @@ func (o OctopusDefaultProjectGroupCountCheck) Execute(concurrency int) (checks.O
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage...), nil
 }
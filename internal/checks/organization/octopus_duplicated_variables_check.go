@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
@@ -14,6 +15,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
@@ -46,26 +48,31 @@ func (o *OctopusDuplicatedVariablesCheck) Id() string {
 	return OctoLintDuplicatedVariables
 }
 
+func (o *OctopusDuplicatedVariablesCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionVariableView}
+}
+
 func (o *OctopusDuplicatedVariablesCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxDuplicateVariableProjects)
+		o.config.MaxDuplicateVariableProjects,
+		o.config.PageSize)
 
 	if err != nil {
-		zap.L().Error("Failed to get projects for check "+o.Id(), zap.Error(err))
+		logging.Check(o.Id()).Error("Failed to get projects for check "+o.Id(), zap.Error(err))
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
@@ -73,6 +80,7 @@ func (o *OctopusDuplicatedVariablesCheck) Execute(concurrency int) (checks.Octop
 	g.SetLimit(concurrency)
 
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	projectVars := map[*projects2.Project]variables.VariableSet{}
 	for i, p := range projects {
@@ -84,14 +92,16 @@ func (o *OctopusDuplicatedVariablesCheck) Execute(concurrency int) (checks.Octop
 		}
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			variableSet, err := o.client.Variables.GetAll(p.ID)
+			variableSet, err := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize).GetVariables(p)
 
 			if err != nil {
-				zap.L().Error("Failed to get variables for project "+p.Name+" in check "+o.Id(), zap.Error(err))
+				logging.Check(o.Id()).Error("Failed to get variables for project "+p.Name+" in check "+o.Id(), logging.Project(p.Name), zap.Error(err))
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
@@ -100,23 +110,25 @@ func (o *OctopusDuplicatedVariablesCheck) Execute(concurrency int) (checks.Octop
 			o.mu.Lock()
 			defer o.mu.Unlock()
 
-			projectVars[p] = variableSet
+			projectVars[p] = *variableSet
 
 			return nil
 		})
 	}
 
 	if err := g.Wait(); err != nil {
-		zap.L().Error("Failed to get variables for projects in check "+o.Id(), zap.Error(err))
+		logging.Check(o.Id()).Error("Failed to get variables for projects in check "+o.Id(), zap.Error(err))
 		return nil, err
 	}
 
 	// Treat the first error as the root cause
 	if goroutineErrors.Length() > 0 {
-		zap.L().Error("One or more errors occurred while getting variables for projects in check "+o.Id(), zap.Error(goroutineErrors.Values()[0]))
+		logging.Check(o.Id()).Error("One or more errors occurred while getting variables for projects in check "+o.Id(), zap.Error(goroutineErrors.Values()[0]))
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))
+
 	duplicateVars := []projectVar{}
 
 OuterLoop:
@@ -148,17 +160,25 @@ OuterLoop:
 	}
 
 	if len(duplicateVars) > 0 {
-		messages := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for _, variable := range duplicateVars {
-			messages = append(messages, variable.project1.Name+"/"+variable.variable1.Name+" == "+variable.project2.Name+"/"+variable.variable2.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeVariable,
+				ResourceId:   variable.variable1.ID + "/" + variable.variable2.ID,
+				ResourceName: variable.variable1.Name + " == " + variable.project2.Name + "/" + variable.variable2.Name,
+				ParentId:     variable.project1.ID,
+				ParentName:   variable.project1.Name,
+				Description:  variable.project1.Name + "/" + variable.variable1.Name + " == " + variable.project2.Name + "/" + variable.variable2.Name,
+			})
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following variables are duplicated between projects. Consider moving these into library variable sets:\n"+strings.Join(messages, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following variables are duplicated between projects. Consider moving these into library variable sets:\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -166,7 +186,7 @@ OuterLoop:
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
 
 func (o *OctopusDuplicatedVariablesCheck) shouldIgnoreVariable(variable *variables.Variable) bool {
@@ -5,14 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"strings"
+	"sync/atomic"
 )
 
 const OctoLintEmptyProject = "OctoLintEmptyProject"
@@ -32,54 +33,79 @@ func (o OctopusEmptyProjectCheck) Id() string {
 	return OctoLintEmptyProject
 }
 
+func (o OctopusEmptyProjectCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView}
+}
+
+func (o OctopusEmptyProjectCheck) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxEmptyProjectCheckProjects)
+		o.config.MaxEmptyProjectCheckProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	runbooks, err := o.client.Runbooks.GetAll()
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
+	loader := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize)
 
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	emptyProjects := threadsafe.NewSlice[string]()
+	emptyProjects := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 		i := i
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
+
+			stepCount, err := o.stepsInDeploymentProcess(loader, p)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
+				}
+				return nil
+			}
 
-			stepCount, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			runbooks, err := loader.GetRunbooks(p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			if runbooksInProject(p.ID, runbooks) == 0 && stepCount == 0 {
-				emptyProjects.Append(p.Name)
+			if len(runbooks) == 0 && stepCount == 0 {
+				emptyProjects.Append(checks.NewProjectFinding(p))
 			}
 
 			return nil
@@ -95,13 +121,16 @@ func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckR
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))
+
 	if emptyProjects.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects have no runbooks and no deployment process:\n"+strings.Join(emptyProjects.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following projects have no runbooks and no deployment process:\n"+strings.Join(checks.FindingDescriptions(emptyProjects.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			emptyProjects.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -109,27 +138,13 @@ func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckR
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
-}
-
-func runbooksInProject(projectID string, runbooks []*runbooks.Runbook) int {
-	count := 0
-	for _, r := range runbooks {
-		if r.ProjectID == projectID {
-			count++
-		}
-	}
-	return count
+		checks.Organization).WithCoverage(coverage), nil
 }
 
-func (o OctopusEmptyProjectCheck) stepsInDeploymentProcess(deploymentProcessID string) (int, error) {
-	if deploymentProcessID == "" {
-		return 0, nil
-	}
+func (o OctopusEmptyProjectCheck) stepsInDeploymentProcess(loader client_wrapper.ProcessLoader, project *projects.Project) (int, error) {
+	resource, err := loader.GetDeploymentProcess(project)
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
-
-	if err != nil {
+	if err != nil || resource == nil {
 		return 0, err
 	}
 
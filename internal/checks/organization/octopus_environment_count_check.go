@@ -6,8 +6,9 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 const OctopusEnvironmentCountCheckName = "OctoLintEnvironmentCount"
@@ -27,35 +28,37 @@ func (o OctopusEnvironmentCountCheck) Id() string {
 	return OctopusEnvironmentCountCheckName
 }
 
+func (o OctopusEnvironmentCountCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionEnvironmentView}
+}
+
 func (o OctopusEnvironmentCountCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	query := environments.EnvironmentsQuery{
-		PartialName: "",
-		Skip:        0,
-		Take:        1000,
-	}
-	resources, err := o.client.Environments.Get(query)
+	// Only the number of environments is required, which is returned with the first page
+	pager := client_wrapper.NewSpacePager[environments.Environment](o.client, o.client.GetSpaceID(), "environments", 1, 1)
 
-	if err != nil {
+	if _, err := pager.Next(); err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	if len(resources.Items) > o.config.MaxEnvironments {
+	coverage := checks.NewFullResourceCoverage(checks.ResourceTypeEnvironment, pager.Total())
+
+	if pager.Total() > o.config.MaxEnvironments {
 		return checks.NewOctopusCheckResultImpl(
-			"The recommended maximum number of environments is "+fmt.Sprint(o.config.MaxEnvironments)+". You have at least "+fmt.Sprint(len(resources.Items)),
+			"The recommended maximum number of environments is "+fmt.Sprint(o.config.MaxEnvironments)+". You have "+fmt.Sprint(pager.Total()),
 			o.Id(),
 			"https://octopus.com/docs/getting-started/best-practices/environments-and-deployment-targets-and-roles#environments",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -63,5 +66,5 @@ func (o OctopusEnvironmentCountCheck) Execute(concurrency int) (checks.OctopusCh
 		o.Id(),
 		"https://octopus.com/docs/getting-started/best-practices/environments-and-deployment-targets-and-roles#environments",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
@@ -6,11 +6,14 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"strings"
 )
 
+const OctoRecLifecycleRetention = "OctoRecLifecycleRetention"
+
 type OctopusLifecycleRetentionPolicyCheck struct {
 	client       *client.Client
 	errorHandler checks.OctopusClientErrorHandler
@@ -22,7 +25,11 @@ func NewOctopusLifecycleRetentionPolicyCheck(client *client.Client, config *conf
 }
 
 func (o OctopusLifecycleRetentionPolicyCheck) Id() string {
-	return "OctoRecLifecycleRetention"
+	return OctoRecLifecycleRetention
+}
+
+func (o OctopusLifecycleRetentionPolicyCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionLifecycleView}
 }
 
 func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
@@ -30,21 +37,22 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.O
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	lifecycles, err := o.client.Lifecycles.GetAll()
+	pager := client_wrapper.NewSpacePager[lifecycles.Lifecycle](o.client, o.client.GetSpaceID(), "lifecycles", o.config.PageSize, 0)
 
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
-	}
+	keepsForever := []checks.OctopusCheckFinding{}
+	skipped := 0
+	for l, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
+		}
 
-	keepsForever := []string{}
-	for i, l := range lifecycles {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(lifecycles))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
 		phaseKeepsForever, err := o.anyPhasesKeepForever(l.Phases)
 
@@ -52,23 +60,32 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.O
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
+			skipped++
 			continue
 		}
 
 		lifecycleKeepsForever := l.ReleaseRetentionPolicy.ShouldKeepForever || l.TentacleRetentionPolicy.ShouldKeepForever
 
 		if lifecycleKeepsForever || phaseKeepsForever {
-			keepsForever = append(keepsForever, l.Name)
+			keepsForever = append(keepsForever, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeLifecycle,
+				ResourceId:   l.ID,
+				ResourceName: l.Name,
+				Description:  l.Name,
+			})
 		}
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeLifecycle, pager.Read(), pager.Read(), skipped)
+
 	if len(keepsForever) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following lifecycles have retention policies that keep releases or files forever:\n"+strings.Join(keepsForever, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following lifecycles have retention policies that keep releases or files forever:\n"+strings.Join(checks.FindingDescriptions(keepsForever), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			keepsForever).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -76,7 +93,7 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.O
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
 
 func (o OctopusLifecycleRetentionPolicyCheck) anyPhasesKeepForever(phases []*lifecycles.Phase) (bool, error) {
@@ -0,0 +1,313 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/octostache"
+	"github.com/hayageek/threadsafe"
+	"golang.org/x/sync/errgroup"
+)
+
+const OctoLintInvalidOutputVariables = "OctoLintInvalidOutputVariables"
+
+// outputVariableReference matches references to output variables like "Octopus.Action[Deploy].Output.Url",
+// "Octopus.Step[Deploy].Output.Url", and "Octopus.Action[Deploy].Output[Web01].Url", capturing the type of
+// reference and the name of the step or action.
+var outputVariableReference = regexp.MustCompile(`(?i)^Octopus\.(Action|Step)\[([^\]]+)]\.Output[.\[]`)
+
+// OctopusOutputVariableReferencesCheck checks to see if any step or variable references the output variables of a
+// step that does not exist, which is typically caused by renaming a step. References from a step to a step that runs
+// later in the same process are also reported, as the output variable will not have been set.
+type OctopusOutputVariableReferencesCheck struct {
+	client       *client.Client
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+}
+
+// stepPosition is the position of an action in a process. Steps referenced by name are given the action index -1.
+type stepPosition struct {
+	step   int
+	action int
+}
+
+// precedes returns true if the output variables of the step or action at this position are available to the
+// action at the other position.
+func (o stepPosition) precedes(other stepPosition) bool {
+	if o.action == -1 {
+		return o.step < other.step
+	}
+
+	return o.step < other.step || (o.step == other.step && o.action < other.action)
+}
+
+func NewOctopusOutputVariableReferencesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusOutputVariableReferencesCheck {
+	return OctopusOutputVariableReferencesCheck{config: config, client: client, errorHandler: errorHandler}
+}
+
+func (o OctopusOutputVariableReferencesCheck) Id() string {
+	return OctoLintInvalidOutputVariables
+}
+
+func (o OctopusOutputVariableReferencesCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView, checks.PermissionVariableView}
+}
+
+func (o OctopusOutputVariableReferencesCheck) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
+func (o OctopusOutputVariableReferencesCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
+
+	defer func() {
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
+	}()
+
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
+		o.client,
+		o.client.GetSpaceID(),
+		o.config.ExcludeProjectsExcept,
+		o.config.ExcludeProjects,
+		o.config.MaxOutputVariablesProjects,
+		o.config.PageSize)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
+	}
+
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
+	g, _ := errgroup.WithContext(context.Background())
+	g.SetLimit(concurrency)
+
+	loader := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize)
+	invalidReferences := threadsafe.NewSlice[checks.OctopusCheckFinding]()
+	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
+
+	for i, p := range projects {
+		i := i
+		p := p
+
+		g.Go(func() error {
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
+
+			processes, runbookProcesses, err := o.processes(loader, p)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
+				}
+				return nil
+			}
+
+			variableSet, err := loader.GetVariables(p)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
+				}
+				return nil
+			}
+
+			for _, finding := range checks.RunbookActionFindings(o.findings(p, variableSet, processes), runbookProcesses) {
+				invalidReferences.Append(finding)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Treat the first error as the root cause
+	if goroutineErrors.Length() > 0 {
+		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
+	}
+
+	return o.result(invalidReferences.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
+
+func (o OctopusOutputVariableReferencesCheck) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	invalidReferences := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		processes := [][]*deployments.DeploymentStep{}
+
+		if p.DeploymentProcess != nil {
+			processes = append(processes, p.DeploymentProcess.Steps)
+		}
+
+		for _, runbookProcess := range p.RunbookProcesses {
+			processes = append(processes, runbookProcess.Steps)
+		}
+
+		invalidReferences = append(invalidReferences, p.Locate(o.findings(p.Project, p.Variables, processes))...)
+	}
+
+	return o.result(invalidReferences, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
+}
+
+// processes returns the steps of the deployment process and each runbook process of a project, along with the
+// runbook processes. Output variables are only available to the steps in the same process.
+func (o OctopusOutputVariableReferencesCheck) processes(loader client_wrapper.ProcessLoader, project *projects.Project) ([][]*deployments.DeploymentStep, []*runbooks.RunbookProcess, error) {
+	processes := [][]*deployments.DeploymentStep{}
+
+	deploymentProcess, err := loader.GetDeploymentProcess(project)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if deploymentProcess != nil {
+		processes = append(processes, deploymentProcess.Steps)
+	}
+
+	runbookProcesses, err := loader.GetRunbookProcesses(project)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, runbookProcess := range runbookProcesses {
+		processes = append(processes, runbookProcess.Steps)
+	}
+
+	return processes, runbookProcesses, nil
+}
+
+func (o OctopusOutputVariableReferencesCheck) result(invalidReferences []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(invalidReferences) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following steps and variables reference output variables from steps that do not exist or have not run yet:\n"+strings.Join(checks.FindingDescriptions(invalidReferences), "\n"),
+			o.Id(),
+			"",
+			checks.Warning,
+			checks.Organization,
+			invalidReferences).WithCoverage(coverage)
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are no invalid output variable references",
+		o.Id(),
+		"",
+		checks.Ok,
+		checks.Organization).WithCoverage(coverage)
+}
+
+// positions returns the positions of the steps and actions in a process, indexed by the lower case name used to
+// reference them. Actions are referenced with Octopus.Action[Name], and steps with Octopus.Step[Name]. Steps with a
+// single action typically share the name of the action, so Octopus.Action[Name] also matches step names.
+func (o OctopusOutputVariableReferencesCheck) positions(steps []*deployments.DeploymentStep) (map[string][]stepPosition, map[string][]stepPosition) {
+	actionPositions := map[string][]stepPosition{}
+	stepPositions := map[string][]stepPosition{}
+
+	for i, s := range steps {
+		stepPositions[strings.ToLower(s.Name)] = append(stepPositions[strings.ToLower(s.Name)], stepPosition{step: i, action: -1})
+		actionPositions[strings.ToLower(s.Name)] = append(actionPositions[strings.ToLower(s.Name)], stepPosition{step: i, action: -1})
+
+		for j, a := range s.Actions {
+			actionPositions[strings.ToLower(a.Name)] = append(actionPositions[strings.ToLower(a.Name)], stepPosition{step: i, action: j})
+		}
+	}
+
+	return actionPositions, stepPositions
+}
+
+// invalidReferences describes the output variable references in the text that refer to a step that does not exist
+// in the process. If the position of the referencing action is supplied, references to steps that do not run before
+// the action are also described.
+func (o OctopusOutputVariableReferencesCheck) invalidReferences(text string, actionPositions map[string][]stepPosition, stepPositions map[string][]stepPosition, position *stepPosition) []string {
+	invalid := []string{}
+
+	for _, name := range octostache.Names(text) {
+		match := outputVariableReference.FindStringSubmatch(name)
+
+		// Steps referenced by other variables, like #{Octopus.Action[#{StepName}].Output.Url}, can't be resolved
+		if match == nil || strings.Contains(match[2], "#{") {
+			continue
+		}
+
+		targets := actionPositions[strings.ToLower(match[2])]
+		if strings.EqualFold(match[1], "Step") {
+			targets = stepPositions[strings.ToLower(match[2])]
+		}
+
+		if len(targets) == 0 {
+			invalid = append(invalid, "#{"+name+"} (step does not exist)")
+			continue
+		}
+
+		if position == nil {
+			continue
+		}
+
+		precedes := false
+		for _, target := range targets {
+			if target.precedes(*position) {
+				precedes = true
+				break
+			}
+		}
+
+		if !precedes {
+			invalid = append(invalid, "#{"+name+"} (step has not run yet)")
+		}
+	}
+
+	return invalid
+}
+
+func (o OctopusOutputVariableReferencesCheck) findings(project *projects.Project, variableSet *variables.VariableSet, processes [][]*deployments.DeploymentStep) []checks.OctopusCheckFinding {
+	findings := []checks.OctopusCheckFinding{}
+
+	// Project variables may be used by any process, so they are only checked for steps that exist in a process
+	allActionPositions := map[string][]stepPosition{}
+	allStepPositions := map[string][]stepPosition{}
+
+	for _, steps := range processes {
+		actionPositions, stepPositions := o.positions(steps)
+
+		for name, positions := range actionPositions {
+			allActionPositions[name] = append(allActionPositions[name], positions...)
+		}
+
+		for name, positions := range stepPositions {
+			allStepPositions[name] = append(allStepPositions[name], positions...)
+		}
+
+		findings = append(findings, checks.ActionReferenceFindings(project, steps, func(stepIndex int, actionIndex int, action *deployments.DeploymentAction) checks.ReferenceFunc {
+			position := stepPosition{step: stepIndex, action: actionIndex}
+			return func(value string) []string {
+				return o.invalidReferences(value, actionPositions, stepPositions, &position)
+			}
+		})...)
+	}
+
+	return append(findings, checks.VariableReferenceFindings(project, variableSet, func(value string) []string {
+		return o.invalidReferences(value, allActionPositions, allStepPositions, nil)
+	})...)
+}
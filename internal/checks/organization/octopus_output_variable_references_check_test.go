@@ -0,0 +1,95 @@
+package organization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+func outputVariablesProject(scripts []string, variableValue string) checks.OctopusOfflineProject {
+	project := projects.NewProject("Output Vars", "", "")
+	project.ID = "Projects-1"
+
+	deploymentProcess := deployments.NewDeploymentProcess(project.ID)
+	names := []string{"Create Database", "Deploy Web App", "Smoke Test"}
+
+	for i, script := range scripts {
+		step := deployments.NewDeploymentStep(names[i])
+		action := deployments.NewDeploymentAction(names[i], "Octopus.Script")
+		action.ID = "Actions-" + names[i]
+		action.Properties["Octopus.Action.Script.ScriptBody"] = core.NewPropertyValue(script, false)
+		step.Actions = append(step.Actions, action)
+		deploymentProcess.Steps = append(deploymentProcess.Steps, step)
+	}
+
+	variableSet := variables.NewVariableSet()
+	variable := variables.NewVariable("Web.Url")
+	variable.ID = "Variables-1"
+	variable.Value = variableValue
+	variableSet.Variables = append(variableSet.Variables, variable)
+
+	return checks.OctopusOfflineProject{Project: project, DeploymentProcess: deploymentProcess, Variables: variableSet}
+}
+
+func TestValidOutputVariableReferences(t *testing.T) {
+	check := NewOctopusOutputVariableReferencesCheck(nil, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+
+	result, err := check.ExecuteOffline([]checks.OctopusOfflineProject{outputVariablesProject(
+		[]string{
+			"Set-OctopusVariable -name Server -value db",
+			"echo #{Octopus.Action[Create Database].Output.Server}",
+			"echo #{Octopus.Step[deploy web app].Output.Url} $OctopusParameters[\"Octopus.Action[#{StepName}].Output.Url\"]",
+		},
+		"#{Octopus.Action[Deploy Web App].Output[Web01].Url}")})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result.Severity() != checks.Ok {
+		t.Fatal("Check should have passed, got " + result.Description())
+	}
+}
+
+func TestInvalidOutputVariableReferences(t *testing.T) {
+	check := NewOctopusOutputVariableReferencesCheck(nil, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+
+	result, err := check.ExecuteOffline([]checks.OctopusOfflineProject{outputVariablesProject(
+		[]string{
+			"echo #{Octopus.Action[Deploy Web App].Output.Url}",
+			"echo #{Octopus.Action[Deploy Web App].Output.Url}",
+			"echo $OctopusParameters[\"Octopus.Action[Create DB].Output.Server\"]",
+		},
+		"#{Octopus.Action[Deploy Website].Output.Url}")})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result.Severity() != checks.Warning {
+		t.Fatal("Check should have failed")
+	}
+
+	if len(result.Findings()) != 4 {
+		t.Fatal("Check should have reported 4 findings, got " + result.Description())
+	}
+
+	expected := []string{
+		"Output Vars/Create Database: Octopus.Action.Script.ScriptBody references #{Octopus.Action[Deploy Web App].Output.Url} (step has not run yet)",
+		"Output Vars/Deploy Web App: Octopus.Action.Script.ScriptBody references #{Octopus.Action[Deploy Web App].Output.Url} (step has not run yet)",
+		"Output Vars/Smoke Test: Octopus.Action.Script.ScriptBody references #{Octopus.Action[Create DB].Output.Server} (step does not exist)",
+		"Output Vars: Web.Url: value references #{Octopus.Action[Deploy Website].Output.Url} (step does not exist)",
+	}
+
+	for _, description := range expected {
+		if !strings.Contains(result.Description(), description) {
+			t.Fatal("Check should have reported \"" + description + "\", got " + result.Description())
+		}
+	}
+}
@@ -6,11 +6,12 @@ import (
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	"strings"
@@ -33,35 +34,40 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Id() string {
 	return OctoLintProjectGroupsWithExclusiveEnvironments
 }
 
+func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectGroupView, checks.PermissionProjectView, checks.PermissionLifecycleView}
+}
+
 func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	allProjectGroups, err := o.client.ProjectGroups.GetAll()
+	allProjectGroups, err := client_wrapper.NewSpacePager[projectgroups.ProjectGroup](o.client, o.client.GetSpaceID(), "projectgroups", o.config.PageSize, 0).Collect()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allProjects, err := client_wrapper.GetProjectsWithFilter(
+	allProjects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxExclusiveEnvironmentsProjects)
+		o.config.MaxExclusiveEnvironmentsProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allLifecycles, err := o.client.Lifecycles.GetAll()
+	allLifecycles, err := client_wrapper.NewSpacePager[lifecycles.Lifecycle](o.client, o.client.GetSpaceID(), "lifecycles", o.config.PageSize, 0).Collect()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -70,7 +76,7 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	projectGroupsWithExclusiveEnvs := threadsafe.NewSlice[string]()
+	projectGroupsWithExclusiveEnvs := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 
 	for i, pg := range allProjectGroups {
 
@@ -79,7 +85,7 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 
 		g.Go(func() error {
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjectGroups))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjectGroups))*100) + "% complete")
 
 			// Find the groups of environments captured in the default lifecyles of the projects in the project group
 			envGroups := [][]string{}
@@ -110,8 +116,14 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 				}
 
 				// if none of the environments from this lifecycle are found in any other lifecycles, we have an project with exclusive environments
-				if allExclusive && !projectGroupsWithExclusiveEnvs.Contains(pg.Name) {
-					projectGroupsWithExclusiveEnvs.Append(pg.Name)
+				finding := checks.OctopusCheckFinding{
+					ResourceType: checks.ResourceTypeProjectGroup,
+					ResourceId:   pg.ID,
+					ResourceName: pg.Name,
+					Description:  pg.Name,
+				}
+				if allExclusive && !projectGroupsWithExclusiveEnvs.Contains(finding) {
+					projectGroupsWithExclusiveEnvs.Append(finding)
 				}
 			}
 
@@ -123,13 +135,16 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 		return nil, err
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(allProjects), 0)
+
 	if projectGroupsWithExclusiveEnvs.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project groups contain projects with mutually exclusive environments in their default lifecycle:\n"+strings.Join(projectGroupsWithExclusiveEnvs.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following project groups contain projects with mutually exclusive environments in their default lifecycle:\n"+strings.Join(checks.FindingDescriptions(projectGroupsWithExclusiveEnvs.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			projectGroupsWithExclusiveEnvs.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -137,7 +152,7 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
 
 func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) getLifecycleEnvironments(lifecycle *lifecycles.Lifecycle) []string {
@@ -3,13 +3,14 @@ package organization
 import (
 	"errors"
 	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"golang.org/x/exp/slices"
 	"strings"
 )
@@ -31,41 +32,46 @@ func (o OctopusProjectSpecificEnvironmentCheck) Id() string {
 	return OctoLintProjectSpecificEnvs
 }
 
+func (o OctopusProjectSpecificEnvironmentCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionLifecycleView, checks.PermissionEnvironmentView}
+}
+
 func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxProjectSpecificEnvironmentProjects)
+		o.config.MaxProjectSpecificEnvironmentProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allLifecycles, err := o.client.Lifecycles.GetAll()
+	allLifecycles, err := client_wrapper.NewSpacePager[lifecycles.Lifecycle](o.client, o.client.GetSpaceID(), "lifecycles", o.config.PageSize, 0).Collect()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allEnvironments, err := client_wrapper.GetEnvironments(o.config.MaxProjectSpecificEnvironmentEnvironments, o.client, o.client.GetSpaceID())
+	allEnvironments, availableEnvironments, err := client_wrapper.GetEnvironments(o.config.MaxProjectSpecificEnvironmentEnvironments, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allChannels, err := o.client.Channels.GetAll()
+	allChannels, err := client_wrapper.NewSpacePager[channels.Channel](o.client, o.client.GetSpaceID(), "channels", o.config.PageSize, 0).Collect()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -74,7 +80,7 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 	// count the number of times an environment is referenced by a project
 	environmentCount := map[string][]string{}
 	for i, p := range projects {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
 		projectEnvironments := []string{}
 
@@ -128,6 +134,11 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 
 	}
 
+	coverage := []checks.ResourceCoverage{
+		checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), 0),
+		checks.NewResourceCoverage(checks.ResourceTypeEnvironment, availableEnvironments, len(allEnvironments), 0),
+	}
+
 	// filter down to allEnvironments that have one project
 	singleProjectEnvironments := map[string]string{}
 	for env, envProjects := range environmentCount {
@@ -137,21 +148,27 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 	}
 
 	if len(singleProjectEnvironments) > 0 {
-		messages := []string{}
+		messages := []checks.OctopusCheckFinding{}
 		for env, envProject := range singleProjectEnvironments {
 			environment := o.getEnvironmentById(allEnvironments, env)
 			if environment == nil {
 				continue
 			}
-			messages = append(messages, environment.Name+" ("+envProject+")")
+			messages = append(messages, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeEnvironment,
+				ResourceId:   environment.ID,
+				ResourceName: environment.Name,
+				Description:  environment.Name + " (" + envProject + ")",
+			})
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following environments are used by a single project:\n"+strings.Join(messages, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following environments are used by a single project:\n"+strings.Join(checks.FindingDescriptions(messages), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			messages).WithCoverage(coverage...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -159,7 +176,7 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage...), nil
 }
 
 func (o OctopusProjectSpecificEnvironmentCheck) getLifecycleById(lifecycles []*lifecycles.Lifecycle, id string) *lifecycles.Lifecycle {
@@ -5,14 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"strings"
+	"sync/atomic"
 )
 
 const maxStepCount = 20
@@ -33,33 +34,45 @@ func (o OctopusProjectTooManyStepsCheck) Id() string {
 	return OctoLintTooManySteps
 }
 
+func (o OctopusProjectTooManyStepsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView}
+}
+
+func (o OctopusProjectTooManyStepsCheck) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxProjectStepsProjects)
+		o.config.MaxProjectStepsProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	complexProjects := threadsafe.NewSlice[string]()
+	complexProjects := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 
@@ -68,19 +81,21 @@ func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.Octopu
 
 		g.Go(func() error {
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			stepCount, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			deploymentProcess, err := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize).GetDeploymentProcess(p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			if stepCount >= maxStepCount {
-				complexProjects.Append(p.Name)
+			if o.hasTooManySteps(deploymentProcess) {
+				complexProjects.Append(checks.NewProjectFinding(p))
 			}
 
 			return nil
@@ -96,13 +111,33 @@ func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.Octopu
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
-	if complexProjects.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects have 20 or more steps:\n"+strings.Join(complexProjects.Values(), "\n"),
+	return o.result(complexProjects.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
+
+func (o OctopusProjectTooManyStepsCheck) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	complexProjects := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		if o.hasTooManySteps(p.DeploymentProcess) {
+			complexProjects = append(complexProjects, p.Locate([]checks.OctopusCheckFinding{checks.NewProjectFinding(p.Project)})...)
+		}
+	}
+
+	return o.result(complexProjects, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
+}
+
+func (o OctopusProjectTooManyStepsCheck) hasTooManySteps(deploymentProcess *deployments.DeploymentProcess) bool {
+	return deploymentProcess != nil && len(deploymentProcess.Steps) >= maxStepCount
+}
+
+func (o OctopusProjectTooManyStepsCheck) result(complexProjects []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(complexProjects) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following projects have 20 or more steps:\n"+strings.Join(checks.FindingDescriptions(complexProjects), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			complexProjects).WithCoverage(coverage)
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -110,23 +145,5 @@ func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.Octopu
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
-}
-
-func (o OctopusProjectTooManyStepsCheck) stepsInDeploymentProcess(deploymentProcessID string) (int, error) {
-	if deploymentProcessID == "" {
-		return 0, nil
-	}
-
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
-
-	if err != nil {
-		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
-			return 0, nil
-		}
-		return 0, err
-	}
-
-	return len(resource.Steps), nil
+		checks.Organization).WithCoverage(coverage)
 }
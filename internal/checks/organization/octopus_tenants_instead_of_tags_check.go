@@ -3,13 +3,15 @@ package organization
 import (
 	"errors"
 	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"golang.org/x/exp/slices"
 	"strings"
 )
@@ -31,36 +33,40 @@ func (o OctopusTenantsInsteadOfTagsCheck) Id() string {
 	return OctoLintDirectTenantReferences
 }
 
+func (o OctopusTenantsInsteadOfTagsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionTenantView, checks.PermissionAccountView, checks.PermissionCertificateView, checks.PermissionMachineView}
+}
+
 func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	allTenants, err := client_wrapper.GetTenants(o.config.MaxTenantTagsTenants, o.client, o.client.GetSpaceID())
+	allTenants, availableTenants, err := client_wrapper.GetTenants(o.config.MaxTenantTagsTenants, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allAccounts, err := o.client.Accounts.GetAll()
+	allAccounts, err := client_wrapper.NewSpacePager[accounts.AccountResource](o.client, o.client.GetSpaceID(), "accounts", o.config.PageSize, 0).Collect()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allCertificates, err := o.client.Certificates.GetAll()
+	allCertificates, err := client_wrapper.NewSpacePager[certificates.CertificateResource](o.client, o.client.GetSpaceID(), "certificates", o.config.PageSize, 0).Collect()
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxTenantTagsTargets, o.client, o.client.GetSpaceID())
+	allMachines, availableMachines, err := client_wrapper.GetMachines(o.config.MaxTenantTagsTargets, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -69,7 +75,7 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 	tenantReferenceCounts := map[string]int{}
 	tenantReferenceSources := map[string][]string{}
 	for i, a := range allAccounts {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allAccounts))*33) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allAccounts))*33) + "% complete")
 
 		if a.GetTenantedDeploymentMode() == core.TenantedDeploymentModeTenantedOrUntenanted {
 			o.addTenants(a.GetTenantIDs(), "Account - "+a.GetName(), tenantReferenceCounts, tenantReferenceSources)
@@ -77,7 +83,7 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 	}
 
 	for i, c := range allCertificates {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allCertificates))*33) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allCertificates))*33) + "% complete")
 
 		if c.TenantedDeploymentMode == core.TenantedDeploymentModeTenantedOrUntenanted {
 			o.addTenants(c.TenantIDs, "Certificate - "+c.Name, tenantReferenceCounts, tenantReferenceSources)
@@ -85,13 +91,20 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 	}
 
 	for i, m := range allMachines {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*33) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*33) + "% complete")
 
 		if m.TenantedDeploymentMode == core.TenantedDeploymentModeTenantedOrUntenanted {
 			o.addTenants(m.TenantIDs, "Target - "+m.Name, tenantReferenceCounts, tenantReferenceSources)
 		}
 	}
 
+	coverage := []checks.ResourceCoverage{
+		checks.NewResourceCoverage(checks.ResourceTypeTenant, availableTenants, len(allTenants), 0),
+		checks.NewFullResourceCoverage(checks.ResourceTypeAccount, len(allAccounts)),
+		checks.NewFullResourceCoverage(checks.ResourceTypeCertificate, len(allCertificates)),
+		checks.NewResourceCoverage(checks.ResourceTypeTarget, availableMachines, len(allMachines), 0),
+	}
+
 	// get any commonly grouped tenants
 	multipleTenantReferences := []string{}
 	for tenantGroups, groupCount := range tenantReferenceCounts {
@@ -103,22 +116,28 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 	if len(multipleTenantReferences) > 0 {
 
 		// We have to convert the comma separated list of tenant IDs into a comma separated list of tenant names
-		groupedTenants := []string{}
+		groupedTenants := []checks.OctopusCheckFinding{}
 		for _, groupedTenant := range multipleTenantReferences {
 			splitTenants := strings.Split(groupedTenant, ",")
 			splitTenantNames := []string{}
 			for _, splitTenant := range splitTenants {
 				splitTenantNames = append(splitTenantNames, o.getTenantNameById(allTenants, splitTenant))
 			}
-			groupedTenants = append(groupedTenants, strings.Join(splitTenantNames, ", ")+" ("+strings.Join(tenantReferenceSources[groupedTenant], ", ")+")")
+			groupedTenants = append(groupedTenants, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeTenantGroup,
+				ResourceId:   groupedTenant,
+				ResourceName: strings.Join(splitTenantNames, ", "),
+				Description:  strings.Join(splitTenantNames, ", ") + " (" + strings.Join(tenantReferenceSources[groupedTenant], ", ") + ")",
+			})
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following groups of tenants have been directly referenced more than once, and may be better grouped as tenant tags:\n"+strings.Join(groupedTenants, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following groups of tenants have been directly referenced more than once, and may be better grouped as tenant tags:\n"+strings.Join(checks.FindingDescriptions(groupedTenants), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			groupedTenants).WithCoverage(coverage...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -126,7 +145,7 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage...), nil
 }
 
 func (o OctopusTenantsInsteadOfTagsCheck) getTenantNameById(tenants []*tenants.Tenant, id string) string {
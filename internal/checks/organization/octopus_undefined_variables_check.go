@@ -0,0 +1,294 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/octostache"
+	"github.com/hayageek/threadsafe"
+	"golang.org/x/sync/errgroup"
+)
+
+const OctoLintUndefinedVariables = "OctoLintUndefinedVariables"
+
+// OctopusUndefinedVariablesCheck checks to see if any step or variable references a variable that is not defined by
+// the project, an included library variable set, a tenant variable template, or Octopus itself. Octopus leaves
+// these references unsubstituted, which is typically caused by a typo.
+type OctopusUndefinedVariablesCheck struct {
+	client       *client.Client
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+}
+
+// libraryVariableSet holds the variables and tenant variable templates defined by a library variable set.
+type libraryVariableSet struct {
+	variables []*variables.Variable
+	templates []string
+}
+
+// definedVariables holds the lower case names of the variables available to a project. Variables with a type
+// other than String, like accounts and certificates, expose additional properties like "Account.Client".
+type definedVariables struct {
+	names     map[string]bool
+	typeNames map[string]bool
+}
+
+func NewOctopusUndefinedVariablesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUndefinedVariablesCheck {
+	return OctopusUndefinedVariablesCheck{config: config, client: client, errorHandler: errorHandler}
+}
+
+func (o OctopusUndefinedVariablesCheck) Id() string {
+	return OctoLintUndefinedVariables
+}
+
+func (o OctopusUndefinedVariablesCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView, checks.PermissionVariableView, checks.PermissionLibraryVariableSetView}
+}
+
+func (o OctopusUndefinedVariablesCheck) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
+func (o OctopusUndefinedVariablesCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
+
+	defer func() {
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
+	}()
+
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
+		o.client,
+		o.client.GetSpaceID(),
+		o.config.ExcludeProjectsExcept,
+		o.config.ExcludeProjects,
+		o.config.MaxUndefinedVariablesProjects,
+		o.config.PageSize)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
+	}
+
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
+	libraryVariableSets, err := o.libraryVariableSets(projects)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
+	}
+
+	g, _ := errgroup.WithContext(context.Background())
+	g.SetLimit(concurrency)
+
+	loader := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize)
+	undefinedReferences := threadsafe.NewSlice[checks.OctopusCheckFinding]()
+	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
+
+	for i, p := range projects {
+		i := i
+		p := p
+
+		g.Go(func() error {
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
+
+			variableSet, err := loader.GetVariables(p)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
+				}
+				return nil
+			}
+
+			deploymentSteps, runbookProcesses, err := loader.GetAllSteps(p)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
+				}
+				return nil
+			}
+
+			for _, finding := range checks.RunbookActionFindings(o.findings(p, variableSet, deploymentSteps, o.definedVariables(p, variableSet, libraryVariableSets)), runbookProcesses) {
+				undefinedReferences.Append(finding)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Treat the first error as the root cause
+	if goroutineErrors.Length() > 0 {
+		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
+	}
+
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))
+
+	if undefinedReferences.Length() > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following steps and variables reference variables that are not defined, which Octopus leaves unsubstituted:\n"+strings.Join(checks.FindingDescriptions(undefinedReferences.Values()), "\n"),
+			o.Id(),
+			"",
+			checks.Warning,
+			checks.Organization,
+			undefinedReferences.Values()).WithCoverage(coverage), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are no references to undefined variables",
+		o.Id(),
+		"",
+		checks.Ok,
+		checks.Organization).WithCoverage(coverage), nil
+}
+
+// libraryVariableSets loads the library variable sets included by any of the projects, so each set is only
+// loaded once.
+func (o OctopusUndefinedVariablesCheck) libraryVariableSets(projects []*projects.Project) (map[string]libraryVariableSet, error) {
+	libraryVariableSets := map[string]libraryVariableSet{}
+
+	for _, p := range projects {
+		for _, id := range p.IncludedLibraryVariableSets {
+			if _, ok := libraryVariableSets[id]; ok {
+				continue
+			}
+
+			resource, err := o.client.LibraryVariableSets.GetByID(id)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					return nil, err
+				}
+				continue
+			}
+
+			variableSet, err := o.client.Variables.GetAll(id)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					return nil, err
+				}
+				continue
+			}
+
+			templates := []string{}
+			for _, template := range resource.Templates {
+				templates = append(templates, template.Name)
+			}
+
+			libraryVariableSets[id] = libraryVariableSet{variables: variableSet.Variables, templates: templates}
+		}
+	}
+
+	return libraryVariableSets, nil
+}
+
+func (o OctopusUndefinedVariablesCheck) definedVariables(project *projects.Project, variableSet *variables.VariableSet, libraryVariableSets map[string]libraryVariableSet) definedVariables {
+	defined := definedVariables{names: map[string]bool{}, typeNames: map[string]bool{}}
+
+	addVariables := func(projectVariables []*variables.Variable) {
+		for _, v := range projectVariables {
+			defined.names[strings.ToLower(v.Name)] = true
+			if v.Type != "" && v.Type != "String" {
+				defined.typeNames[strings.ToLower(v.Name)] = true
+			}
+		}
+	}
+
+	if variableSet != nil {
+		addVariables(variableSet.Variables)
+	}
+
+	// Tenant variables are defined by the templates of the project and library variable sets
+	for _, template := range project.Templates {
+		defined.names[strings.ToLower(template.Name)] = true
+	}
+
+	for _, id := range project.IncludedLibraryVariableSets {
+		addVariables(libraryVariableSets[id].variables)
+		for _, template := range libraryVariableSets[id].templates {
+			defined.names[strings.ToLower(template)] = true
+		}
+	}
+
+	return defined
+}
+
+// isDefined returns true if the reference is to a variable that is available to the project. Step template
+// parameters are exposed as the properties of the action, which are supplied as the local names.
+func (o OctopusUndefinedVariablesCheck) isDefined(name string, defined definedVariables, local map[string]bool) bool {
+	lowerName := strings.ToLower(name)
+
+	// References built from other references, like #{Octopus.Action[#{StepName}].Output.Value}, can't be resolved
+	if strings.Contains(name, "#{") || checks.IsSystemVariable(name) || defined.names[lowerName] || local[lowerName] {
+		return true
+	}
+
+	// Indexed references, like #{Endpoint[Web].Url}, refer to variables like "Endpoint[Web].Url"
+	if index := strings.Index(lowerName, "["); index != -1 {
+		for definedName := range defined.names {
+			if strings.HasPrefix(definedName, lowerName[:index+1]) {
+				return true
+			}
+		}
+	}
+
+	// Properties of typed variables, like #{Account.Client} for an Azure account variable called "Account"
+	for index := strings.LastIndex(lowerName, "."); index != -1; index = strings.LastIndex(lowerName[:index], ".") {
+		if defined.typeNames[lowerName[:index]] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// undefined returns the undefined variables referenced by the text, formatted as Octostache expressions.
+func (o OctopusUndefinedVariablesCheck) undefined(text string, defined definedVariables, local map[string]bool) []string {
+	undefined := []string{}
+	for _, name := range octostache.Names(text) {
+		if !o.isDefined(name, defined, local) {
+			undefined = append(undefined, "#{"+name+"}")
+		}
+	}
+	return undefined
+}
+
+func (o OctopusUndefinedVariablesCheck) findings(project *projects.Project, variableSet *variables.VariableSet, deploymentSteps []*deployments.DeploymentStep, defined definedVariables) []checks.OctopusCheckFinding {
+	// The properties of an action, like Octopus.Action.Script.ScriptBody, can be referenced by the action itself
+	findings := checks.ActionReferenceFindings(project, deploymentSteps, func(stepIndex int, actionIndex int, action *deployments.DeploymentAction) checks.ReferenceFunc {
+		local := map[string]bool{}
+		for name := range action.Properties {
+			local[strings.ToLower(name)] = true
+		}
+
+		return func(value string) []string { return o.undefined(value, defined, local) }
+	})
+
+	return append(findings, checks.VariableReferenceFindings(project, variableSet, func(value string) []string {
+		return o.undefined(value, defined, map[string]bool{})
+	})...)
+}
@@ -0,0 +1,87 @@
+package organization
+
+import (
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/octoclient"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/v2/test"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNoUndefinedVars(t *testing.T) {
+	testFramework := test.OctopusContainerTest{}
+	testFramework.ArrangeTest(t, func(t *testing.T, container *test.OctopusContainer, client *client.Client) error {
+		// Act
+		newSpaceId, err := testFramework.Act(t, container, filepath.Join("..", "..", "..", "test", "terraform"), "8-nounusedvars", []string{})
+
+		if err != nil {
+			return err
+		}
+
+		newSpaceClient, err := octoclient.CreateClient(container.URI, newSpaceId, test.ApiKey)
+
+		if err != nil {
+			return err
+		}
+
+		check := NewOctopusUndefinedVariablesCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+
+		result, err := check.Execute(2)
+
+		if err != nil {
+			return err
+		}
+
+		// Assert
+		if result.Severity() != checks.Ok {
+			return errors.New("Check should have passed")
+		}
+
+		return nil
+	})
+}
+
+func TestUndefinedVars(t *testing.T) {
+	testFramework := test.OctopusContainerTest{}
+	testFramework.ArrangeTest(t, func(t *testing.T, container *test.OctopusContainer, client *client.Client) error {
+		// Act
+		newSpaceId, err := testFramework.Act(t, container, filepath.Join("..", "..", "..", "test", "terraform"), "34-undefinedvars", []string{})
+
+		if err != nil {
+			return err
+		}
+
+		newSpaceClient, err := octoclient.CreateClient(container.URI, newSpaceId, test.ApiKey)
+
+		if err != nil {
+			return err
+		}
+
+		check := NewOctopusUndefinedVariablesCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+
+		result, err := check.Execute(2)
+
+		if err != nil {
+			return err
+		}
+
+		// Assert
+		if result.Severity() != checks.Warning {
+			return errors.New("Check should have failed")
+		}
+
+		if len(result.Findings()) != 1 || !strings.Contains(result.Findings()[0].Description, "#{Varaible.Typo}") {
+			return errors.New("Check should have reported the misspelled variable")
+		}
+
+		if strings.Contains(result.Description(), "Octopus.Environment.Name") {
+			return errors.New("Check should not have reported the system variable")
+		}
+
+		return nil
+	})
+}
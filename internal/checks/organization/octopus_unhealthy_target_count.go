@@ -5,14 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -34,18 +35,22 @@ func (o OctopusUnhealthyTargetCheck) Id() string {
 	return OctoLintUnhealthyTargets
 }
 
+func (o OctopusUnhealthyTargetCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionMachineView, checks.PermissionEventView}
+}
+
 func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxUnhealthyTargets, o.client, o.client.GetSpaceID())
+	allMachines, availableMachines, err := client_wrapper.GetMachines(o.config.MaxUnhealthyTargets, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -54,8 +59,9 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	unhealthyMachines := threadsafe.NewSlice[string]()
+	unhealthyMachines := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, m := range allMachines {
 		i := i
@@ -63,24 +69,34 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 
 		g.Go(func() error {
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
 
 			wasEverHealthy := true
 			if m.HealthStatus == "Unhealthy" {
 				wasEverHealthy = false
 
-				targetEvents, err := o.client.Events.Get(events.EventsQuery{
-					Regarding: m.ID,
-				})
-
-				if err != nil {
-					if !o.errorHandler.ShouldContinue(err) {
-						goroutineErrors.Append(err)
+				// Any healthy event in the period means the target was healthy, so only the first event is read
+				pager := client_wrapper.NewEventPager(
+					o.client,
+					o.client.GetSpaceID(),
+					url.Values{
+						"regarding":       []string{m.ID},
+						"eventCategories": []string{"MachineHealthy"},
+						"from":            []string{time.Now().Add(-maxHealthCheckTime).Format("2006-01-02T15:04:05-0700")},
+					},
+					1,
+					1)
+
+				for e, err := range pager.Items() {
+					if err != nil {
+						if !o.errorHandler.ShouldContinue(err) {
+							goroutineErrors.Append(err)
+						} else {
+							skipped.Add(1)
+						}
+						return nil
 					}
-					return nil
-				}
 
-				for _, e := range targetEvents.Items {
 					if e.Category == "MachineHealthy" && time.Now().Sub(e.Occurred) < maxHealthCheckTime {
 						wasEverHealthy = true
 						break
@@ -89,7 +105,7 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 			}
 
 			if !wasEverHealthy {
-				unhealthyMachines.Append(m.Name)
+				unhealthyMachines.Append(checks.NewTargetFinding(m))
 			}
 
 			return nil
@@ -105,13 +121,16 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeTarget, availableMachines, len(allMachines), int(skipped.Load()))
+
 	if unhealthyMachines.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following targets have not been healthy in the last 30 days:\n"+strings.Join(unhealthyMachines.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following targets have not been healthy in the last 30 days:\n"+strings.Join(checks.FindingDescriptions(unhealthyMachines.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unhealthyMachines.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -119,5 +138,5 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
@@ -76,7 +76,7 @@ func TestUnhealthyTargets(t *testing.T) {
 }
 
 func startHealthCheck(newSpaceClient *client.Client) error {
-	machines, err := client_wrapper.GetMachines(0, newSpaceClient, newSpaceClient.GetSpaceID())
+	machines, err := client_wrapper.GetMachines(0, 0, newSpaceClient, newSpaceClient.GetSpaceID())
 
 	if err != nil {
 		return err
@@ -106,7 +106,7 @@ func startHealthCheck(newSpaceClient *client.Client) error {
 }
 
 func checkMachinesUnhealthy(newSpaceClient *client.Client) (bool, error) {
-	machines, err := client_wrapper.GetMachines(0, newSpaceClient, newSpaceClient.GetSpaceID())
+	machines, err := client_wrapper.GetMachines(0, 0, newSpaceClient, newSpaceClient.GetSpaceID())
 
 	if err != nil {
 		return false, err
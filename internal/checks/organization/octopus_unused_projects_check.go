@@ -5,13 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -33,23 +33,28 @@ func (o OctopusUnusedProjectsCheck) Id() string {
 	return OctopusUnusedProjectsCheckName
 }
 
+func (o OctopusUnusedProjectsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionTaskView}
+}
+
 func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxUnusedProjects)
+		o.config.MaxUnusedProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -58,7 +63,7 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	unusedProjects := threadsafe.NewSlice[string]()
+	unusedProjects := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
 	for i, project := range projects {
@@ -66,35 +71,26 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 		project := project
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(project.Name))
 
 			// Ignore disabled projects
 			if project.IsDisabled {
 				return nil
 			}
 
-			projectHasTask := false
-
-			tasks, err := o.client.Tasks.Get(tasks.TasksQuery{
-				Project: project.ID,
-				Skip:    0,
-				Take:    100,
-			})
+			projectHasTask, err := client_wrapper.HasTaskSince(
+				o.client.HttpSession(),
+				client_wrapper.SpacePath(o.client.GetSpaceID(), "tasks?project="+url.QueryEscape(project.ID)),
+				time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask)),
+				o.config.PageSize)
 
 			if err != nil {
 				goroutineErrors.Append(err)
 				return nil
 			}
 
-			for _, task := range tasks.Items {
-				if task.StartTime != nil && task.StartTime.After(time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask))) {
-					projectHasTask = true
-					break
-				}
-			}
-
 			if !projectHasTask {
-				unusedProjects.Append(project.Name)
+				unusedProjects.Append(checks.NewProjectFinding(project))
 			}
 
 			return nil
@@ -110,15 +106,18 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), 0)
+
 	daysString := fmt.Sprintf("%d", o.config.MaxDaysSinceLastTask)
 
 	if unusedProjects.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects have not had any tasks in "+daysString+" days:\n"+strings.Join(unusedProjects.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following projects have not had any tasks in "+daysString+" days:\n"+strings.Join(checks.FindingDescriptions(unusedProjects.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedProjects.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -126,5 +125,5 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
@@ -6,18 +6,16 @@ import (
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -39,18 +37,22 @@ func (o OctopusUnusedTargetsCheck) Id() string {
 	return OctoLintUnusedTargets
 }
 
+func (o OctopusUnusedTargetsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionMachineView, checks.PermissionTaskView}
+}
+
 func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := client_wrapper.GetMachines(o.config.MaxUnusedTargets, o.client, o.client.GetSpaceID())
+	targets, availableMachines, err := client_wrapper.GetMachines(o.config.MaxUnusedTargets, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
@@ -59,8 +61,9 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	unusedMachines := threadsafe.NewSlice[string]()
+	unusedMachines := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	linksTemplate := regexp.MustCompile(`\{.+\}`)
 	for i, m := range targets {
@@ -69,28 +72,26 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 		m := m
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
 
 			tasksLink := linksTemplate.ReplaceAllString(m.Links["TasksTemplate"], "")
-			tasks, err := newclient.Get[resources.Resources[tasks.Task]](o.client.HttpSession(), tasksLink+"?type=Deployment")
+			recentTask, err := client_wrapper.HasTaskSince(
+				o.client.HttpSession(),
+				tasksLink+"?type=Deployment",
+				time.Now().Add(-maxTimeSinceLastMachineDeployment),
+				o.config.PageSize)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			recentTask := false
-			for _, t := range tasks.Items {
-				if t.CompletedTime != nil && time.Now().Sub(*t.CompletedTime) < maxTimeSinceLastMachineDeployment {
-					recentTask = true
-					break
-				}
-			}
-
 			if !recentTask {
-				unusedMachines.Append(m.Name)
+				unusedMachines.Append(checks.NewTargetFinding(m))
 			}
 
 			return nil
@@ -106,13 +107,16 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeTarget, availableMachines, len(targets), int(skipped.Load()))
+
 	if unusedMachines.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following targets have not performed a deployment in 30 days:\n"+strings.Join(unusedMachines.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following targets have not performed a deployment in 30 days:\n"+strings.Join(checks.FindingDescriptions(unusedMachines.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedMachines.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -120,7 +124,7 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
 
 // naiveStepVariableScan does a simple text search for the variable in a steps properties. This does lead to false positives as simple variables names, like "a",
@@ -5,13 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -33,19 +33,23 @@ func (o OctopusUnusedTenantsCheck) Id() string {
 	return OctopusUnusedTenantsCheckName
 }
 
+func (o OctopusUnusedTenantsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionTenantView, checks.PermissionTaskView}
+}
+
 func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	tenants, err := client_wrapper.GetTenants(
-		o.config.MaxUnusedTenants,
+	tenants, availableTenants, err := client_wrapper.GetTenants(o.config.MaxUnusedTenants,
+		o.config.PageSize,
 		o.client,
 		o.client.GetSpaceID())
 
@@ -56,7 +60,7 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	unusedTenants := threadsafe.NewSlice[string]()
+	unusedTenants := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
 	for i, tenant := range tenants {
@@ -64,35 +68,31 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 		tenant := tenant
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(tenants))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(tenants))*100) + "% complete")
 
 			// Ignore disabled projects
 			if tenant.IsDisabled {
 				return nil
 			}
 
-			tenantHasTask := false
-
-			tasks, err := o.client.Tasks.Get(tasks.TasksQuery{
-				Tenant: tenant.ID,
-				Skip:   0,
-				Take:   100,
-			})
+			tenantHasTask, err := client_wrapper.HasTaskSince(
+				o.client.HttpSession(),
+				client_wrapper.SpacePath(o.client.GetSpaceID(), "tasks?tenant="+url.QueryEscape(tenant.ID)),
+				time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask)),
+				o.config.PageSize)
 
 			if err != nil {
 				goroutineErrors.Append(err)
 				return nil
 			}
 
-			for _, task := range tasks.Items {
-				if task.StartTime != nil && task.StartTime.After(time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask))) {
-					tenantHasTask = true
-					break
-				}
-			}
-
 			if !tenantHasTask {
-				unusedTenants.Append(tenant.Name)
+				unusedTenants.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.ResourceTypeTenant,
+					ResourceId:   tenant.ID,
+					ResourceName: tenant.Name,
+					Description:  tenant.Name,
+				})
 			}
 
 			return nil
@@ -108,15 +108,18 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeTenant, availableTenants, len(tenants), 0)
+
 	daysString := fmt.Sprintf("%d", o.config.MaxDaysSinceLastTask)
 
 	if unusedTenants.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following tenants have not had any tasks in "+daysString+" days:\n"+strings.Join(unusedTenants.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following tenants have not had any tasks in "+daysString+" days:\n"+strings.Join(checks.FindingDescriptions(unusedTenants.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedTenants.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -124,5 +127,5 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage), nil
 }
@@ -4,21 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
-	"sync"
+	"sync/atomic"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbookprocess"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/octostache"
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -26,14 +23,11 @@ import (
 
 const OctoLintUnusedVariables = "OctoLintUnusedVariables"
 
-var linkOptions = regexp.MustCompile(`\{.*?}`)
-
 // OctopusUnusedVariablesCheck checks to see if any project variables are unused.
 type OctopusUnusedVariablesCheck struct {
 	client       *client.Client
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
-	mu           sync.Mutex
 }
 
 func NewOctopusUnusedVariablesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) *OctopusUnusedVariablesCheck {
@@ -44,79 +38,81 @@ func (o *OctopusUnusedVariablesCheck) Id() string {
 	return OctoLintUnusedVariables
 }
 
+func (o *OctopusUnusedVariablesCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionProcessView, checks.PermissionRunbookView, checks.PermissionVariableView}
+}
+
+func (o *OctopusUnusedVariablesCheck) ScopedResourceType() string {
+	return checks.ResourceTypeProject
+}
+
 func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxUnusedVariablesProjects)
+		o.config.MaxUnusedVariablesProjects,
+		o.config.PageSize)
 
 	if err != nil {
-		zap.L().Error("Failed to get projects", zap.Error(err))
+		logging.Check(o.Id()).Error("Failed to get projects", zap.Error(err))
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
+	projects = client_wrapper.ScopedProjects(projects, o.config.Scope)
+
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(concurrency)
 
-	unusedVars := map[*projects2.Project][]*variables.Variable{}
+	loader := client_wrapper.NewProcessLoader(o.client, o.config.GitRef, o.config.PageSize)
+	unusedVars := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
+	var skipped atomic.Int32
 
 	for i, p := range projects {
 		i := i
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
-			variableSet, err := o.client.Variables.GetAll(p.ID)
+			variableSet, err := loader.GetVariables(p)
 
 			if err != nil {
-				zap.L().Error("Failed to get variables for project "+p.Name+" in check "+o.Id(), zap.Error(err))
+				logging.Check(o.Id()).Error("Failed to get variables for project "+p.Name+" in check "+o.Id(), logging.Project(p.Name), zap.Error(err))
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			deploymentSteps, err := o.getDeploymentSteps(p)
+			deploymentSteps, _, err := loader.GetAllSteps(p)
 
 			if err != nil {
-				zap.L().Error("Failed to get deployment steps for project "+p.Name+" in check "+o.Id(), zap.Error(err))
+				logging.Check(o.Id()).Error("Failed to get deployment steps for project "+p.Name+" in check "+o.Id(), logging.Project(p.Name), zap.Error(err))
 				if !o.errorHandler.ShouldContinue(err) {
 					goroutineErrors.Append(err)
+				} else {
+					skipped.Add(1)
 				}
 				return nil
 			}
 
-			// Lock the map so we are not writing to it concurrently
-			o.mu.Lock()
-			defer o.mu.Unlock()
-
-			for _, v := range variableSet.Variables {
-				if checks.IgnoreVariable(v.Name) {
-					continue
-				}
-
-				used := o.naiveStepVariableScan(deploymentSteps, v) || o.naiveVariableSetVariableScan(variableSet, v)
-
-				if !used {
-					if _, ok := unusedVars[p]; !ok {
-						unusedVars[p] = []*variables.Variable{}
-					}
-					unusedVars[p] = append(unusedVars[p], v)
-				}
+			for _, finding := range o.findings(p, variableSet, deploymentSteps) {
+				unusedVars.Append(finding)
 			}
 
 			return nil
@@ -124,32 +120,62 @@ func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCh
 	}
 
 	if err := g.Wait(); err != nil {
-		zap.L().Error("Failed to scan projects for unused variables in check "+o.Id(), zap.Error(err))
+		logging.Check(o.Id()).Error("Failed to scan projects for unused variables in check "+o.Id(), zap.Error(err))
 		return nil, err
 	}
 
 	// Treat the first error as the root cause
 	if goroutineErrors.Length() > 0 {
-		zap.L().Error("Failed to scan projects for unused variables in check "+o.Id(), zap.Error(goroutineErrors.Values()[0]))
+		logging.Check(o.Id()).Error("Failed to scan projects for unused variables in check "+o.Id(), zap.Error(goroutineErrors.Values()[0]))
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
-	if len(unusedVars) > 0 {
-		messages := []string{}
-		for p, variables := range unusedVars {
-			if len(variables) != 0 {
-				for _, variable := range variables {
-					messages = append(messages, p.Name+": "+variable.Name)
-				}
-			}
+	return o.result(unusedVars.Values(), checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))), nil
+}
+
+func (o *OctopusUnusedVariablesCheck) ExecuteOffline(projects []checks.OctopusOfflineProject) (checks.OctopusCheckResult, error) {
+	unusedVars := []checks.OctopusCheckFinding{}
+	for _, p := range projects {
+		unusedVars = append(unusedVars, p.Locate(o.findings(p.Project, p.Variables, p.AllSteps()))...)
+	}
+
+	return o.result(unusedVars, checks.NewFullResourceCoverage(checks.ResourceTypeProject, len(projects))), nil
+}
+
+func (o *OctopusUnusedVariablesCheck) findings(project *projects2.Project, variableSet *variables.VariableSet, deploymentSteps []*deployments.DeploymentStep) []checks.OctopusCheckFinding {
+	findings := []checks.OctopusCheckFinding{}
+
+	if variableSet == nil {
+		return findings
+	}
+
+	stepReferences := o.stepReferences(deploymentSteps)
+	variableReferences := o.variableReferences(variableSet)
+
+	for _, v := range variableSet.Variables {
+		if checks.IgnoreVariable(v.Name) {
+			continue
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following variables may be unused (note there are edge cases that may use these variables that can't be detected, so double check these before deleting them): \n"+strings.Join(messages, "\n"),
+		used := stepReferences[strings.ToLower(v.Name)] || variableReferences[strings.ToLower(v.Name)]
+
+		if !used {
+			findings = append(findings, checks.NewProjectVariableFinding(project, v))
+		}
+	}
+
+	return findings
+}
+
+func (o *OctopusUnusedVariablesCheck) result(unusedVars []checks.OctopusCheckFinding, coverage checks.ResourceCoverage) checks.OctopusCheckResult {
+	if len(unusedVars) > 0 {
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following variables may be unused (note there are edge cases that may use these variables that can't be detected, so double check these before deleting them): \n"+strings.Join(checks.FindingDescriptions(unusedVars), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedVars).WithCoverage(coverage)
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -157,104 +183,59 @@ func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCh
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Organization), nil
+		checks.Organization).WithCoverage(coverage)
 }
 
-func (o *OctopusUnusedVariablesCheck) getDeploymentSteps(p *projects2.Project) ([]*deployments.DeploymentStep, error) {
-	deploymentProcesses := []*deployments.DeploymentStep{}
-	var deploymentProcess *deployments.DeploymentProcess
-	var err error
-
-	if p.IsVersionControlled {
-		gitPersistenceSettings, ok := p.PersistenceSettings.(projects2.GitPersistenceSettings)
-		if !ok {
-			zap.L().Error("Failed to cast PersistenceSettings to GitPersistenceSettings for project "+p.Name, zap.Any("PersistenceSettings", p.PersistenceSettings))
-			return nil, errors.New("failed to cast PersistenceSettings to GitPersistenceSettings for project " + p.Name)
+// stepReferences returns the lower case names of the variables referenced by the steps.
+func (o *OctopusUnusedVariablesCheck) stepReferences(deploymentSteps []*deployments.DeploymentStep) map[string]bool {
+	references := map[string]bool{}
+	addReferences := func(text string) {
+		for _, name := range octostache.Names(text) {
+			references[strings.ToLower(name)] = true
 		}
-		deploymentProcess, err = deployments.GetDeploymentProcessByGitRef(o.client, o.client.GetSpaceID(), p, gitPersistenceSettings.DefaultBranch())
-	} else {
-		deploymentProcess, err = deployments.GetDeploymentProcessByID(o.client, o.client.GetSpaceID(), p.DeploymentProcessID)
 	}
 
-	if err != nil {
-		zap.L().Error("Failed to get deployment process for project "+p.Name+" in check "+o.Id(), zap.Error(err))
-		if !o.errorHandler.ShouldContinue(err) {
-			return nil, err
-		}
-	} else {
-		if deploymentProcess != nil && deploymentProcess.Steps != nil {
-			deploymentProcesses = append(deploymentProcesses, deploymentProcess.Steps...)
+	for _, s := range deploymentSteps {
+		for _, p := range s.Properties {
+			addReferences(p.Value)
 		}
-	}
-
-	// Don't attempt to load CaC runbooks - this will need to be fixed later
-	if link, ok := p.Links["Runbooks"]; ok && !p.IsVersionControlled {
-		runbooks, err := newclient.Get[resources.Resources[runbooks.Runbook]](o.client.HttpSession(), linkOptions.ReplaceAllString(link, ""))
 
-		if err != nil {
-			zap.L().Error("Failed to get runbooks for project "+p.Name+" in check "+o.Id(), zap.Error(err))
-			if !o.errorHandler.ShouldContinue(err) {
-				return nil, err
+		for _, a := range s.Actions {
+			for _, p := range a.Properties {
+				addReferences(p.Value)
 			}
-		}
 
-		for _, runbook := range runbooks.Items {
-			runbookProcess, err := runbookprocess.GetByID(o.client, o.client.GetSpaceID(), runbook.RunbookProcessID)
-
-			if err != nil {
-				zap.L().Error("Failed to get runbook process for runbook "+runbook.Name+" in project "+p.Name+" in check "+o.Id(), zap.Error(err))
-				if !o.errorHandler.ShouldContinue(err) {
-					return nil, err
-				}
-				continue
-			} else {
-				if runbookProcess != nil && runbookProcess.Steps != nil {
-					deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
-				}
+			// Packages and feeds can use variables
+			for _, p := range a.Packages {
+				addReferences(p.FeedID)
+				addReferences(p.PackageID)
 			}
-		}
-	}
 
-	return deploymentProcesses, nil
-}
-
-// naiveStepVariableScan does a simple text search for the variable in a steps properties. This does lead to false positives as simple variables names, like "a",
-// will almost certainly appear in a step property text without necessarily being referenced as a variable.
-func (o *OctopusUnusedVariablesCheck) naiveStepVariableScan(deploymentSteps []*deployments.DeploymentStep, variable *variables.Variable) bool {
-	if deploymentSteps != nil {
-		for _, s := range deploymentSteps {
-			for _, a := range s.Actions {
-				for _, p := range a.Properties {
-					if strings.Index(p.Value, variable.Name) != -1 {
-						return true
-					}
-				}
-
-				// Packages and feeds can use variables
-				for _, p := range a.Packages {
-					if strings.Index(p.FeedID, variable.Name) != -1 || strings.Index(p.PackageID, variable.Name) != -1 {
-						return true
-					}
-				}
+			if a.Container != nil {
+				addReferences(a.Container.FeedID)
+				addReferences(a.Container.Image)
+			}
 
-				// Worker pools can use variables
-				if a.WorkerPoolVariable == variable.Name {
-					return true
-				}
+			// Worker pools variables are referenced by name
+			if a.WorkerPoolVariable != "" {
+				references[strings.ToLower(a.WorkerPoolVariable)] = true
 			}
 		}
 	}
 
-	return false
+	return references
 }
 
-// naiveVariableSetVariableScan does a simple text search for the variable in the value of other variables
-func (o *OctopusUnusedVariablesCheck) naiveVariableSetVariableScan(variables variables.VariableSet, variable *variables.Variable) bool {
-	for _, v := range variables.Variables {
-		if strings.Index(v.Value, variable.Name) != -1 {
-			return true
+// variableReferences returns the lower case names of the variables referenced by the values of other variables.
+// A variable that only references itself is not considered to be used.
+func (o *OctopusUnusedVariablesCheck) variableReferences(variableSet *variables.VariableSet) map[string]bool {
+	references := map[string]bool{}
+	for _, v := range variableSet.Variables {
+		for _, name := range octostache.Names(v.Value) {
+			if !strings.EqualFold(name, v.Name) {
+				references[strings.ToLower(name)] = true
+			}
 		}
 	}
-
-	return false
+	return references
 }
@@ -4,15 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/samber/lo"
-	"go.uber.org/zap"
 )
 
 const maxQueueTimeMinutes = 1
@@ -51,88 +53,101 @@ func (o OctopusDeploymentQueuedTimeCheck) Id() string {
 	return OctoLintDeploymentQueuedTime
 }
 
+func (o OctopusDeploymentQueuedTimeCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionEventView, checks.PermissionDeploymentView}
+}
+
 func (o OctopusDeploymentQueuedTimeCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	resource, err := o.client.Events.Get(events.EventsQuery{
-		EventCategories: []string{"DeploymentQueued", "DeploymentStarted"},
-		Skip:            0,
-		Take:            o.config.MaxDeploymentTasks,
-	})
-
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Performance, err)
-	}
+	pager := client_wrapper.NewEventPager(
+		o.client,
+		o.client.GetSpaceID(),
+		url.Values{"eventCategories": []string{"DeploymentQueued,DeploymentStarted"}},
+		o.config.PageSize,
+		o.config.MaxDeploymentTasks)
 
+	// Events are returned with the most recent event first, so a deployment's start event is read before the event
+	// that queued it. Only the start times of deployments whose queued event has not been read yet are kept.
+	startTimes := map[string]time.Time{}
 	deployments := []deploymentInfo{}
-	if resource != nil {
-		for i, r := range resource.Items {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(resource.Items))*100) + "% complete")
-
-			if r.Category == "DeploymentQueued" {
-				queuedDeploymentId := o.getDeploymentFromRelatedDocs(r)
-
-				foundStartTime := false
-
-				for _, r2 := range resource.Items {
-					if r2.Category == "DeploymentStarted" && queuedDeploymentId == o.getDeploymentFromRelatedDocs(r2) {
-						queueTime := r2.Occurred.Sub(r.Occurred)
-						if queueTime.Minutes() > maxQueueTimeMinutes {
-							deployments = append(deployments, deploymentInfo{
-								deploymentId: queuedDeploymentId,
-								duration:     queueTime.Minutes(),
-								queuedAt:     r.Occurred,
-							})
-						}
-
-						// No need to check other started events for this queued event
-						foundStartTime = true
-						break
-					}
-				}
-
-				if !foundStartTime {
-					// Deployment has not started yet, check against current time
-					queueTime := time.Since(r.Occurred)
-					if queueTime.Minutes() > maxQueueTimeMinutes {
-						deployments = append(deployments, deploymentInfo{
-							deploymentId: queuedDeploymentId,
-							duration:     queueTime.Minutes(),
-							queuedAt:     r.Occurred,
-						})
-					}
-				}
-			}
+	for r, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Performance, err)
+		}
+
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
+
+		deploymentId := o.getDeploymentFromRelatedDocs(r)
+
+		if r.Category == "DeploymentStarted" {
+			startTimes[deploymentId] = r.Occurred
+			continue
+		}
+
+		if r.Category != "DeploymentQueued" {
+			continue
+		}
+
+		// A deployment that has not started yet is checked against the current time
+		startTime, found := startTimes[deploymentId]
+		if !found {
+			startTime = time.Now()
+		}
+		delete(startTimes, deploymentId)
+
+		queueTime := startTime.Sub(r.Occurred)
+		if queueTime.Minutes() > maxQueueTimeMinutes {
+			deployments = append(deployments, deploymentInfo{
+				deploymentId: deploymentId,
+				duration:     queueTime.Minutes(),
+				queuedAt:     r.Occurred,
+			})
 		}
 	}
 
-	deploymentLinks := lo.Map(deployments, func(item deploymentInfo, index int) string {
+	coverage := []checks.ResourceCoverage{
+		checks.NewResourceCoverage(checks.ResourceTypeEvent, pager.Total(), pager.Read(), 0),
+	}
+
+	deploymentFindings := lo.Map(deployments, func(item deploymentInfo, index int) checks.OctopusCheckFinding {
+		finding := checks.OctopusCheckFinding{
+			ResourceType: checks.ResourceTypeDeployment,
+			ResourceId:   item.deploymentId,
+			ResourceName: item.deploymentId,
+			Description:  item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)",
+		}
+
 		deployment, err := o.client.Deployments.GetByID(item.deploymentId)
 
 		if err != nil {
-			return item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
+			return finding
 		}
 
-		return o.url + "/app#/" + o.space + "/projects/" + deployment.ProjectID + "/deployments/releases/" + deployment.ReleaseID +
+		finding.ParentId = deployment.ProjectID
+		finding.Description = o.url + "/app#/" + o.space + "/projects/" + deployment.ProjectID + "/deployments/releases/" + deployment.ReleaseID +
 			"/deployments/" + item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
+		return finding
 	})
+	deploymentLinks := checks.FindingDescriptions(deploymentFindings)
 
 	if len(deployments) >= maxQueuedTasks {
-		return checks.NewOctopusCheckResultImpl(
+		return checks.NewOctopusCheckResultImplWithFindings(
 			fmt.Sprint("Found "+fmt.Sprint(len(deployments)))+" deployments that were queued for longer than "+fmt.Sprint(maxQueueTimeMinutes)+" minutes. Consider increasing the task cap or adding a HA node to reduce task queue times:\n"+
 				strings.Join(deploymentLinks, "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Performance), nil
+			checks.Performance,
+			deploymentFindings).WithCoverage(coverage...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -141,7 +156,7 @@ func (o OctopusDeploymentQueuedTimeCheck) Execute(concurrency int) (checks.Octop
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Performance), nil
+		checks.Performance).WithCoverage(coverage...), nil
 }
 
 func (o OctopusDeploymentQueuedTimeCheck) getDeploymentFromRelatedDocs(event *events.Event) string {
@@ -0,0 +1,45 @@
+package checks
+
+// These are the space permissions that checks declare they need to run. They are all granted by the Octolint user
+// role defined in serviceaccount/terraform.tf.
+const (
+	PermissionAccountView            = "AccountView"
+	PermissionCertificateView        = "CertificateView"
+	PermissionDeploymentView         = "DeploymentView"
+	PermissionEnvironmentView        = "EnvironmentView"
+	PermissionEventView              = "EventView"
+	PermissionFeedView               = "FeedView"
+	PermissionGitCredentialView      = "GitCredentialView"
+	PermissionLibraryVariableSetView = "LibraryVariableSetView"
+	PermissionLifecycleView          = "LifecycleView"
+	PermissionMachinePolicyView      = "MachinePolicyView"
+	PermissionMachineView            = "MachineView"
+	PermissionProcessView            = "ProcessView"
+	PermissionProjectGroupView       = "ProjectGroupView"
+	PermissionProjectView            = "ProjectView"
+	PermissionRunbookView            = "RunbookView"
+	PermissionSubscriptionView       = "SubscriptionView"
+	PermissionTaskView               = "TaskView"
+	PermissionTeamView               = "TeamView"
+	PermissionTenantView             = "TenantView"
+	PermissionUserView               = "UserView"
+	PermissionVariableView           = "VariableView"
+	PermissionWorkerView             = "WorkerView"
+)
+
+// OctopusRequiredPermissionsCheck is implemented by checks that declare the space permissions they need, which
+// allows checks that can not run with the permissions granted to the API key to be skipped before they are executed.
+type OctopusRequiredPermissionsCheck interface {
+	OctopusCheck
+	// RequiredPermissions returns the space permissions the API key must have to run the check
+	RequiredPermissions() []string
+}
+
+// RequiredPermissions returns the space permissions declared by a check, or nil if the check does not declare them.
+func RequiredPermissions(check OctopusCheck) []string {
+	if permissionsCheck, ok := check.(OctopusRequiredPermissionsCheck); ok {
+		return permissionsCheck.RequiredPermissions()
+	}
+
+	return nil
+}
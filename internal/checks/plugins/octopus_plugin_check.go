@@ -0,0 +1,195 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// ProtocolVersion is incremented when a change to the PluginContext or the result written by a plugin is not
+// backwards compatible.
+const ProtocolVersion = 1
+
+// pluginOutputLimit is the number of characters of a failed plugin's stderr included in the result.
+const pluginOutputLimit = 1000
+
+// PluginContext is the JSON document written to the stdin of a plugin.
+type PluginContext struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	CheckId         string `json:"checkId"`
+	Url             string `json:"url"`
+	Space           string `json:"space"`
+	// Resources holds the resources selected by the pluginResources argument, keyed by the resource type
+	Resources map[string]any `json:"resources"`
+}
+
+// OctopusPluginCheck runs an external executable as a check. The executable reads a PluginContext from stdin and
+// writes a result, in the same shape as the results of the json report format, to stdout.
+type OctopusPluginCheck struct {
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	resources    *ResourceLoader
+	url          string
+	space        string
+	path         string
+}
+
+// NewOctopusPluginChecks creates a check for each plugin in the config. The plugins share the resources read from
+// the space.
+func NewOctopusPluginChecks(client *client.Client, config *config.OctolintConfig, url string, space string, errorHandler checks.OctopusClientErrorHandler) ([]checks.OctopusCheck, error) {
+	if err := ValidateResourceTypes(config.PluginResources); err != nil {
+		return nil, err
+	}
+
+	resources := NewResourceLoader(client, config)
+
+	return lo.Map(config.Plugins, func(path string, index int) checks.OctopusCheck {
+		return OctopusPluginCheck{
+			errorHandler: errorHandler,
+			config:       config,
+			resources:    resources,
+			url:          url,
+			space:        space,
+			path:         path,
+		}
+	}), nil
+}
+
+// Id is the file name of the plugin without the extension.
+func (o OctopusPluginCheck) Id() string {
+	name := filepath.Base(o.path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// Path is the path of the plugin executable.
+func (o OctopusPluginCheck) Path() string {
+	return o.path
+}
+
+func (o OctopusPluginCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
+
+	defer func() {
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
+	}()
+
+	resources, err := o.resources.Load(o.config.PluginResources)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.GeneralError, err)
+	}
+
+	input, err := json.Marshal(PluginContext{
+		ProtocolVersion: ProtocolVersion,
+		CheckId:         o.Id(),
+		Url:             o.url,
+		Space:           o.space,
+		Resources:       resources,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutSeconds := o.config.PluginTimeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaults.PluginTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, o.path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Processes started by the plugin may keep the output pipes open after the plugin is stopped
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return o.failure("did not return a result within " + fmt.Sprint(timeoutSeconds) + " seconds"), nil
+	}
+
+	if err != nil {
+		return o.failure("failed: " + err.Error() + withOutput(stderr.String())), nil
+	}
+
+	result := reporters.JsonCheckResult{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return o.failure("returned a result that is not valid JSON: " + err.Error()), nil
+	}
+
+	if err := validateResult(result); err != nil {
+		return o.failure(err.Error()), nil
+	}
+
+	// Results are matched to checks by their code, so plugins can not report under the code of another check
+	result.Code = o.Id()
+
+	return result.ToResult(), nil
+}
+
+// failure returns the result reported when the plugin fails to run.
+func (o OctopusPluginCheck) failure(reason string) checks.OctopusCheckResult {
+	zap.L().Error("The plugin "+o.path+" "+reason, logging.CheckId(o.Id()))
+
+	return checks.NewOctopusCheckResultImpl(
+		"The plugin "+o.path+" "+reason,
+		o.Id(),
+		"",
+		checks.Error,
+		checks.GeneralError)
+}
+
+// validateResult checks the fields of a result written by a plugin.
+func validateResult(result reporters.JsonCheckResult) error {
+	if strings.TrimSpace(result.Description) == "" {
+		return errors.New("returned a result without a description")
+	}
+
+	if !lo.Contains([]string{checks.Organization, checks.Naming, checks.Security, checks.Performance, checks.Optimization, checks.GeneralError}, result.Category) {
+		return errors.New("returned a result with the unsupported category \"" + result.Category + "\"")
+	}
+
+	if result.Severity != checks.Error && result.Severity != checks.Warning && result.Severity != checks.Info &&
+		result.Severity != checks.Permission && result.Severity != checks.Ok {
+		return errors.New("returned a result with the unsupported severity " + fmt.Sprint(result.Severity))
+	}
+
+	return nil
+}
+
+// withOutput appends the output of a failed plugin to the reason it failed.
+func withOutput(output string) string {
+	output = strings.TrimSpace(output)
+
+	if output == "" {
+		return ""
+	}
+
+	if len(output) > pluginOutputLimit {
+		output = output[:pluginOutputLimit] + "..."
+	}
+
+	return ": " + output
+}
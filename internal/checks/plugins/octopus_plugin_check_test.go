@@ -0,0 +1,166 @@
+package plugins
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// writePlugin saves a shell script as an executable plugin, and returns its path.
+func writePlugin(t *testing.T, name string, script string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test plugins are shell scripts")
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal("Should have written the plugin: " + err.Error())
+	}
+
+	return path
+}
+
+// buildPluginCheck returns the check that runs the plugin.
+func buildPluginCheck(t *testing.T, octopusClient *client.Client, octolintConfig *config.OctolintConfig) checks.OctopusCheck {
+	pluginChecks, err := NewOctopusPluginChecks(octopusClient, octolintConfig, "https://example.octopus.app", "Spaces-1", checks.OctopusClientPermissiveErrorHandler{})
+
+	if err != nil || len(pluginChecks) != 1 {
+		t.Fatal("Should have created the plugin check")
+	}
+
+	return pluginChecks[0]
+}
+
+func TestPluginResult(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.json")
+	path := writePlugin(t, "cmdb-owner.sh", "cat > "+input+"\n"+
+		`echo '{"code": "Other", "description": "Projects without an owner", "severity": 15, "category": "Organization", "findings": [{"resourceType": "Project", "resourceId": "Projects-1", "resourceName": "Web", "description": "No owner"}]}'`)
+
+	check := buildPluginCheck(t, nil, &config.OctolintConfig{Plugins: config.StringSliceArgs{path}, PluginTimeout: 10})
+
+	if check.Id() != "cmdb-owner" {
+		t.Fatal("Should have named the check after the plugin file")
+	}
+
+	result, err := check.Execute(1)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if result.Code() != "cmdb-owner" || result.Severity() != checks.Warning || len(result.Findings()) != 1 || result.Findings()[0].ResourceId != "Projects-1" {
+		t.Fatal("Should have returned the result written by the plugin")
+	}
+
+	pluginContext := PluginContext{}
+	contents, _ := os.ReadFile(input)
+
+	if err := json.Unmarshal(contents, &pluginContext); err != nil {
+		t.Fatal("Should have written the context to stdin: " + err.Error())
+	}
+
+	if pluginContext.ProtocolVersion != ProtocolVersion || pluginContext.CheckId != "cmdb-owner" || pluginContext.Space != "Spaces-1" || pluginContext.Url != "https://example.octopus.app" {
+		t.Fatal("Should have described the scan in the context")
+	}
+}
+
+func TestPluginFailures(t *testing.T) {
+	plugins := map[string]string{
+		"exit status 3":               "echo 'CMDB is down' >&2\nexit 3",
+		"not valid JSON":              "echo 'not json'",
+		"unsupported category":        `echo '{"description": "Found problems", "severity": 15, "category": "Other"}'`,
+		"did not return a result":     "sleep 5",
+		"returned a result without a": `echo '{"severity": 15, "category": "Security"}'`,
+	}
+
+	for reason, script := range plugins {
+		path := writePlugin(t, "plugin", script)
+		result, err := buildPluginCheck(t, nil, &config.OctolintConfig{Plugins: config.StringSliceArgs{path}, PluginTimeout: 1}).Execute(1)
+
+		if err != nil {
+			t.Fatal("Should have reported the failure as a result: " + err.Error())
+		}
+
+		if result.Category() != checks.GeneralError || result.Severity() != checks.Error || !strings.Contains(result.Description(), reason) {
+			t.Fatal("Should have reported a general error containing \"" + reason + "\", got: " + result.Description())
+		}
+	}
+
+	failed, _ := buildPluginCheck(t, nil, &config.OctolintConfig{Plugins: config.StringSliceArgs{writePlugin(t, "plugin", "echo 'CMDB is down' >&2\nexit 3")}}).Execute(1)
+
+	if !strings.Contains(failed.Description(), "CMDB is down") {
+		t.Fatal("Should have included the stderr of the plugin in the result")
+	}
+}
+
+func TestPluginResources(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/", "/api/Spaces-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Links": map[string]string{}})
+		case "/api/Spaces-1/environments":
+			requests++
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]any{{"Id": "Environments-1", "Name": "Production"}}, "TotalResults": 1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	octopusClient, err := client.NewClient(server.Client(), serverUrl, "API-TEST", "Spaces-1")
+
+	if err != nil {
+		t.Fatal("Should have created the client: " + err.Error())
+	}
+
+	dir := t.TempDir()
+	octolintConfig := &config.OctolintConfig{PluginResources: config.StringSliceArgs{ResourceEnvironments}, PageSize: 10}
+
+	for _, name := range []string{"first", "second"} {
+		octolintConfig.Plugins = append(octolintConfig.Plugins, writePlugin(t, name, "cat > "+filepath.Join(dir, name+".json")+"\n"+
+			`echo '{"description": "OK", "severity": 0, "category": "Organization"}'`))
+	}
+
+	pluginChecks, err := NewOctopusPluginChecks(octopusClient, octolintConfig, server.URL, "Spaces-1", checks.OctopusClientPermissiveErrorHandler{})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	for _, check := range pluginChecks {
+		if _, err := check.Execute(1); err != nil {
+			t.Fatal("Should not have returned an error: " + err.Error())
+		}
+
+		contents, _ := os.ReadFile(filepath.Join(dir, check.Id()+".json"))
+
+		if !strings.Contains(string(contents), `"environments":[{`) || !strings.Contains(string(contents), "Production") {
+			t.Fatal("Should have passed the environments to the plugin, got: " + string(contents))
+		}
+	}
+
+	if requests != 1 {
+		t.Fatal("Should have read the environments once for all the plugins")
+	}
+}
+
+func TestInvalidPluginResources(t *testing.T) {
+	if _, err := NewOctopusPluginChecks(nil, &config.OctolintConfig{PluginResources: config.StringSliceArgs{"feeds"}}, "", "", checks.OctopusClientPermissiveErrorHandler{}); err == nil {
+		t.Fatal("Should have returned an error for an unsupported resource type")
+	}
+}
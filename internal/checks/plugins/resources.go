@@ -0,0 +1,103 @@
+package plugins
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+)
+
+const (
+	ResourceProjects     = "projects"
+	ResourceEnvironments = "environments"
+	ResourceTenants      = "tenants"
+	ResourceTargets      = "targets"
+	ResourceWorkers      = "workers"
+)
+
+// ResourceTypes are the values supported by the pluginResources argument.
+var ResourceTypes = []string{ResourceProjects, ResourceEnvironments, ResourceTenants, ResourceTargets, ResourceWorkers}
+
+// ValidateResourceTypes returns an error if any of the resource types are not supported.
+func ValidateResourceTypes(resourceTypes []string) error {
+	for _, resourceType := range resourceTypes {
+		if !lo.Contains(ResourceTypes, resourceType) {
+			return errors.New("The pluginResources argument must be one of " + strings.Join(ResourceTypes, ", ") + ", but was \"" + resourceType + "\"")
+		}
+	}
+
+	return nil
+}
+
+// ResourceLoader reads the resources passed to the plugins. Each type of resource is read once and shared by all the
+// plugins in a scan.
+type ResourceLoader struct {
+	client    *client.Client
+	config    *config.OctolintConfig
+	mutex     sync.Mutex
+	resources map[string]any
+}
+
+func NewResourceLoader(client *client.Client, config *config.OctolintConfig) *ResourceLoader {
+	return &ResourceLoader{client: client, config: config, resources: map[string]any{}}
+}
+
+// Load returns the resources of each type, keyed by the resource type.
+func (o *ResourceLoader) Load(resourceTypes []string) (map[string]any, error) {
+	loaded := map[string]any{}
+
+	if len(resourceTypes) == 0 {
+		return loaded, nil
+	}
+
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, resourceType := range resourceTypes {
+		if _, ok := o.resources[resourceType]; !ok {
+			resources, err := o.read(resourceType)
+
+			if err != nil {
+				return nil, err
+			}
+
+			o.resources[resourceType] = resources
+		}
+
+		loaded[resourceType] = o.resources[resourceType]
+	}
+
+	return loaded, nil
+}
+
+func (o *ResourceLoader) read(resourceType string) (any, error) {
+	spaceID := o.client.GetSpaceID()
+
+	switch resourceType {
+	case ResourceProjects:
+		projects, _, err := client_wrapper.GetProjectsWithFilter(o.client, spaceID, o.config.ExcludeProjectsExcept, o.config.ExcludeProjects, 0, o.config.PageSize)
+		return projects, err
+	case ResourceEnvironments:
+		environments, _, err := client_wrapper.GetEnvironments(0, o.config.PageSize, o.client, spaceID)
+		return environments, err
+	case ResourceTenants:
+		tenants, _, err := client_wrapper.GetTenants(0, o.config.PageSize, o.client, spaceID)
+		return tenants, err
+	case ResourceTargets:
+		targets, _, err := client_wrapper.GetMachines(0, o.config.PageSize, o.client, spaceID)
+		return targets, err
+	case ResourceWorkers:
+		workers, _, err := client_wrapper.GetWorkers(0, o.config.PageSize, o.client, spaceID)
+		return workers, err
+	}
+
+	return nil, ValidateResourceTypes([]string{resourceType})
+}
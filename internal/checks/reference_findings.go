@@ -0,0 +1,81 @@
+package checks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+// ReferenceFunc returns the invalid variable references in a value, formatted for the finding description.
+type ReferenceFunc func(value string) []string
+
+// ActionReferenceFindings returns a finding for each action in the steps with a property or package that has an
+// invalid variable reference. The references function is called for each action, and returns the function that
+// finds the invalid references in the values of that action. The step properties, like the target roles or the run
+// condition, are reported against the first action of the step.
+func ActionReferenceFindings(project *projects.Project, steps []*deployments.DeploymentStep, references func(stepIndex int, actionIndex int, action *deployments.DeploymentAction) ReferenceFunc) []OctopusCheckFinding {
+	findings := []OctopusCheckFinding{}
+
+	for i, s := range steps {
+		for j, a := range s.Actions {
+			invalidReferences := references(i, j, a)
+			properties := map[string][]string{}
+
+			for name, value := range a.Properties {
+				properties[name] = invalidReferences(value.Value)
+			}
+
+			if j == 0 {
+				for name, value := range s.Properties {
+					properties[name] = append(properties[name], invalidReferences(value.Value)...)
+				}
+			}
+
+			for _, p := range a.Packages {
+				properties["Packages["+p.Name+"].PackageId"] = invalidReferences(p.PackageID)
+				properties["Packages["+p.Name+"].FeedId"] = invalidReferences(p.FeedID)
+			}
+
+			if details := referenceDetails(properties); details != "" {
+				findings = append(findings, NewProjectActionFinding(project, a, details))
+			}
+		}
+	}
+
+	return findings
+}
+
+// VariableReferenceFindings returns a finding for each project variable whose value has an invalid variable reference.
+func VariableReferenceFindings(project *projects.Project, variableSet *variables.VariableSet, invalidReferences ReferenceFunc) []OctopusCheckFinding {
+	findings := []OctopusCheckFinding{}
+
+	if variableSet == nil {
+		return findings
+	}
+
+	for _, v := range variableSet.Variables {
+		if invalid := invalidReferences(v.Value); len(invalid) != 0 {
+			finding := NewProjectVariableFinding(project, v)
+			finding.Description += ": value references " + strings.Join(invalid, ", ")
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// referenceDetails describes the invalid references of each property, sorted by property name.
+func referenceDetails(properties map[string][]string) string {
+	details := []string{}
+	for property, invalid := range properties {
+		if len(invalid) != 0 {
+			details = append(details, property+" references "+strings.Join(invalid, ", "))
+		}
+	}
+
+	sort.Strings(details)
+	return strings.Join(details, "; ")
+}
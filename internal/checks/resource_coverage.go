@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceCoverage describes how many resources of a single type a check inspected. Checks only scan a limited
+// number of resources by default, so a passing check does not mean the whole space is free of issues.
+type ResourceCoverage struct {
+	// ResourceType is the type of the resource, for example "Project" or "Target"
+	ResourceType string
+	// Available is the number of resources in the space that could have been scanned
+	Available int
+	// Scanned is the number of resources that were inspected by the check
+	Scanned int
+	// Skipped is the number of resources that could not be inspected because of permission errors
+	Skipped int
+}
+
+// NewResourceCoverage creates the coverage of a check that loaded some of the available resources, and could not
+// inspect the skipped resources because of permission errors.
+func NewResourceCoverage(resourceType string, available int, loaded int, skipped int) ResourceCoverage {
+	// The number of resources can change while a check is running, so the available count is at least the number
+	// of resources that were loaded
+	if available < loaded {
+		available = loaded
+	}
+
+	return ResourceCoverage{
+		ResourceType: resourceType,
+		Available:    available,
+		Scanned:      loaded - skipped,
+		Skipped:      skipped,
+	}
+}
+
+// NewFullResourceCoverage creates the coverage of a check that inspected every resource that was loaded.
+func NewFullResourceCoverage(resourceType string, loaded int) ResourceCoverage {
+	return NewResourceCoverage(resourceType, loaded, loaded, 0)
+}
+
+// IsPartial returns true if some of the available resources were not inspected.
+func (o ResourceCoverage) IsPartial() bool {
+	return o.Scanned < o.Available
+}
+
+// String describes the coverage, like "Project 100 of 250 scanned, 2 skipped due to permission errors".
+func (o ResourceCoverage) String() string {
+	description := fmt.Sprintf("%s %d of %d scanned", o.ResourceType, o.Scanned, o.Available)
+
+	if o.Skipped != 0 {
+		description += fmt.Sprintf(", %d skipped due to permission errors", o.Skipped)
+	}
+
+	return description
+}
+
+// OctopusCoverageCheckResult is implemented by results that record how many resources the check inspected.
+type OctopusCoverageCheckResult interface {
+	OctopusCheckResult
+	// Coverage returns the number of resources of each type that were available and inspected
+	Coverage() []ResourceCoverage
+}
+
+// Coverage returns the coverage of a result, or nil if the result does not record its coverage.
+func Coverage(result OctopusCheckResult) []ResourceCoverage {
+	if coverageResult, ok := result.(OctopusCoverageCheckResult); ok {
+		return coverageResult.Coverage()
+	}
+
+	return nil
+}
+
+// IsPartialCoverage returns true if the check that produced the result did not inspect every available resource.
+func IsPartialCoverage(result OctopusCheckResult) bool {
+	for _, coverage := range Coverage(result) {
+		if coverage.IsPartial() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CoverageDescription describes the coverage of a result on a single line, like
+// "Coverage: Project 100 of 250 scanned; Target 10 of 10 scanned", or returns an empty string if the result does not
+// record its coverage.
+func CoverageDescription(result OctopusCheckResult) string {
+	descriptions := []string{}
+	for _, coverage := range Coverage(result) {
+		descriptions = append(descriptions, coverage.String())
+	}
+
+	if len(descriptions) == 0 {
+		return ""
+	}
+
+	description := "Coverage: " + strings.Join(descriptions, "; ")
+
+	if IsPartialCoverage(result) {
+		description += " (partial scan, increase the max... arguments or check the API key permissions to scan every resource)"
+	}
+
+	return description
+}
@@ -0,0 +1,33 @@
+package checks
+
+// These are the resource types that are assigned to an OctopusCheckFinding or a ResourceCoverage
+const (
+	ResourceTypeAccount            = "Account"
+	ResourceTypeAction             = "Action"
+	ResourceTypeApiKey             = "ApiKey"
+	ResourceTypeCertificate        = "Certificate"
+	ResourceTypeDeployment         = "Deployment"
+	ResourceTypeEnvironment        = "Environment"
+	ResourceTypeEvent              = "Event"
+	ResourceTypeFeed               = "Feed"
+	ResourceTypeGitCredential      = "GitCredential"
+	ResourceTypeGitUsername        = "GitUsername"
+	ResourceTypeLibraryVariableSet = "LibraryVariableSet"
+	ResourceTypeLifecycle          = "Lifecycle"
+	ResourceTypeMachinePolicy      = "MachinePolicy"
+	ResourceTypeProject            = "Project"
+	ResourceTypeProjectGroup       = "ProjectGroup"
+	ResourceTypeScriptModule       = "ScriptModule"
+	ResourceTypeServerCertificate  = "ServerCertificate"
+	ResourceTypeSpace              = "Space"
+	ResourceTypeSubscription       = "Subscription"
+	ResourceTypeTag                = "Tag"
+	ResourceTypeTagSet             = "TagSet"
+	ResourceTypeTarget             = "Target"
+	ResourceTypeTenant             = "Tenant"
+	ResourceTypeTenantGroup        = "TenantGroup"
+	ResourceTypeUser               = "User"
+	ResourceTypeVariable           = "Variable"
+	ResourceTypeWorker             = "Worker"
+	ResourceTypeWorkerPool         = "WorkerPool"
+)
@@ -5,17 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/hayageek/threadsafe"
-	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -37,23 +38,28 @@ func (o OctopusDeploymentQueuedByAdminCheck) Id() string {
 	return OctoLintDeploymentQueuedByAdmin
 }
 
+func (o OctopusDeploymentQueuedByAdminCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView, checks.PermissionEventView, checks.PermissionUserView, checks.PermissionTeamView}
+}
+
 func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
+	projects, availableProjects, err := client_wrapper.GetProjectsWithFilter(
 		o.client,
 		o.client.GetSpaceID(),
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxDeploymentsByAdminProjects)
+		o.config.MaxDeploymentsByAdminProjects,
+		o.config.PageSize)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
@@ -73,7 +79,8 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 	g.SetLimit(concurrency)
 
 	goroutineErrors := threadsafe.NewSlice[error]()
-	projectsDeployedByAdmins := threadsafe.NewSlice[string]()
+	var skipped atomic.Int32
+	projectsDeployedByAdmins := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 
 	for i, p := range projects {
 
@@ -82,57 +89,68 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 
 		g.Go(func() error {
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			logging.Check(o.Id()).Debug(o.Id()+" "+fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100)+"% complete", logging.Project(p.Name))
 
 			projectId := p.ID
 			usersWhoDeployedProject := []string{}
 
-			resource, err := o.client.Events.Get(events.EventsQuery{
-				EventCategories: []string{"DeploymentQueued"},
-				Projects:        []string{projectId},
-				Skip:            0,
-				Take:            100,
-				From:            from,
-			})
+			pager := client_wrapper.NewEventPager(
+				o.client,
+				o.client.GetSpaceID(),
+				url.Values{"eventCategories": []string{"DeploymentQueued"}, "projects": []string{projectId}, "from": []string{from}},
+				o.config.PageSize,
+				0)
+
+			// Each user only needs to be looked up once, regardless of how many deployments they queued
+			checkedUsers := map[string]bool{}
+
+			for r, err := range pager.Items() {
+				if err != nil {
+					if !o.errorHandler.ShouldContinue(err) {
+						goroutineErrors.Append(err)
+					} else {
+						skipped.Add(1)
+					}
+					return nil
+				}
 
-			if err != nil {
-				if !o.errorHandler.ShouldContinue(err) {
-					goroutineErrors.Append(err)
+				if r.Username == "system" || checkedUsers[r.Username] {
+					continue
 				}
-				return nil
-			}
 
-			if resource != nil {
-				for _, r := range resource.Items {
-					if r.Username == "system" {
-						continue
-					}
+				checkedUsers[r.Username] = true
 
-					user, err := o.client.Users.Get(users.UsersQuery{
-						Filter: r.Username,
-						Skip:   0,
-						Take:   1,
-					})
+				user, err := o.client.Users.Get(users.UsersQuery{
+					Filter: r.Username,
+					Skip:   0,
+					Take:   1,
+				})
 
-					if err != nil {
-						if !o.errorHandler.ShouldContinue(err) {
-							goroutineErrors.Append(err)
-						}
-						return nil
+				if err != nil {
+					if !o.errorHandler.ShouldContinue(err) {
+						goroutineErrors.Append(err)
+					} else {
+						skipped.Add(1)
 					}
+					return nil
+				}
 
-					for _, u := range user.Items {
-						for _, t := range teams {
-							if slices.Index(t.MemberUserIDs, u.ID) != -1 && slices.Index(usersWhoDeployedProject, u.Username) == -1 {
-								usersWhoDeployedProject = append(usersWhoDeployedProject, u.Username)
-							}
+				for _, u := range user.Items {
+					for _, t := range teams {
+						if slices.Index(t.MemberUserIDs, u.ID) != -1 && slices.Index(usersWhoDeployedProject, u.Username) == -1 {
+							usersWhoDeployedProject = append(usersWhoDeployedProject, u.Username)
 						}
 					}
 				}
 			}
 
 			if len(usersWhoDeployedProject) != 0 {
-				projectsDeployedByAdmins.Append(p.Name + " (" + strings.Join(usersWhoDeployedProject, ",") + ")")
+				projectsDeployedByAdmins.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.ResourceTypeProject,
+					ResourceId:   p.ID,
+					ResourceName: p.Name,
+					Description:  p.Name + " (" + strings.Join(usersWhoDeployedProject, ",") + ")",
+				})
 			}
 
 			return nil
@@ -148,13 +166,16 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeProject, availableProjects, len(projects), int(skipped.Load()))
+
 	if projectsDeployedByAdmins.Length() != 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects were deployed by admins. Consider creating a limited user account to perform deployments:\n"+strings.Join(projectsDeployedByAdmins.Values(), "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following projects were deployed by admins. Consider creating a limited user account to perform deployments:\n"+strings.Join(checks.FindingDescriptions(projectsDeployedByAdmins.Values()), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			projectsDeployedByAdmins.Values()).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -162,7 +183,7 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
 }
 
 func (o OctopusDeploymentQueuedByAdminCheck) getAdminTeams() ([]*teams.Team, error) {
@@ -170,19 +191,23 @@ func (o OctopusDeploymentQueuedByAdminCheck) getAdminTeams() ([]*teams.Team, err
 
 	teamResources := []*teams.Team{}
 	for _, adminTeam := range adminTeams {
-		team, err := o.client.Teams.Get(teams.TeamsQuery{
-			IDs:           nil,
-			IncludeSystem: true,
-			PartialName:   adminTeam,
-			Skip:          0,
-			Take:          1,
-		})
+		// The partial name can match other teams, so only teams with the exact name are kept
+		pager := client_wrapper.NewSpacePager[teams.Team](
+			o.client,
+			o.client.GetSpaceID(),
+			"teams?includeSystem=true&partialName="+url.QueryEscape(adminTeam),
+			o.config.PageSize,
+			0)
+
+		for team, err := range pager.Items() {
+			if err != nil {
+				return nil, err
+			}
 
-		if err != nil {
-			return nil, err
+			if team.Name == adminTeam {
+				teamResources = append(teamResources, team)
+			}
 		}
-
-		teamResources = append(teamResources, team.Items...)
 	}
 
 	return teamResources, nil
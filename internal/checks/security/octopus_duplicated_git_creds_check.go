@@ -6,11 +6,10 @@ import (
 	"strings"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 // CustomProject is the simplest representation of a project and its version controlled settings
@@ -44,30 +43,35 @@ func (o OctopusDuplicatedGitCredentialsCheck) Id() string {
 	return "OctoLintSharedGitUsername"
 }
 
+func (o OctopusDuplicatedGitCredentialsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionProjectView}
+}
+
 func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	url := o.client.HttpSession().BaseURL.String() + "/api/" + o.client.GetSpaceID() + "/Projects?take=1000"
-	allProjects, err := newclient.Get[resources.Resources[CustomProject]](o.client.HttpSession(), url)
-
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
-	}
+	// Only the persistence settings of each project are kept, so every project in the space is streamed a page at a time
+	pager := client_wrapper.NewSpacePager[CustomProject](o.client, o.client.GetSpaceID(), "projects", o.config.PageSize, 0)
 
 	gitUsernameCounts := map[string]int{}
 	gitUsernameProjects := map[string][]string{}
-	for i, p := range allProjects.Items {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjects.Items))*100) + "% complete")
+	for p, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Security, err)
+		}
+
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
 		if p.PersistenceSettings.Type == "VersionControlled" &&
+			p.PersistenceSettings.Credentials != nil &&
 			p.PersistenceSettings.Credentials.Type == "UsernamePassword" &&
 			p.PersistenceSettings.Credentials.Username != nil {
 			if _, ok := gitUsernameCounts[*p.PersistenceSettings.Credentials.Username]; !ok {
@@ -82,6 +86,8 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.O
 		}
 	}
 
+	coverage := checks.NewFullResourceCoverage(checks.ResourceTypeProject, pager.Read())
+
 	duplicatedGitCredentials := map[string][]string{}
 	for u, c := range gitUsernameCounts {
 		if c > 1 {
@@ -90,17 +96,22 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.O
 	}
 
 	if len(duplicatedGitCredentials) != 0 {
-		message := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for u, p := range duplicatedGitCredentials {
-			message = append(message, u+" ("+strings.Join(p, ", ")+")")
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeGitUsername,
+				ResourceName: u,
+				Description:  u + " (" + strings.Join(p, ", ") + ")",
+			})
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following Git usernames have been reused across the following projects:\n"+strings.Join(message, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following Git usernames have been reused across the following projects:\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -108,5 +119,5 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.O
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
 }
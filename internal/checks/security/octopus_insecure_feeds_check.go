@@ -6,11 +6,15 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"slices"
 	"strings"
 )
 
+const OctoLintInsecureFeeds = "OctoLintInsecureFeedsTargets"
+
 // OctopusInsecureFeedsCheck checks to see if any targets have not been used in a month
 type OctopusInsecureFeedsCheck struct {
 	client       *client.Client
@@ -23,7 +27,11 @@ func NewOctopusInsecureFeedsCheck(client *client.Client, config *config.Octolint
 }
 
 func (o OctopusInsecureFeedsCheck) Id() string {
-	return "OctoLintInsecureFeedsTargets"
+	return OctoLintInsecureFeeds
+}
+
+func (o OctopusInsecureFeedsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionFeedView}
 }
 
 func (o OctopusInsecureFeedsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
@@ -31,73 +39,40 @@ func (o OctopusInsecureFeedsCheck) Execute(concurrency int) (checks.OctopusCheck
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := o.client.Feeds.GetAll()
-
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
-	}
+	pager := client_wrapper.NewSpacePager[feeds.FeedResource](o.client, o.client.GetSpaceID(), "feeds", o.config.PageSize, 0)
 
-	insecureFeeds := []string{}
-	for i, m := range targets {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
+	// The feed types that are configured with a URL
+	urlFeedTypes := []feeds.FeedType{"ArtifactoryGeneric", "NuGet", "Maven", "Helm", "GitHub", "Docker"}
 
-		if m.GetFeedType() == "ArtifactoryGeneric" {
-			typedFeed := m.(*feeds.ArtifactoryGenericFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
+	insecureFeeds := []checks.OctopusCheckFinding{}
+	for m, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 		}
 
-		if m.GetFeedType() == "NuGet" {
-			typedFeed := m.(*feeds.NuGetFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Maven" {
-			typedFeed := m.(*feeds.MavenFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
-		if m.GetFeedType() == "Helm" {
-			typedFeed := m.(*feeds.HelmFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
+		if slices.Contains(urlFeedTypes, m.GetFeedType()) && strings.HasPrefix(m.FeedURI, "http://") {
+			insecureFeeds = append(insecureFeeds, o.newFinding(m))
 		}
-
-		if m.GetFeedType() == "GitHub" {
-			typedFeed := m.(*feeds.GitHubRepositoryFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Docker" {
-			typedFeed := m.(*feeds.DockerContainerRegistry)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
 	}
 
+	coverage := checks.NewFullResourceCoverage(checks.ResourceTypeFeed, pager.Read())
+
 	if len(insecureFeeds) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following feeds use an insecure HTTP endpoint:\n"+strings.Join(insecureFeeds, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following feeds use an insecure HTTP endpoint:\n"+strings.Join(checks.FindingDescriptions(insecureFeeds), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			insecureFeeds).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -105,5 +80,14 @@ func (o OctopusInsecureFeedsCheck) Execute(concurrency int) (checks.OctopusCheck
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
+}
+
+func (o OctopusInsecureFeedsCheck) newFinding(feed *feeds.FeedResource) checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{
+		ResourceType: checks.ResourceTypeFeed,
+		ResourceId:   feed.GetID(),
+		ResourceName: feed.GetName(),
+		Description:  feed.GetName(),
+	}
 }
@@ -8,8 +8,8 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/samber/lo"
-	"go.uber.org/zap"
 	"strings"
 )
 
@@ -30,45 +30,63 @@ func (o OctopusInsecureK8sCheck) Id() string {
 	return OctoLintInsecureK8sTargets
 }
 
+func (o OctopusInsecureK8sCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionMachineView}
+}
+
+func (o OctopusInsecureK8sCheck) ScopedResourceType() string {
+	return checks.ResourceTypeTarget
+}
+
 func (o OctopusInsecureK8sCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := client_wrapper.GetMachines(o.config.MaxInsecureK8sTargets, o.client, o.client.GetSpaceID())
+	targets, availableTargets, err := client_wrapper.GetMachines(o.config.MaxInsecureK8sTargets, o.config.PageSize, o.client, o.client.GetSpaceID())
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
+	targets = client_wrapper.ScopedMachines(targets, o.config.Scope)
+
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeTarget, availableTargets, len(targets), 0)
+
 	k8sTargets := lo.Filter(targets, func(item *machines.DeploymentTarget, index int) bool {
 		return item.Endpoint != nil && item.Endpoint.GetCommunicationStyle() == "Kubernetes"
 	})
 
-	insecureMachines := []string{}
+	insecureMachines := []checks.OctopusCheckFinding{}
 	for i, m := range k8sTargets {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(k8sTargets))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(k8sTargets))*100) + "% complete")
 
 		k8sEndpoint := m.Endpoint.(*machines.KubernetesEndpoint)
 		if k8sEndpoint.SkipTLSVerification || (k8sEndpoint.ClusterURL != nil && strings.HasPrefix(k8sEndpoint.ClusterURL.String(), "http://")) {
-			insecureMachines = append(insecureMachines, m.Name)
+			insecureMachines = append(insecureMachines, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeTarget,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				Description:  m.Name,
+			})
 		}
 
 	}
 
 	if len(insecureMachines) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following Kubernetes targets skip TLS validation or use an insecure HTTP endpoint:\n"+strings.Join(insecureMachines, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following Kubernetes targets skip TLS validation or use an insecure HTTP endpoint:\n"+strings.Join(checks.FindingDescriptions(insecureMachines), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			insecureMachines).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -76,5 +94,5 @@ func (o OctopusInsecureK8sCheck) Execute(concurrency int) (checks.OctopusCheckRe
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
 }
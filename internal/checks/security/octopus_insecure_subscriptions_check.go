@@ -4,11 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services/api"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"strings"
 )
 
@@ -27,41 +26,52 @@ func (o OctopusInsecureSubscriptionsCheck) Id() string {
 	return "OctoLintInsecureWebhookUrls"
 }
 
+func (o OctopusInsecureSubscriptionsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionSubscriptionView}
+}
+
 func (o OctopusInsecureSubscriptionsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	collection := resources.Resources[*OctopusSubscription]{}
-	_, err := api.ApiGet(o.client.Subscriptions.GetClient(), &collection, o.client.Subscriptions.BasePath+"?skip=0&take=2147483647")
+	pager := client_wrapper.NewSpacePager[OctopusSubscription](o.client, o.client.GetSpaceID(), "subscriptions", o.config.PageSize, 0)
 
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
-	}
+	insecureItems := []checks.OctopusCheckFinding{}
+	for m, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Security, err)
+		}
 
-	insecureItems := []string{}
-	for i, m := range collection.Items {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(collection.Items))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
 		if m.EventNotificationSubscription != nil && strings.HasPrefix(m.EventNotificationSubscription.WebhookURI, "http://") {
-			insecureItems = append(insecureItems, m.Name)
+			insecureItems = append(insecureItems, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeSubscription,
+				ResourceId:   m.Id,
+				ResourceName: m.Name,
+				Description:  m.Name,
+			})
 		}
 
 	}
 
+	coverage := checks.NewFullResourceCoverage(checks.ResourceTypeSubscription, pager.Read())
+
 	if len(insecureItems) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following subscriptions use an insecure HTTP webhook URL:\n"+strings.Join(insecureItems, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following subscriptions use an insecure HTTP webhook URL:\n"+strings.Join(checks.FindingDescriptions(insecureItems), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			insecureItems).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -69,10 +79,11 @@ func (o OctopusInsecureSubscriptionsCheck) Execute(concurrency int) (checks.Octo
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
 }
 
 type OctopusSubscription struct {
+	Id                            string
 	Name                          string
 	EventNotificationSubscription *OctopusEventNotificationSubscription
 }
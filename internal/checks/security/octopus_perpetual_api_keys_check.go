@@ -4,11 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"regexp"
 	"strings"
 	"time"
@@ -39,52 +39,67 @@ func (o OctopusPerpetualApiKeysCheck) Id() string {
 	return "OctoLintPerpetualApiKeys"
 }
 
+func (o OctopusPerpetualApiKeysCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionUserView}
+}
+
 func (o OctopusPerpetualApiKeysCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
-	users, err := o.client.Users.GetAll()
-
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
-	}
+	pager := client_wrapper.NewPager[users.User](o.client.HttpSession(), "/api/users", o.config.PageSize, 0)
 
 	linksTemplate := regexp.MustCompile(`\{.+\}`)
-	perpetualApiKeys := []string{}
-	for i, u := range users {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(users))*100) + "% complete")
+	perpetualApiKeys := []checks.OctopusCheckFinding{}
+	skipped := 0
+	for u, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Security, err)
+		}
+
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
 		apiKeysLink := linksTemplate.ReplaceAllString(u.Links["ApiKeys"], "")
-		keys, err := newclient.Get[resources.Resources[APIKey]](o.client.HttpSession(), apiKeysLink)
+		keys, err := client_wrapper.NewPager[APIKey](o.client.HttpSession(), apiKeysLink, o.config.PageSize, 0).Collect()
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
+			skipped++
 			continue
 		}
 
-		for _, k := range keys.Items {
+		for _, k := range keys {
 			if k.Expires == nil && k.APIKey.Hint != nil && u.Username != "guest" {
-				perpetualApiKeys = append(perpetualApiKeys, *k.APIKey.Hint+"... ("+u.Username+")")
+				perpetualApiKeys = append(perpetualApiKeys, checks.OctopusCheckFinding{
+					ResourceType: checks.ResourceTypeApiKey,
+					ResourceName: *k.APIKey.Hint,
+					ParentId:     u.ID,
+					ParentName:   u.Username,
+					Description:  *k.APIKey.Hint + "... (" + u.Username + ")",
+				})
 			}
 		}
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeUser, pager.Read(), pager.Read(), skipped)
+
 	if len(perpetualApiKeys) != 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following API keys do not expire:\n"+strings.Join(perpetualApiKeys, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following API keys do not expire:\n"+strings.Join(checks.FindingDescriptions(perpetualApiKeys), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			perpetualApiKeys).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -92,5 +107,5 @@ func (o OctopusPerpetualApiKeysCheck) Execute(concurrency int) (checks.OctopusCh
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
 }
@@ -13,7 +13,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 )
 
 const (
@@ -29,6 +29,7 @@ type OctopusSha1CertificatesCheck struct {
 }
 
 type Sha1CertificateResult struct {
+	ID   string
 	Name string
 	Type string // "Target", "Worker", or "Global"
 }
@@ -49,6 +50,10 @@ func (o OctopusSha1CertificatesCheck) Id() string {
 	return OctoLintSha1Certificates
 }
 
+func (o OctopusSha1CertificatesCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionMachineView, checks.PermissionWorkerView}
+}
+
 // fetchServerCertificate gets the server certificate object and returns it.
 func fetchServerCertificate(url, apiKey, accessToken string) (*ServerCertificate, error) {
 	requestURL := fmt.Sprintf("%s/api/configuration/certificates/certificate-global", url)
@@ -102,13 +107,14 @@ func hasSha1Certificate(ep machines.IEndpoint) bool {
 func addSha1FromMachines[T any](
 	results *[]Sha1CertificateResult,
 	items []T,
+	getId func(T) string,
 	getName func(T) string,
 	getEndpoint func(T) machines.IEndpoint,
 	typ string,
 ) {
 	for _, item := range items {
 		if hasSha1Certificate(getEndpoint(item)) {
-			*results = append(*results, Sha1CertificateResult{Name: getName(item), Type: typ})
+			*results = append(*results, Sha1CertificateResult{ID: getId(item), Name: getName(item), Type: typ})
 		}
 	}
 }
@@ -118,9 +124,9 @@ func (o OctopusSha1CertificatesCheck) Execute(concurrency int) (checks.OctopusCh
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	var results []Sha1CertificateResult
@@ -131,31 +137,38 @@ func (o OctopusSha1CertificatesCheck) Execute(concurrency int) (checks.OctopusCh
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 	if cert != nil && cert.SignatureAlgorithm == sha1Alg {
-		results = append(results, Sha1CertificateResult{Name: cert.Name, Type: "Global"})
+		results = append(results, Sha1CertificateResult{ID: cert.ID, Name: cert.Name, Type: "Global"})
 	}
 
 	// Check deployment targets
-	targets, err := client_wrapper.GetMachines(o.config.MaxSha1CertificatesMachines, o.client, o.client.GetSpaceID())
+	targets, availableTargets, err := client_wrapper.GetMachines(o.config.MaxSha1CertificatesMachines, o.config.PageSize, o.client, o.client.GetSpaceID())
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 	addSha1FromMachines(&results, targets,
+		func(m *machines.DeploymentTarget) string { return m.ID },
 		func(m *machines.DeploymentTarget) string { return m.Name },
 		func(m *machines.DeploymentTarget) machines.IEndpoint { return m.Endpoint },
 		"Target",
 	)
 
 	// Check workers
-	workers, err := client_wrapper.GetWorkers(o.config.MaxSha1CertificatesMachines, o.client, o.client.GetSpaceID())
+	workers, availableWorkers, err := client_wrapper.GetWorkers(o.config.MaxSha1CertificatesMachines, o.config.PageSize, o.client, o.client.GetSpaceID())
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 	addSha1FromMachines(&results, workers,
+		func(w *machines.Worker) string { return w.ID },
 		func(w *machines.Worker) string { return w.Name },
 		func(w *machines.Worker) machines.IEndpoint { return w.Endpoint },
 		"Worker",
 	)
 
+	coverage := []checks.ResourceCoverage{
+		checks.NewResourceCoverage(checks.ResourceTypeTarget, availableTargets, len(targets), 0),
+		checks.NewResourceCoverage(checks.ResourceTypeWorker, availableWorkers, len(workers), 0),
+	}
+
 	// Provide results
 	if len(results) > 0 {
 		// Sort by Type then Name for stable output
@@ -166,17 +179,23 @@ func (o OctopusSha1CertificatesCheck) Execute(concurrency int) (checks.OctopusCh
 			return results[i].Type < results[j].Type
 		})
 
-		lines := make([]string, len(results))
+		findings := make([]checks.OctopusCheckFinding, len(results))
 		for i, m := range results {
-			lines[i] = fmt.Sprintf("%s: %s", m.Type, m.Name)
+			findings[i] = checks.OctopusCheckFinding{
+				ResourceType: sha1ResourceType(m.Type),
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				Description:  fmt.Sprintf("%s: %s", m.Type, m.Name),
+			}
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following resources use a SHA1 certificate:\n"+strings.Join(lines, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following resources use a SHA1 certificate:\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings).WithCoverage(coverage...), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -184,5 +203,17 @@ func (o OctopusSha1CertificatesCheck) Execute(concurrency int) (checks.OctopusCh
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage...), nil
+}
+
+// sha1ResourceType maps the type of a Sha1CertificateResult to the resource type of a finding
+func sha1ResourceType(typ string) string {
+	switch typ {
+	case "Target":
+		return checks.ResourceTypeTarget
+	case "Worker":
+		return checks.ResourceTypeWorker
+	default:
+		return checks.ResourceTypeServerCertificate
+	}
 }
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"go.uber.org/zap"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"golang.org/x/exp/slices"
 	"net/url"
 	"strings"
@@ -33,31 +32,35 @@ func (o OctopusUnrotatedAccountsCheck) Id() string {
 	return "OctoLintUnrotatedAccounts"
 }
 
+func (o OctopusUnrotatedAccountsCheck) RequiredPermissions() []string {
+	return []string{checks.PermissionAccountView, checks.PermissionEventView}
+}
+
 func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
 
-	zap.L().Debug("Starting check " + o.Id())
+	logging.Check(o.Id()).Debug("Starting check " + o.Id())
 
 	defer func() {
-		zap.L().Debug("Ended check " + o.Id())
+		logging.Check(o.Id()).Debug("Ended check " + o.Id())
 	}()
 
 	now := time.Now()
 	start := now.Add(maxTimeSinceAccountEdit * -1)
 	end := now
 
-	allAccounts, err := o.GetAll(o.client, o.client.GetSpaceID())
+	pager := client_wrapper.NewSpacePager[accounts.AccountResource](o.client, o.client.GetSpaceID(), "accounts", o.config.PageSize, 0)
 
-	if err != nil {
-		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
-	}
-
-	uneditedAccounts := []string{}
-	for i, m := range allAccounts {
+	uneditedAccounts := []checks.OctopusCheckFinding{}
+	skipped := 0
+	for m, err := range pager.Items() {
+		if err != nil {
+			return o.errorHandler.HandleError(o.Id(), checks.Security, err)
+		}
 
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allAccounts))*100) + "% complete")
+		logging.Check(o.Id()).Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(pager.Read())/float32(pager.Total())*100) + "% complete")
 
 		// Skip OIDC accounts
 		if m.GetAccountType() == "AmazonWebServicesOidcAccount" {
@@ -68,35 +71,37 @@ func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusC
 			continue
 		}
 
-		audits, err := newclient.Get[resources.Resources[OctopusAudit]](o.client.HttpSession(), "/api/events?regardingAny="+m.GetID()+"&from="+url.QueryEscape(start.Format("2006-01-02T15:04:05-0700"))+"&to="+url.QueryEscape(end.Format("2006-01-02T15:04:05-0700")))
+		recentEdit, err := o.recentlyModified(m.GetID(), start, end)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
+			skipped++
 			continue
 		}
 
-		recentEdit := false
-		for _, t := range audits.Items {
-			if t.Category == "Modified" && slices.Index(t.RelatedDocumentIds, m.GetID()) != -1 {
-				recentEdit = true
-			}
-		}
-
 		if !recentEdit {
-			uneditedAccounts = append(uneditedAccounts, m.GetName())
+			uneditedAccounts = append(uneditedAccounts, checks.OctopusCheckFinding{
+				ResourceType: checks.ResourceTypeAccount,
+				ResourceId:   m.GetID(),
+				ResourceName: m.GetName(),
+				Description:  m.GetName(),
+			})
 		}
 
 	}
 
+	coverage := checks.NewResourceCoverage(checks.ResourceTypeAccount, pager.Read(), pager.Read(), skipped)
+
 	if len(uneditedAccounts) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following accounts have not been updated in 90 days:\n"+strings.Join(uneditedAccounts, "\n"),
+		return checks.NewOctopusCheckResultImplWithFindings(
+			"The following accounts have not been updated in 90 days:\n"+strings.Join(checks.FindingDescriptions(uneditedAccounts), "\n"),
 			o.Id(),
 			"",
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			uneditedAccounts).WithCoverage(coverage), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
@@ -104,7 +109,7 @@ func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusC
 		o.Id(),
 		"",
 		checks.Ok,
-		checks.Security), nil
+		checks.Security).WithCoverage(coverage), nil
 }
 
 type OctopusAudit struct {
@@ -112,7 +117,26 @@ type OctopusAudit struct {
 	RelatedDocumentIds []string
 }
 
-func (o OctopusUnrotatedAccountsCheck) GetAll(client newclient.Client, spaceID string) ([]*accounts.AccountResource, error) {
-	items, err := newclient.GetAll[accounts.AccountResource](client, "/api/{spaceId}/accounts", spaceID)
-	return items, err
+// recentlyModified returns true if the account was modified between the start and end times. Pages of audit events
+// are only read until a modification is found.
+func (o OctopusUnrotatedAccountsCheck) recentlyModified(accountId string, start time.Time, end time.Time) (bool, error) {
+	query := url.Values{
+		"regardingAny": []string{accountId},
+		"from":         []string{start.Format("2006-01-02T15:04:05-0700")},
+		"to":           []string{end.Format("2006-01-02T15:04:05-0700")},
+	}
+
+	pager := client_wrapper.NewPager[OctopusAudit](o.client.HttpSession(), "/api/events?"+query.Encode(), o.config.PageSize, 0)
+
+	for audit, err := range pager.Items() {
+		if err != nil {
+			return false, err
+		}
+
+		if audit.Category == "Modified" && slices.Index(audit.RelatedDocumentIds, accountId) != -1 {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
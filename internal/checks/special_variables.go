@@ -29,3 +29,124 @@ func IgnoreVariable(name string) bool {
 
 	return false
 }
+
+// SystemVariables is a catalogue of the variables that Octopus defines for deployments and runbook runs.
+// See https://octopus.com/docs/projects/variables/system-variables
+var SystemVariables = []string{
+	"Octopus.Channel.Id",
+	"Octopus.Channel.Name",
+	"Octopus.Deployment.Changes",
+	"Octopus.Deployment.ChangesMarkdown",
+	"Octopus.Deployment.Comments",
+	"Octopus.Deployment.Created",
+	"Octopus.Deployment.CreatedBy.DisplayName",
+	"Octopus.Deployment.CreatedBy.EmailAddress",
+	"Octopus.Deployment.CreatedBy.Id",
+	"Octopus.Deployment.CreatedBy.Username",
+	"Octopus.Deployment.Error",
+	"Octopus.Deployment.ErrorDetail",
+	"Octopus.Deployment.ExcludedMachines",
+	"Octopus.Deployment.ForcePackageDownload",
+	"Octopus.Deployment.ForcePackageRedeployment",
+	"Octopus.Deployment.Id",
+	"Octopus.Deployment.Machines",
+	"Octopus.Deployment.Name",
+	"Octopus.Deployment.PreviousSuccessful.Id",
+	"Octopus.Deployment.QueueTimeExpiry",
+	"Octopus.Deployment.SpecificMachines",
+	"Octopus.Deployment.TargetedRoles",
+	"Octopus.Deployment.Tenant.Id",
+	"Octopus.Deployment.Tenant.Name",
+	"Octopus.Deployment.Trigger.Id",
+	"Octopus.Deployment.Trigger.Name",
+	"Octopus.Deployment.WorkerLeaseCap",
+	"Octopus.Environment.Id",
+	"Octopus.Environment.Name",
+	"Octopus.Environment.SortOrder",
+	"Octopus.Machine.CommunicationStyle",
+	"Octopus.Machine.Hostname",
+	"Octopus.Machine.Id",
+	"Octopus.Machine.Name",
+	"Octopus.Machine.Roles",
+	"Octopus.Machine.TenantTags",
+	"Octopus.Project.Id",
+	"Octopus.Project.Name",
+	"Octopus.Project.Slug",
+	"Octopus.ProjectGroup.Id",
+	"Octopus.ProjectGroup.Name",
+	"Octopus.Release.Channel.Name",
+	"Octopus.Release.Created",
+	"Octopus.Release.CurrentForEnvironment.Id",
+	"Octopus.Release.CurrentForEnvironment.Number",
+	"Octopus.Release.Git.BranchName",
+	"Octopus.Release.Git.CommitHash",
+	"Octopus.Release.Git.Ref",
+	"Octopus.Release.Id",
+	"Octopus.Release.Notes",
+	"Octopus.Release.Number",
+	"Octopus.Release.PreviousForEnvironment.Id",
+	"Octopus.Release.PreviousForEnvironment.Number",
+	"Octopus.Runbook.Id",
+	"Octopus.Runbook.Name",
+	"Octopus.RunbookRun.Id",
+	"Octopus.RunbookRun.Name",
+	"Octopus.RunbookSnapshot.Id",
+	"Octopus.RunbookSnapshot.Name",
+	"Octopus.Space.Id",
+	"Octopus.Space.Name",
+	"Octopus.Task.Id",
+	"Octopus.Task.Name",
+	"Octopus.Task.QueueTime",
+	"Octopus.Task.QueueTimeExpiry",
+	"Octopus.Tenant.Id",
+	"Octopus.Tenant.Name",
+	"Octopus.Tenant.Tags",
+	"Octopus.Web.BaseUrl",
+	"Octopus.Web.DeploymentLink",
+	"Octopus.Web.ProjectLink",
+	"Octopus.Web.ReleaseLink",
+	"Octopus.Web.ServerUri",
+	"Octopus.WorkerPool.Id",
+	"Octopus.WorkerPool.Name",
+}
+
+// SystemVariablePrefixes are the prefixes of the families of system variables whose names can not be listed
+// ahead of time, like the properties and output variables of steps.
+var SystemVariablePrefixes = []string{
+	"Octopus.Action.",
+	"Octopus.Action[",
+	"Octopus.Agent.",
+	"Octopus.Calamari.",
+	"Octopus.Date.",
+	"Octopus.Endpoint.",
+	"Octopus.Environment.MachinesInRole[",
+	"Octopus.Release.Builds",
+	"Octopus.Release.Package",
+	"Octopus.Server.",
+	"Octopus.Step.",
+	"Octopus.Step[",
+	"Octopus.Task.Argument[",
+	"Octopus.Tentacle.",
+	"Octopus.Time.",
+	"Octopus.Version.",
+	"env:",
+}
+
+// IsSystemVariable returns true if the variable is defined by Octopus rather than the end user.
+func IsSystemVariable(name string) bool {
+	for _, variables := range [][]string{SystemVariables, SpecialVars} {
+		for _, systemVariable := range variables {
+			if strings.EqualFold(systemVariable, name) {
+				return true
+			}
+		}
+	}
+
+	for _, prefix := range SystemVariablePrefixes {
+		if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package checks
+
+import "testing"
+
+func TestIsSystemVariable(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"Octopus.Project.Name", true},
+		{"octopus.project.name", true},
+		{"Octopus.Action[Deploy].Output.Url", true},
+		{"Octopus.Step[Deploy].Status.Code", true},
+		{"Octopus.Date.Year", true},
+		{"Octopus.Date.Day", true},
+		{"Octopus.Time.Hour", true},
+		{"Octopus.Time.Minute", true},
+		{"Octopus.Agent.ProgramDirectoryPath", true},
+		{"Octopus.Agent.Id", true},
+		{"Octopus.Server.Id", true},
+		{"Octopus.Server.Url", true},
+		{"env:PATH", true},
+		{"Octopus.DateFormat", false},
+		{"Database.Name", false},
+		{"Octopus", false},
+		{"Octopus.AgentPath", false},
+	}
+
+	for _, test := range tests {
+		if IsSystemVariable(test.name) != test.expected {
+			t.Fatalf("Should have returned %t for %s", test.expected, test.name)
+		}
+	}
+}
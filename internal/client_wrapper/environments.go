@@ -5,18 +5,15 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 )
 
-func GetEnvironments(limit int, client newclient.Client, spaceID string) ([]*environments.Environment, error) {
-	if limit == 0 {
-		return environments.GetAll(client, spaceID)
-	}
-
-	result, err := environments.Get(client, spaceID, environments.EnvironmentsQuery{
-		Take: limit,
-	})
+// GetEnvironments returns up to limit environments, or all environments if limit is 0, and the number of environments in the space.
+// The environments are read pageSize at a time.
+func GetEnvironments(limit int, pageSize int, client newclient.Client, spaceID string) ([]*environments.Environment, int, error) {
+	pager := NewSpacePager[environments.Environment](client, spaceID, "environments", pageSize, limit)
+	items, err := pager.Collect()
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result.Items, nil
+	return items, pager.Total(), nil
 }
@@ -0,0 +1,14 @@
+package client_wrapper
+
+import (
+	"net/url"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+)
+
+// NewEventPager creates a pager that reads up to limit events matching the query, or every matching event if limit
+// is 0, from the space. Events are returned with the most recent event first.
+func NewEventPager(client newclient.Client, spaceID string, query url.Values, pageSize int, limit int) *Pager[events.Event] {
+	return NewSpacePager[events.Event](client, spaceID, "events?"+query.Encode(), pageSize, limit)
+}
@@ -3,20 +3,26 @@ package client_wrapper
 import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
 )
 
-func GetMachines(limit int, client newclient.Client, spaceID string) ([]*machines.DeploymentTarget, error) {
-	if limit == 0 {
-		return machines.GetAll(client, spaceID)
-	}
-
-	result, err := machines.Get(client, spaceID, machines.MachinesQuery{
-		Take: limit,
-	})
+// GetMachines returns up to limit targets, or all targets if limit is 0, and the number of targets in the space.
+// The targets are read pageSize at a time.
+func GetMachines(limit int, pageSize int, client newclient.Client, spaceID string) ([]*machines.DeploymentTarget, int, error) {
+	pager := NewSpacePager[machines.DeploymentTarget](client, spaceID, "machines", pageSize, limit)
+	items, err := pager.Collect()
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result.Items, nil
+	return items, pager.Total(), nil
+}
+
+// ScopedMachines returns the targets in the scope of an incremental scan, or every target for a full scan.
+func ScopedMachines(targets []*machines.DeploymentTarget, scope *config.ResourceScope) []*machines.DeploymentTarget {
+	return lo.Filter(targets, func(item *machines.DeploymentTarget, index int) bool {
+		return scope.IncludesTarget(item.ID)
+	})
 }
@@ -0,0 +1,157 @@
+package client_wrapper
+
+import (
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+)
+
+// Pager reads a collection from the Octopus API one page at a time. Only the current page is held in memory, so
+// large collections can be processed without loading every resource at once, and callers that find what they are
+// looking for can stop without requesting the remaining pages.
+type Pager[T any] struct {
+	session  *newclient.HttpSession
+	path     string
+	pageSize int
+	limit    int
+	read     int
+	total    int
+	done     bool
+}
+
+// NewPager creates a pager that reads up to limit resources, or every resource if limit is 0, from the collection at
+// path. The path may include query parameters, like "/api/Spaces-1/events?eventCategories=DeploymentQueued", and
+// any skip or take parameters are replaced. A pageSize of 0 uses the default page size.
+func NewPager[T any](session *newclient.HttpSession, path string, pageSize int, limit int) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = defaults.PageSize
+	}
+
+	return &Pager[T]{
+		session:  session,
+		path:     path,
+		pageSize: pageSize,
+		limit:    max(limit, 0),
+	}
+}
+
+// NewSpacePager creates a pager for a collection in a space, like "projects" or "lifecycles".
+func NewSpacePager[T any](client newclient.Client, spaceID string, collection string, pageSize int, limit int) *Pager[T] {
+	return NewPager[T](client.HttpSession(), SpacePath(spaceID, collection), pageSize, limit)
+}
+
+// SpacePath returns the path of a collection in a space, or the path of the collection in the default space if
+// spaceID is empty.
+func SpacePath(spaceID string, collection string) string {
+	if spaceID == "" {
+		return "/api/" + collection
+	}
+
+	return "/api/" + spaceID + "/" + collection
+}
+
+// Next returns the next page of resources. An empty page is returned once every resource, or the limit, has
+// been read.
+func (o *Pager[T]) Next() ([]*T, error) {
+	if o.done {
+		return []*T{}, nil
+	}
+
+	take := o.pageSize
+	if o.limit != 0 {
+		take = min(take, o.limit-o.read)
+	}
+
+	pageUrl, err := o.pageUrl(take)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := newclient.Get[resources.Resources[*T]](o.session, pageUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	o.read += len(page.Items)
+	o.total = max(page.TotalResults, o.read)
+
+	// Some endpoints ignore the take parameter, so a short page or a count that reaches the total ends the
+	// collection as well as the limit
+	o.done = len(page.Items) < take ||
+		o.read >= page.TotalResults ||
+		(o.limit != 0 && o.read >= o.limit)
+
+	if o.limit != 0 && o.read > o.limit {
+		page.Items = page.Items[:len(page.Items)-(o.read-o.limit)]
+		o.read = o.limit
+	}
+
+	return page.Items, nil
+}
+
+// Items returns an iterator over the resources in the collection, reading each page as it is required. Breaking out
+// of the loop stops the pager without requesting the remaining pages. An error ends the iteration, and is returned
+// with a nil resource.
+func (o *Pager[T]) Items() iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for !o.done {
+			page, err := o.Next()
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect reads the remaining pages and returns all the resources they contain.
+func (o *Pager[T]) Collect() ([]*T, error) {
+	items := []*T{}
+	for item, err := range o.Items() {
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Total returns the number of resources in the collection, as reported by the last page that was read.
+func (o *Pager[T]) Total() int {
+	return o.total
+}
+
+// Read returns the number of resources that have been returned by the pager.
+func (o *Pager[T]) Read() int {
+	return o.read
+}
+
+func (o *Pager[T]) pageUrl(take int) (string, error) {
+	pageUrl, err := url.Parse(o.path)
+
+	if err != nil {
+		return "", err
+	}
+
+	query := pageUrl.Query()
+	query.Set("skip", strconv.Itoa(o.read))
+	query.Set("take", strconv.Itoa(take))
+	pageUrl.RawQuery = query.Encode()
+
+	return pageUrl.String(), nil
+}
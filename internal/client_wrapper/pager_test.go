@@ -0,0 +1,162 @@
+package client_wrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+)
+
+type pagedItem struct {
+	Id        string     `json:"Id"`
+	StartTime *time.Time `json:"StartTime,omitempty"`
+	QueueTime *time.Time `json:"QueueTime,omitempty"`
+}
+
+// newPagedServer serves a collection of items, honouring the skip and take parameters, and counts the requests.
+func newPagedServer(t *testing.T, items []pagedItem, requests *int) *newclient.HttpSession {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+		end := min(skip+take, len(items))
+		skip = min(skip, end)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Items":        items[skip:end],
+			"TotalResults": len(items),
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	baseUrl, _ := url.Parse(server.URL)
+	return &newclient.HttpSession{HttpClient: server.Client(), BaseURL: baseUrl}
+}
+
+func pagedItems(count int) []pagedItem {
+	items := []pagedItem{}
+	for i := 0; i < count; i++ {
+		items = append(items, pagedItem{Id: fmt.Sprintf("Items-%d", i)})
+	}
+	return items
+}
+
+func TestPagerReadsAllPages(t *testing.T) {
+	requests := 0
+	pager := NewPager[pagedItem](newPagedServer(t, pagedItems(25), &requests), "/api/items", 10, 0)
+
+	items, err := pager.Collect()
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(items) != 25 || items[24].Id != "Items-24" {
+		t.Fatal("Should have returned every item")
+	}
+
+	if requests != 3 {
+		t.Fatal("Should have read 3 pages, read " + fmt.Sprint(requests))
+	}
+
+	if pager.Total() != 25 || pager.Read() != 25 {
+		t.Fatal("Should have reported the total and read counts")
+	}
+}
+
+func TestPagerLimit(t *testing.T) {
+	requests := 0
+	pager := NewPager[pagedItem](newPagedServer(t, pagedItems(25), &requests), "/api/items?partialName=Item", 10, 15)
+
+	items, err := pager.Collect()
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(items) != 15 || requests != 2 {
+		t.Fatal("Should have stopped reading at the limit")
+	}
+
+	if pager.Total() != 25 {
+		t.Fatal("Should have reported the number of items in the collection")
+	}
+}
+
+func TestPagerEarlyTermination(t *testing.T) {
+	requests := 0
+	pager := NewPager[pagedItem](newPagedServer(t, pagedItems(100), &requests), "/api/items", 10, 0)
+
+	for item, err := range pager.Items() {
+		if err != nil {
+			t.Fatal("Should not have returned an error: " + err.Error())
+		}
+
+		if item.Id == "Items-12" {
+			break
+		}
+	}
+
+	if requests != 2 {
+		t.Fatal("Should only have read the pages up to the item that was found, read " + fmt.Sprint(requests))
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"ErrorMessage": "You do not have permission"}`))
+	}))
+	defer server.Close()
+
+	baseUrl, _ := url.Parse(server.URL)
+	pager := NewPager[pagedItem](&newclient.HttpSession{HttpClient: server.Client(), BaseURL: baseUrl}, "/api/items", 10, 0)
+
+	if _, err := pager.Collect(); err == nil {
+		t.Fatal("Should have returned the error from the server")
+	}
+}
+
+func TestHasTaskSince(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-time.Hour * 24)
+	recent := now.Add(-time.Hour)
+	old := now.Add(-time.Hour * 48)
+
+	requests := 0
+	session := newPagedServer(t, []pagedItem{
+		{Id: "Tasks-4", QueueTime: &recent},
+		{Id: "Tasks-3", QueueTime: &recent, StartTime: &recent},
+		{Id: "Tasks-2", QueueTime: &old, StartTime: &old},
+		{Id: "Tasks-1", QueueTime: &old, StartTime: &old},
+	}, &requests)
+
+	found, err := HasTaskSince(session, "/api/tasks", since, 1)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !found || requests != 2 {
+		t.Fatal("Should have stopped reading at the first task started after the time")
+	}
+
+	requests = 0
+	found, err = HasTaskSince(session, "/api/tasks?type=Deployment", now, 1)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if found || requests != 1 {
+		t.Fatal("Should have stopped reading at the first task queued before the time")
+	}
+}
@@ -0,0 +1,245 @@
+package client_wrapper
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"go.uber.org/zap"
+)
+
+var linkTemplateOptions = regexp.MustCompile(`\{.*?}`)
+
+// ProcessLoader loads the deployment process, runbooks, and variables of a project. Projects that use
+// Config-as-Code are loaded from the configured git reference, or the default branch of the project if no
+// reference was configured. Projects saved in the database are loaded from the database.
+type ProcessLoader struct {
+	client   *client.Client
+	gitRef   string
+	pageSize int
+}
+
+func NewProcessLoader(client *client.Client, gitRef string, pageSize int) ProcessLoader {
+	return ProcessLoader{client: client, gitRef: gitRef, pageSize: pageSize}
+}
+
+// GitRef returns the git reference that the Config-as-Code resources of a project are loaded from. An empty
+// string is returned for projects saved in the database.
+func (o ProcessLoader) GitRef(project *projects.Project) (string, error) {
+	gitPersistenceSettings, err := o.gitPersistenceSettings(project)
+
+	if err != nil || gitPersistenceSettings == nil {
+		return "", err
+	}
+
+	if o.gitRef != "" {
+		return o.gitRef, nil
+	}
+
+	return gitPersistenceSettings.DefaultBranch(), nil
+}
+
+// GetDeploymentProcess returns the deployment process of a project, or nil if the project does not have a
+// deployment process.
+func (o ProcessLoader) GetDeploymentProcess(project *projects.Project) (*deployments.DeploymentProcess, error) {
+	gitRef, err := o.GitRef(project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if gitRef != "" {
+		deploymentProcess, err := deployments.GetDeploymentProcessByGitRef(o.client, o.client.GetSpaceID(), project, gitRef)
+		return ignoreNotFound(deploymentProcess, err)
+	}
+
+	if project.DeploymentProcessID == "" {
+		return nil, nil
+	}
+
+	return ignoreNotFound(o.client.DeploymentProcesses.GetByID(project.DeploymentProcessID))
+}
+
+// GetRunbooks returns the runbooks of a project.
+func (o ProcessLoader) GetRunbooks(project *projects.Project) ([]*runbooks.Runbook, error) {
+	path, err := o.runbooksPath(project)
+
+	if err != nil || path == "" {
+		return []*runbooks.Runbook{}, err
+	}
+
+	return NewPager[runbooks.Runbook](o.client.HttpSession(), path, o.pageSize, 0).Collect()
+}
+
+// GetRunbookProcesses returns the processes of all the runbooks in a project.
+func (o ProcessLoader) GetRunbookProcesses(project *projects.Project) ([]*runbooks.RunbookProcess, error) {
+	projectRunbooks, err := o.GetRunbooks(project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	runbookProcesses := []*runbooks.RunbookProcess{}
+	for _, runbook := range projectRunbooks {
+		runbookProcess, err := o.getRunbookProcess(runbook)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if runbookProcess != nil {
+			runbookProcesses = append(runbookProcesses, runbookProcess)
+		}
+	}
+
+	return runbookProcesses, nil
+}
+
+// GetAllSteps returns the steps in the deployment process and runbook processes of a project, along with the runbook
+// processes that hold the runbook steps.
+func (o ProcessLoader) GetAllSteps(project *projects.Project) ([]*deployments.DeploymentStep, []*runbooks.RunbookProcess, error) {
+	steps := []*deployments.DeploymentStep{}
+
+	deploymentProcess, err := o.GetDeploymentProcess(project)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if deploymentProcess != nil {
+		steps = append(steps, deploymentProcess.Steps...)
+	}
+
+	runbookProcesses, err := o.GetRunbookProcesses(project)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, runbookProcess := range runbookProcesses {
+		steps = append(steps, runbookProcess.Steps...)
+	}
+
+	return steps, runbookProcesses, nil
+}
+
+// GetVariables returns the variables of a project. The non-sensitive variables of a Config-as-Code project are
+// loaded from git, and the sensitive variables are loaded from the database.
+func (o ProcessLoader) GetVariables(project *projects.Project) (*variables.VariableSet, error) {
+	variableSet, err := o.client.Variables.GetAll(project.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gitPersistenceSettings, err := o.gitPersistenceSettings(project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if gitPersistenceSettings == nil || !gitPersistenceSettings.VariablesAreInGit() {
+		return &variableSet, nil
+	}
+
+	gitRef, err := o.GitRef(project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gitVariableSet, err := o.client.ProjectVariables.GetAllByGitRef(o.client.GetSpaceID(), project.ID, gitRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, v := range gitVariableSet.Variables {
+		existing[v.ID] = true
+	}
+
+	for _, v := range variableSet.Variables {
+		if !existing[v.ID] {
+			gitVariableSet.Variables = append(gitVariableSet.Variables, v)
+		}
+	}
+
+	return gitVariableSet, nil
+}
+
+func (o ProcessLoader) gitPersistenceSettings(project *projects.Project) (projects.GitPersistenceSettings, error) {
+	if !project.IsVersionControlled {
+		return nil, nil
+	}
+
+	gitPersistenceSettings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings)
+
+	if !ok {
+		zap.L().Error("Failed to cast PersistenceSettings to GitPersistenceSettings for project "+project.Name, logging.Project(project.Name), zap.Any("PersistenceSettings", project.PersistenceSettings))
+		return nil, errors.New("failed to cast PersistenceSettings to GitPersistenceSettings for project " + project.Name)
+	}
+
+	return gitPersistenceSettings, nil
+}
+
+func (o ProcessLoader) runbooksPath(project *projects.Project) (string, error) {
+	gitPersistenceSettings, err := o.gitPersistenceSettings(project)
+
+	if err != nil {
+		return "", err
+	}
+
+	if gitPersistenceSettings != nil && gitPersistenceSettings.RunbooksAreInGit() {
+		gitRef, err := o.GitRef(project)
+
+		if err != nil {
+			return "", err
+		}
+
+		return "/api/" + o.client.GetSpaceID() + "/projects/" + project.ID + "/" + url.PathEscape(gitRef) + "/runbooks", nil
+	}
+
+	link, ok := project.Links["Runbooks"]
+
+	if !ok {
+		return "", nil
+	}
+
+	return linkTemplateOptions.ReplaceAllString(link, ""), nil
+}
+
+func (o ProcessLoader) getRunbookProcess(runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error) {
+	// Runbooks in git link to the process for the git reference they were loaded from
+	if link, ok := runbook.Links["RunbookProcesses"]; ok {
+		runbookProcess, err := newclient.Get[runbooks.RunbookProcess](o.client.HttpSession(), linkTemplateOptions.ReplaceAllString(link, ""))
+		return ignoreNotFound(runbookProcess, err)
+	}
+
+	if runbook.RunbookProcessID == "" {
+		return nil, nil
+	}
+
+	return ignoreNotFound(o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID))
+}
+
+// ignoreNotFound treats missing resources as empty results rather than errors.
+func ignoreNotFound[T any](resource *T, err error) (*T, error) {
+	if err != nil {
+		var apiError *core.APIError
+		if errors.As(err, &apiError) && apiError.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resource, nil
+}
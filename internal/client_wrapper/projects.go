@@ -1,44 +1,44 @@
 package client_wrapper
 
 import (
+	"net/url"
+
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/excluder"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
-func GetProjects(limit int, client newclient.Client, spaceID string) ([]*projects.Project, error) {
-	if limit == 0 {
-		return projects.GetAll(client, spaceID)
-	}
-
-	result, err := projects.Get(client, spaceID, projects.ProjectsQuery{
-		Take: limit,
-	})
+// GetProjects returns up to limit projects, or all projects if limit is 0, and the number of projects in the space.
+// The projects are read pageSize at a time.
+func GetProjects(limit int, pageSize int, client newclient.Client, spaceID string) ([]*projects.Project, int, error) {
+	pager := NewSpacePager[projects.Project](client, spaceID, "projects", pageSize, limit)
+	items, err := pager.Collect()
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result.Items, nil
+	return items, pager.Total(), nil
 }
 
-func GetProjectByName(name string, client newclient.Client, spaceID string) ([]*projects.Project, error) {
+// GetProjectByName returns the project with the exact name, or an empty slice if no project has the name. The
+// partial name search can match many projects, so pages are read until the project is found.
+func GetProjectByName(name string, pageSize int, client newclient.Client, spaceID string) ([]*projects.Project, error) {
 	if name == "" {
 		return []*projects.Project{}, nil
 	}
 
-	result, err := projects.Get(client, spaceID, projects.ProjectsQuery{
-		PartialName: name,
-	})
+	pager := NewSpacePager[projects.Project](client, spaceID, "projects?partialName="+url.QueryEscape(name), pageSize, 0)
 
-	if err != nil {
-		return nil, err
-	}
+	for project, err := range pager.Items() {
+		if err != nil {
+			return nil, err
+		}
 
-	for _, project := range result.Items {
 		if project.Name == name {
 			return []*projects.Project{project}, nil
 		}
@@ -47,28 +47,33 @@ func GetProjectByName(name string, client newclient.Client, spaceID string) ([]*
 	return []*projects.Project{}, nil
 }
 
-func GetProjectsWithFilter(client newclient.Client, spaceID string, excludeProjectsExcept config.StringSliceArgs, excludeProjects config.StringSliceArgs, maxItems int) ([]*projects.Project, error) {
+// GetProjectsWithFilter returns up to maxItems projects that are not excluded by the excludeProjects and
+// excludeProjectsExcept arguments, and the number of projects that were available to scan. Excluded projects are
+// not counted as available. The projects are read pageSize at a time.
+func GetProjectsWithFilter(client newclient.Client, spaceID string, excludeProjectsExcept config.StringSliceArgs, excludeProjects config.StringSliceArgs, maxItems int, pageSize int) ([]*projects.Project, int, error) {
 	if len(excludeProjectsExcept) != 0 {
-		return GetNamedProjects(client, spaceID, excludeProjectsExcept)
+		namedProjects, err := GetNamedProjects(client, spaceID, excludeProjectsExcept, pageSize)
+		return namedProjects, len(namedProjects), err
 	}
 
-	if allProjects, err := GetProjects(maxItems, client, spaceID); err != nil {
+	if allProjects, total, err := GetProjects(maxItems, pageSize, client, spaceID); err != nil {
 		zap.L().Error("Failed to get projects", zap.Error(err))
-		return nil, err
+		return nil, 0, err
 	} else {
 		defaultExcluder := excluder.DefaultExcluder{}
-		return lo.Filter(allProjects, func(item *projects.Project, index int) bool {
+		filteredProjects := lo.Filter(allProjects, func(item *projects.Project, index int) bool {
 			return !defaultExcluder.IsResourceExcluded(item.Name, false, excludeProjects, excludeProjectsExcept)
-		}), nil
+		})
+		return filteredProjects, total - (len(allProjects) - len(filteredProjects)), nil
 	}
 }
 
-func GetNamedProjects(client newclient.Client, spaceID string, excludeProjectsExcept config.StringSliceArgs) ([]*projects.Project, error) {
+func GetNamedProjects(client newclient.Client, spaceID string, excludeProjectsExcept config.StringSliceArgs, pageSize int) ([]*projects.Project, error) {
 	projects := []*projects.Project{}
 
 	for _, projectName := range excludeProjectsExcept {
-		if project, err := GetProjectByName(projectName, client, spaceID); err != nil {
-			zap.L().Error("Failed to get project with name "+projectName, zap.Error(err))
+		if project, err := GetProjectByName(projectName, pageSize, client, spaceID); err != nil {
+			zap.L().Error("Failed to get project with name "+projectName, logging.Project(projectName), zap.Error(err))
 			return nil, err
 		} else {
 			projects = append(projects, project...)
@@ -77,3 +82,10 @@ func GetNamedProjects(client newclient.Client, spaceID string, excludeProjectsEx
 
 	return projects, nil
 }
+
+// ScopedProjects returns the projects in the scope of an incremental scan, or every project for a full scan.
+func ScopedProjects(allProjects []*projects.Project, scope *config.ResourceScope) []*projects.Project {
+	return lo.Filter(allProjects, func(item *projects.Project, index int) bool {
+		return scope.IncludesProject(item.ID)
+	})
+}
@@ -1,6 +1,12 @@
 package client_wrapper
 
-import "net/http"
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+)
 
 type HeaderRoundTripper struct {
 	Transport http.RoundTripper
@@ -13,3 +19,76 @@ func (h *HeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 	}
 	return h.Transport.RoundTrip(req)
 }
+
+// rootPath matches the paths of the API root and space root documents, which are read each time an Octopus client
+// is created.
+var rootPath = regexp.MustCompile(`/api/(Spaces-\d+)?$`)
+
+// RootCacheRoundTripper saves the API root and space root documents, so Octopus clients created with the same
+// transport only read them once. All other requests are sent to the Transport.
+type RootCacheRoundTripper struct {
+	Transport http.RoundTripper
+	mutex     sync.Mutex
+	roots     map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	header http.Header
+	body   []byte
+}
+
+func NewRootCacheRoundTripper(transport http.RoundTripper) *RootCacheRoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &RootCacheRoundTripper{Transport: transport, roots: map[string]cachedResponse{}}
+}
+
+func (o *RootCacheRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !rootPath.MatchString(req.URL.Path) {
+		return o.Transport.RoundTrip(req)
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	key := req.URL.String()
+
+	if cached, found := o.roots[key]; found {
+		return cached.response(req), nil
+	}
+
+	resp, err := o.Transport.RoundTrip(req)
+
+	// Errors are not saved, so the next client tries again
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cached := cachedResponse{header: resp.Header.Clone(), body: body}
+	o.roots[key] = cached
+
+	return cached.response(req), nil
+}
+
+func (o cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        o.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(o.body)),
+		ContentLength: int64(len(o.body)),
+		Request:       req,
+	}
+}
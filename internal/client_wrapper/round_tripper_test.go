@@ -0,0 +1,45 @@
+package client_wrapper
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRootCacheRoundTripper(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Path": "` + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: NewRootCacheRoundTripper(nil)}
+
+	for i := 0; i < 3; i++ {
+		for _, path := range []string{"/api/", "/api/Spaces-1", "/api/Spaces-1/projects"} {
+			resp, err := client.Get(server.URL + path)
+
+			if err != nil {
+				t.Fatal("Should not have returned an error: " + err.Error())
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if string(body) != `{"Path": "`+path+`"}` || resp.Header.Get("Content-Type") != "application/json" {
+				t.Fatal("Should have returned the response of " + path + ", got " + string(body))
+			}
+		}
+	}
+
+	if requests["/api/"] != 1 || requests["/api/Spaces-1"] != 1 {
+		t.Fatal("Should have read the root documents once")
+	}
+
+	if requests["/api/Spaces-1/projects"] != 3 {
+		t.Fatal("Should have sent every other request")
+	}
+}
@@ -0,0 +1,32 @@
+package client_wrapper
+
+import (
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+)
+
+// HasTaskSince returns true if any task in the collection at path started or completed after the time. Tasks are
+// returned with the most recently queued task first, so pages are only read until a matching task, or a task queued
+// before the time, is found.
+func HasTaskSince(session *newclient.HttpSession, path string, since time.Time, pageSize int) (bool, error) {
+	pager := NewPager[tasks.Task](session, path, pageSize, 0)
+
+	for task, err := range pager.Items() {
+		if err != nil {
+			return false, err
+		}
+
+		if (task.StartTime != nil && task.StartTime.After(since)) ||
+			(task.CompletedTime != nil && task.CompletedTime.After(since)) {
+			return true, nil
+		}
+
+		if task.QueueTime != nil && task.QueueTime.Before(since) {
+			return false, nil
+		}
+	}
+
+	return false, nil
+}
@@ -5,18 +5,15 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
 )
 
-func GetTenants(limit int, client newclient.Client, spaceID string) ([]*tenants.Tenant, error) {
-	if limit == 0 {
-		return tenants.GetAll(client, spaceID)
-	}
-
-	result, err := tenants.Get(client, spaceID, tenants.TenantsQuery{
-		Take: limit,
-	})
+// GetTenants returns up to limit tenants, or all tenants if limit is 0, and the number of tenants in the space.
+// The tenants are read pageSize at a time.
+func GetTenants(limit int, pageSize int, client newclient.Client, spaceID string) ([]*tenants.Tenant, int, error) {
+	pager := NewSpacePager[tenants.Tenant](client, spaceID, "tenants", pageSize, limit)
+	items, err := pager.Collect()
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result.Items, nil
+	return items, pager.Total(), nil
 }
@@ -3,21 +3,17 @@ package client_wrapper
 import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workers"
 )
 
-func GetWorkers(limit int, client newclient.Client, spaceID string) ([]*machines.Worker, error) {
-	if limit == 0 {
-		return workers.GetAll(client, spaceID)
-	}
-
-	result, err := workers.Get(client, spaceID, machines.WorkersQuery{
-		Take: limit,
-	})
+// GetWorkers returns up to limit workers, or all workers if limit is 0, and the number of workers in the space.
+// The workers are read pageSize at a time.
+func GetWorkers(limit int, pageSize int, client newclient.Client, spaceID string) ([]*machines.Worker, int, error) {
+	pager := NewSpacePager[machines.Worker](client, spaceID, "workers", pageSize, limit)
+	items, err := pager.Collect()
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result.Items, nil
+	return items, pager.Total(), nil
 }
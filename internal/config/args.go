@@ -18,6 +18,38 @@ type OctolintConfig struct {
 	ConfigFile    string
 	ConfigPath    string
 	Verbose       bool
+	ReportFormat  string
+
+	// permission settings
+	PrintRequiredPermissions bool
+
+	// API settings
+	PageSize int
+
+	// octopus report settings
+	OctopusArtifactFile string
+	FailOnSeverity      string
+
+	// remediation settings
+	RemediationPlan              string
+	RemediationRetentionQuantity int
+	RemediationRetentionUnit     string
+	Fix                          bool
+	FixApiKey                    string
+	UndoLog                      string
+	Yes                          bool
+
+	// markdown report settings
+	MarkdownMaxLength int
+
+	// health score settings
+	ScoreWeightSecurity     float64
+	ScoreWeightOrganization float64
+	ScoreWeightNaming       float64
+	ScoreWeightPerformance  float64
+	ScoreWeightError        float64
+	ScoreWeightWarning      float64
+	ScoreWeightInfo         float64
 
 	// redirector settings
 	UseRedirector           bool
@@ -26,6 +58,57 @@ type OctolintConfig struct {
 	RedirecrtorApiKey       string
 	RedirectorRedirections  string
 
+	// Config-as-Code settings
+	GitRef       string
+	OclDirectory string
+
+	// history settings
+	HistoryFile string
+	Trend       bool
+	TrendFormat string
+
+	// incremental scan settings
+	Incremental      bool
+	IncrementalCache string
+	// Scope is set by incremental scans to limit the resources inspected by checks that support incremental scans.
+	// It is nil for a full scan, and is never set by an argument.
+	Scope *ResourceScope
+
+	// logging settings
+	LogFormat string
+	LogLevel  string
+	LogFile   string
+
+	// tracing settings
+	TraceExporter string
+	TraceFile     string
+	TraceEndpoint string
+
+	// plugin settings
+	Plugins         StringSliceArgs
+	PluginTimeout   int
+	PluginResources StringSliceArgs
+
+	// webhook settings
+	WebhookUrls      StringSliceArgs
+	WebhookFormat    string
+	WebhookSeverity  string
+	WebhookOnChange  bool
+	WebhookSecret    string
+	WebhookRetries   int
+	WebhookStateFile string
+
+	// issue tracker settings
+	IssueTracker    string
+	IssueUrl        string
+	IssueToken      string
+	IssueUsername   string
+	IssueRepository string
+	IssueProject    string
+	IssueType       string
+	IssueGrouping   string
+	IssueLabels     StringSliceArgs
+
 	// Global filters for resources
 	ExcludeProjects       StringSliceArgs
 	ExcludeProjectsExcept StringSliceArgs
@@ -41,6 +124,7 @@ type OctolintConfig struct {
 	TargetRoleRegex                           string
 	ProjectReleaseTemplateRegex               string
 	ProjectStepWorkerPoolRegex                string
+	ProjectStepWorkerPoolSlugRegex            string
 	SpaceNameRegex                            string
 	LibraryVariableSetNameRegex               string
 	TenantNameRegex                           string
@@ -68,6 +152,8 @@ type OctolintConfig struct {
 	MaxProjectSpecificEnvironmentEnvironments int
 	MaxProjectStepsProjects                   int
 	MaxUnusedVariablesProjects                int
+	MaxUndefinedVariablesProjects             int
+	MaxOutputVariablesProjects                int
 	MaxUnusedProjects                         int
 	MaxUnusedTenants                          int
 	MaxDefaultStepNameProjects                int
@@ -83,6 +169,15 @@ type OctolintConfig struct {
 	MaxSha1CertificatesMachines               int
 }
 
+// DiffConfig holds the settings used to compare two reports.
+type DiffConfig struct {
+	Help      bool
+	OldReport string
+	NewReport string
+	Format    string
+	MatchBy   string
+}
+
 type StringSliceArgs []string
 
 func (i *StringSliceArgs) String() string {
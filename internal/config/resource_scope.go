@@ -0,0 +1,33 @@
+package config
+
+import "slices"
+
+// ResourceScope lists the resources that changed since the last scan. Checks that support incremental scans only
+// inspect the resources in the scope, and their cached findings are kept for every other resource.
+type ResourceScope struct {
+	ProjectIds            []string
+	TargetIds             []string
+	TenantIds             []string
+	LibraryVariableSetIds []string
+}
+
+// IncludesProject returns true if the project should be inspected. Every project is inspected by a full scan,
+// which has a nil scope.
+func (o *ResourceScope) IncludesProject(id string) bool {
+	return o == nil || slices.Contains(o.ProjectIds, id)
+}
+
+// IncludesTarget returns true if the deployment target should be inspected.
+func (o *ResourceScope) IncludesTarget(id string) bool {
+	return o == nil || slices.Contains(o.TargetIds, id)
+}
+
+// IncludesTenant returns true if the tenant should be inspected.
+func (o *ResourceScope) IncludesTenant(id string) bool {
+	return o == nil || slices.Contains(o.TenantIds, id)
+}
+
+// IncludesLibraryVariableSet returns true if the library variable set should be inspected.
+func (o *ResourceScope) IncludesLibraryVariableSet(id string) bool {
+	return o == nil || slices.Contains(o.LibraryVariableSetIds, id)
+}
@@ -11,6 +11,8 @@ const MaxInvalidContainerImageProjects = 100
 const MaxInvalidReleaseTemplateProjects = 100
 const MaxProjectSpecificEnvironmentProjects = 100
 const MaxUnusedVariablesProjects = 100
+const MaxUndefinedVariablesProjects = 100
+const MaxOutputVariablesProjects = 100
 const MaxProjectStepsProjects = 100
 const MaxExclusiveEnvironmentsProjects = 100
 const MaxEmptyProjectCheckProjects = 100
@@ -27,3 +29,20 @@ const MaxInsecureK8sTargets = 100
 const MaxSha1CertificatesMachines = 100
 const MaxDeploymentTasks = 100
 const MaxDefaultStepNameProjects = 100
+const MarkdownMaxLength = 65000
+const ScoreWeightSecurity = 1.0
+const ScoreWeightOrganization = 1.0
+const ScoreWeightNaming = 1.0
+const ScoreWeightPerformance = 1.0
+const ScoreWeightError = 1.0
+const ScoreWeightWarning = 0.6
+const ScoreWeightInfo = 0.2
+const RemediationRetentionQuantity = 30
+const PageSize = 100
+const IncrementalCache = "octolint-cache.json"
+const TraceFile = "octolint-trace.jsonl"
+const PluginTimeout = 60
+const WebhookRetries = 3
+const WebhookStateFile = "octolint-webhook-state.json"
+const IssueType = "Task"
+const IssueGrouping = "check"
@@ -0,0 +1,55 @@
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+)
+
+const (
+	DiffFormatText = "text"
+	DiffFormatJson = "json"
+)
+
+// GenerateDiffReport renders the diff in the requested format.
+func GenerateDiffReport(reportDiff *ReportDiff, format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", DiffFormatText:
+		return generateTextDiffReport(reportDiff), nil
+	case DiffFormatJson:
+		report, err := json.MarshalIndent(reportDiff, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(report), nil
+	default:
+		return "", errors.New("the diff format " + format + " is not supported. Supported formats are " +
+			strings.Join([]string{DiffFormatText, DiffFormatJson}, ", "))
+	}
+}
+
+func generateTextDiffReport(reportDiff *ReportDiff) string {
+	var builder strings.Builder
+
+	for _, check := range reportDiff.Checks {
+		builder.WriteString("[" + check.Code + "] " + check.Category + "\n")
+		writeFindings(&builder, "+", check.Added)
+		writeFindings(&builder, "-", check.Removed)
+		writeFindings(&builder, " ", check.Unchanged)
+		builder.WriteString("=====\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("Added: %d, Removed: %d, Unchanged: %d",
+		reportDiff.Summary.Added, reportDiff.Summary.Removed, reportDiff.Summary.Unchanged))
+
+	return builder.String()
+}
+
+func writeFindings(builder *strings.Builder, prefix string, findings []reporters.JsonFinding) {
+	for _, finding := range findings {
+		builder.WriteString(prefix + " " + finding.Description + "\n")
+	}
+}
@@ -0,0 +1,177 @@
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+)
+
+const (
+	MatchById   = "id"
+	MatchByName = "name"
+)
+
+// ReportDiff describes the changes between two JSON reports.
+type ReportDiff struct {
+	Checks  []CheckDiff `json:"checks"`
+	Summary DiffSummary `json:"summary"`
+}
+
+// DiffSummary counts the findings that were added, removed, or left unchanged across all checks.
+type DiffSummary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// CheckDiff describes the changes to the findings of a single check.
+type CheckDiff struct {
+	Code      string                  `json:"code"`
+	Category  string                  `json:"category"`
+	Added     []reporters.JsonFinding `json:"added"`
+	Removed   []reporters.JsonFinding `json:"removed"`
+	Unchanged []reporters.JsonFinding `json:"unchanged"`
+}
+
+// LoadReport reads a report generated with the json report format.
+func LoadReport(path string) (*reporters.JsonReport, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := reporters.JsonReport{}
+	if err := json.Unmarshal(content, &report); err != nil {
+		return nil, errors.New("the file " + path + " is not a JSON report: " + err.Error())
+	}
+
+	return &report, nil
+}
+
+// Compare matches the findings in the old and new reports by check code and resource identity. The matchBy
+// argument selects whether resources are matched by their Octopus IDs or by their names.
+func Compare(oldReport *reporters.JsonReport, newReport *reporters.JsonReport, matchBy string) (*ReportDiff, error) {
+	identity, err := identityFunc(matchBy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	oldFindings := findingsByCheck(oldReport)
+	newFindings := findingsByCheck(newReport)
+
+	codes := map[string]string{}
+	for code, result := range oldFindings {
+		codes[code] = result.category
+	}
+	for code, result := range newFindings {
+		codes[code] = result.category
+	}
+
+	sortedCodes := make([]string, 0, len(codes))
+	for code := range codes {
+		sortedCodes = append(sortedCodes, code)
+	}
+	sort.Strings(sortedCodes)
+
+	reportDiff := ReportDiff{
+		Checks: []CheckDiff{},
+	}
+
+	for _, code := range sortedCodes {
+		checkDiff := CheckDiff{
+			Code:      code,
+			Category:  codes[code],
+			Added:     []reporters.JsonFinding{},
+			Removed:   []reporters.JsonFinding{},
+			Unchanged: []reporters.JsonFinding{},
+		}
+
+		oldIdentities := map[string]bool{}
+		for _, finding := range oldFindings[code].findings {
+			oldIdentities[identity(finding.ToFinding())] = true
+		}
+
+		newIdentities := map[string]bool{}
+		for _, finding := range newFindings[code].findings {
+			id := identity(finding.ToFinding())
+			newIdentities[id] = true
+
+			if oldIdentities[id] {
+				checkDiff.Unchanged = append(checkDiff.Unchanged, finding)
+			} else {
+				checkDiff.Added = append(checkDiff.Added, finding)
+			}
+		}
+
+		for _, finding := range oldFindings[code].findings {
+			if !newIdentities[identity(finding.ToFinding())] {
+				checkDiff.Removed = append(checkDiff.Removed, finding)
+			}
+		}
+
+		if len(checkDiff.Added)+len(checkDiff.Removed)+len(checkDiff.Unchanged) == 0 {
+			continue
+		}
+
+		reportDiff.Summary.Added += len(checkDiff.Added)
+		reportDiff.Summary.Removed += len(checkDiff.Removed)
+		reportDiff.Summary.Unchanged += len(checkDiff.Unchanged)
+		reportDiff.Checks = append(reportDiff.Checks, checkDiff)
+	}
+
+	return &reportDiff, nil
+}
+
+type checkFindings struct {
+	category string
+	findings []reporters.JsonFinding
+}
+
+// findingsByCheck groups the findings of failed checks by check code. Checks that failed without reporting
+// individual resources are represented by a single finding describing the check itself.
+func findingsByCheck(report *reporters.JsonReport) map[string]checkFindings {
+	results := map[string]checkFindings{}
+
+	if report == nil {
+		return results
+	}
+
+	for _, result := range report.Results {
+		if result.Severity < checks.Warning {
+			results[result.Code] = checkFindings{category: result.Category}
+			continue
+		}
+
+		findings := result.Findings
+		if len(findings) == 0 {
+			findings = []reporters.JsonFinding{{
+				ResourceType: "Check",
+				ResourceName: result.Code,
+				Description:  result.Description,
+			}}
+		}
+
+		results[result.Code] = checkFindings{category: result.Category, findings: findings}
+	}
+
+	return results
+}
+
+func identityFunc(matchBy string) (func(finding checks.OctopusCheckFinding) string, error) {
+	switch strings.ToLower(strings.TrimSpace(matchBy)) {
+	case "", MatchById:
+		return checks.OctopusCheckFinding.Identity, nil
+	case MatchByName:
+		return checks.OctopusCheckFinding.NameIdentity, nil
+	default:
+		return nil, errors.New("the matchBy value " + matchBy + " is not supported. Supported values are " +
+			strings.Join([]string{MatchById, MatchByName}, ", "))
+	}
+}
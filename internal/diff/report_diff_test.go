@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+func buildReport(findings ...reporters.JsonFinding) *reporters.JsonReport {
+	severity := checks.Ok
+	if len(findings) != 0 {
+		severity = checks.Warning
+	}
+
+	return &reporters.JsonReport{Results: []reporters.JsonCheckResult{{
+		Code:     "OctoRecAlwaysFail",
+		Severity: severity,
+		Category: checks.Organization,
+		Findings: findings,
+	}}}
+}
+
+func TestCompareById(t *testing.T) {
+	project1 := reporters.JsonFinding{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project 1", Description: "Project 1"}
+	project2 := reporters.JsonFinding{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-2", ResourceName: "Project 2", Description: "Project 2"}
+	project3 := reporters.JsonFinding{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-3", ResourceName: "Project 3", Description: "Project 3"}
+
+	reportDiff, err := Compare(buildReport(project1, project2), buildReport(project2, project3), MatchById)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(reportDiff.Checks) != 1 {
+		t.Fatal("Should have returned 1 check")
+	}
+
+	check := reportDiff.Checks[0]
+	if len(check.Added) != 1 || check.Added[0].ResourceId != "Projects-3" {
+		t.Fatal("Should have added Projects-3")
+	}
+
+	if len(check.Removed) != 1 || check.Removed[0].ResourceId != "Projects-1" {
+		t.Fatal("Should have removed Projects-1")
+	}
+
+	if len(check.Unchanged) != 1 || check.Unchanged[0].ResourceId != "Projects-2" {
+		t.Fatal("Should have left Projects-2 unchanged")
+	}
+}
+
+func TestCompareByName(t *testing.T) {
+	oldProject := reporters.JsonFinding{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project 1", Description: "Project 1"}
+	newProject := reporters.JsonFinding{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-101", ResourceName: "Project 1", Description: "Project 1"}
+
+	reportDiff, err := Compare(buildReport(oldProject), buildReport(newProject), MatchByName)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if reportDiff.Summary.Unchanged != 1 || reportDiff.Summary.Added != 0 || reportDiff.Summary.Removed != 0 {
+		t.Fatal("Should have matched the projects by name")
+	}
+}
+
+func TestCompareFixedCheck(t *testing.T) {
+	project1 := reporters.JsonFinding{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project 1", Description: "Project 1"}
+
+	reportDiff, err := Compare(buildReport(project1), buildReport(), MatchById)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if reportDiff.Summary.Removed != 1 {
+		t.Fatal("Should have removed Projects-1")
+	}
+
+	text, err := GenerateDiffReport(reportDiff, DiffFormatText)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(text, "- Project 1") {
+		t.Fatal("Should have listed the removed finding")
+	}
+}
+
+func TestCompareCheckWithoutFindings(t *testing.T) {
+	oldReport := &reporters.JsonReport{Results: []reporters.JsonCheckResult{{
+		Code:        "OctoRecTooManyEnvironments",
+		Description: "There are too many environments",
+		Severity:    checks.Warning,
+		Category:    checks.Organization,
+	}}}
+
+	reportDiff, err := Compare(oldReport, oldReport, MatchById)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if reportDiff.Summary.Unchanged != 1 {
+		t.Fatal("Should have treated the failed check as a single unchanged finding")
+	}
+}
+
+func TestLoadReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	results, err := reporters.NewOctopusJsonCheckReporter(checks.Ok, scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization),
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if err := os.WriteFile(path, []byte(results), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := LoadReport(path)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(report.Results) != 1 || report.Results[0].Code != "OctoRecAlwaysPass" {
+		t.Fatal("Should have loaded the report")
+	}
+}
+
+func TestInvalidMatchBy(t *testing.T) {
+	if _, err := Compare(buildReport(), buildReport(), "invalid"); err == nil {
+		t.Fatal("Should have returned an error")
+	}
+}
@@ -1,32 +1,47 @@
 package entry
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/args"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/factory"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/plugins"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/executor"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/diff"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/history"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/issues"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/notify"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/preflight"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/remediation"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/tracing"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/pkg/octolint"
 	"github.com/briandowns/spinner"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
 var Version = "development"
 
 func Entry(octolintConfig *config.OctolintConfig) ([]checks.OctopusCheckResult, error) {
-	zap.ReplaceGlobals(createLogger(octolintConfig.Verbose))
+	logger, err := logging.NewLogger(octolintConfig.LogFormat, octolintConfig.LogLevel, octolintConfig.LogFile, octolintConfig.Verbose)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Every log line written during the scan identifies the space
+	zap.ReplaceGlobals(logger.With(logging.Space(octolintConfig.Space)))
+	defer logger.Sync()
 
 	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 
@@ -40,229 +55,367 @@ func Entry(octolintConfig *config.OctolintConfig) ([]checks.OctopusCheckResult,
 		}
 	}()
 
-	if octolintConfig.Version {
-		fmt.Println("Version: " + Version)
-		os.Exit(0)
+	if octolintConfig.FailOnSeverity != "" {
+		if _, err := checks.ParseSeverity(octolintConfig.FailOnSeverity); err != nil {
+			return nil, err
+		}
 	}
 
-	if octolintConfig.Url == "" {
-		return nil, errors.New("You must specify the URL with the -url argument")
+	if err := notify.Validate(octolintConfig); err != nil {
+		return nil, err
 	}
 
-	if _, err := url.ParseRequestURI(octolintConfig.Url); err != nil {
-		return nil, errors.New("The URL \"" + octolintConfig.Url + "\" is not valid")
+	if err := issues.Validate(octolintConfig); err != nil {
+		return nil, err
 	}
 
-	if octolintConfig.ApiKey == "" && octolintConfig.AccessToken == "" {
-		return nil, errors.New("You must specify the API key with the -apiKey argument")
+	if octolintConfig.OclDirectory != "" {
+		if arguments := onlineArguments(octolintConfig); len(arguments) != 0 {
+			return nil, errors.New("The " + strings.Join(arguments, ", ") + " arguments can not be used with the -oclDirectory argument, " +
+				"as they record or act on the results of a scan of an Octopus space")
+		}
 	}
 
-	if octolintConfig.Space == "" {
-		return nil, errors.New("You must specify the space key with the -space argument")
+	if octolintConfig.Fix && octolintConfig.FixApiKey == "" {
+		return nil, errors.New("You must specify an API key with write permissions with the -fixApiKey argument to apply fixes")
 	}
 
-	if !strings.HasPrefix(octolintConfig.Space, "Spaces-") {
-		spaceId, err := lookupSpaceAsName(octolintConfig.Url, octolintConfig.Space, octolintConfig.ApiKey, octolintConfig.AccessToken)
-
-		if err != nil {
-			return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
-		}
-
-		octolintConfig.Space = spaceId
+	if (octolintConfig.RemediationPlan != "" || octolintConfig.Fix) && octolintConfig.RemediationRetentionUnit != lifecycles.RetentionUnitDays &&
+		octolintConfig.RemediationRetentionUnit != lifecycles.RetentionUnitItems {
+		return nil, errors.New("The -remediationRetentionUnit argument must be " + lifecycles.RetentionUnitDays + " or " + lifecycles.RetentionUnitItems)
 	}
 
-	httpClient, err := createHttpClient(octolintConfig)
+	scanTracing, err := tracing.Setup(octolintConfig, Version)
 
 	if err != nil {
-		return nil, errors.New("Failed to create the HTTP client. Check that the url is correct.\nThe error was: " + err.Error())
+		return nil, errors.New("Failed to set up tracing.\nThe error was: " + err.Error())
 	}
 
-	client, err := createClient(httpClient, octolintConfig)
+	defer func() {
+		if err := scanTracing.Shutdown(context.Background()); err != nil {
+			zap.L().Error("Failed to export the trace", zap.Error(err))
+		}
+
+		if octolintConfig.Verbose {
+			// Write to stderr so machine-readable reports written to stdout are not affected
+			fmt.Fprint(os.Stderr, scanTracing.Summary(10))
+		}
+	}()
+
+	report, err := octolint.NewScanner(octolintConfig).
+		WithVersion(Version).
+		// The summary printed by -verbose does not need the request spans under each check
+		WithCheckRequestSpans(octolintConfig.TraceExporter != "").
+		WithErrorHandler(func(check checks.OctopusCheck, err error) error {
+			fmt.Fprint(os.Stderr, "Failed to execute check "+check.Id())
+			if octolintConfig.VerboseErrors {
+				fmt.Println("##octopus[stdout-verbose]")
+				fmt.Println(err.Error())
+				fmt.Println("##octopus[stdout-default]")
+			} else {
+				fmt.Fprint(os.Stderr, err.Error()+"\n")
+			}
+			return nil
+		}).
+		Scan(context.Background())
 
 	if err != nil {
-		return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+		return nil, err
 	}
 
-	checkFactory := factory.NewOctopusCheckFactory(client, octolintConfig.Url, octolintConfig.Space)
-	checkCollection, err := checkFactory.BuildAllChecks(octolintConfig)
+	if octolintConfig.OclDirectory != "" {
+		return report.Results, nil
+	}
 
-	if err != nil {
-		ErrorExit("Failed to create the checks")
+	// Later steps, like the scan history, record the space ID rather than the space name
+	octolintConfig.Space = report.Space
+
+	for _, check := range report.SkippedChecks {
+		// Write to stderr so machine-readable reports written to stdout are not affected
+		fmt.Fprintln(os.Stderr, "Skipped check "+check.Check.Id()+" because the API key does not have the following permissions in the space: "+strings.Join(check.MissingPermissions, ", "))
 	}
 
-	// Time the execution
-	startTime := time.Now().UnixMilli()
-	defer func() {
-		endTime := time.Now().UnixMilli()
-		fmt.Println("Report took " + fmt.Sprint((endTime-startTime)/1000) + " seconds")
-	}()
+	// Write to stderr so machine-readable reports written to stdout are not affected
+	fmt.Fprintln(os.Stderr, "Report took "+fmt.Sprint(int(report.Duration.Seconds()))+" seconds")
 
-	checkExecutor := executor.NewOctopusCheckExecutor()
-	results, err := checkExecutor.ExecuteChecks(checkCollection, func(check checks.OctopusCheck, err error) error {
-		fmt.Fprint(os.Stderr, "Failed to execute check "+check.Id())
-		if octolintConfig.VerboseErrors {
-			fmt.Println("##octopus[stdout-verbose]")
-			fmt.Println(err.Error())
-			fmt.Println("##octopus[stdout-default]")
-		} else {
-			fmt.Fprint(os.Stderr, err.Error()+"\n")
+	results := report.Results
+
+	if octolintConfig.HistoryFile != "" {
+		historyStore := history.NewJsonHistoryStore(octolintConfig.HistoryFile)
+		if err := historyStore.Record(history.NewRunRecord(octolintConfig.Url, octolintConfig.Space, time.Now(), results)); err != nil {
+			zap.L().Error("Failed to record the scan history in "+octolintConfig.HistoryFile, zap.Error(err))
 		}
-		return nil
-	})
+	}
 
-	if err != nil {
-		return nil, errors.New("Failed to run the checks")
+	if len(octolintConfig.WebhookUrls) != 0 {
+		if _, err := notify.NewWebhookNotifier(octolintConfig).Notify(octolintConfig.Url, octolintConfig.Space, time.Now(), results); err != nil {
+			zap.L().Error("Failed to post the scan results to the webhooks", zap.Error(err))
+		}
+	}
+
+	if octolintConfig.IssueTracker != "" {
+		syncIssues(octolintConfig, results)
+	}
+
+	if octolintConfig.RemediationPlan != "" || octolintConfig.Fix {
+		if err := remediate(octolintConfig, results, s); err != nil {
+			return nil, err
+		}
 	}
 
 	return results, nil
 }
 
-func createClient(httpClient *http.Client, octolintConfig *config.OctolintConfig) (*client.Client, error) {
+// onlineArguments returns the arguments that were set but are only supported when scanning an Octopus space.
+func onlineArguments(octolintConfig *config.OctolintConfig) []string {
+	arguments := []string{}
 
-	parsedUrl, err := getHost(octolintConfig)
+	if octolintConfig.HistoryFile != "" {
+		arguments = append(arguments, "-historyFile")
+	}
 
-	if err != nil {
-		return nil, err
+	if len(octolintConfig.WebhookUrls) != 0 {
+		arguments = append(arguments, "-webhookUrls")
 	}
 
-	if octolintConfig.ApiKey != "" {
-		return createClientApiKey(httpClient, parsedUrl, octolintConfig.Space, octolintConfig.ApiKey)
+	if octolintConfig.IssueTracker != "" {
+		arguments = append(arguments, "-issueTracker")
 	}
 
-	return createClientAccessToken(httpClient, parsedUrl, octolintConfig.Space, octolintConfig.AccessToken)
+	if octolintConfig.RemediationPlan != "" {
+		arguments = append(arguments, "-remediationPlan")
+	}
+
+	if octolintConfig.Fix {
+		arguments = append(arguments, "-fix")
+	}
+
+	return arguments
 }
 
-func getHost(octolintConfig *config.OctolintConfig) (*url.URL, error) {
-	if octolintConfig.UseRedirector {
-		return url.Parse("https://" + octolintConfig.RedirectorHost)
+// syncIssues updates the issue tracker with the results of the scan. Failures are logged rather than returned, so
+// the report is still printed when the tracker is unavailable.
+func syncIssues(octolintConfig *config.OctolintConfig, results []checks.OctopusCheckResult) {
+	tracker, err := issues.NewTracker(octolintConfig, nil)
+
+	if err != nil {
+		zap.L().Error("Failed to create the issue tracker client", zap.Error(err))
+		return
+	}
+
+	syncResult, err := issues.NewIssueSync(octolintConfig, tracker).Sync(octolintConfig.Url, octolintConfig.Space, results)
+
+	if err != nil {
+		zap.L().Error("Failed to sync the scan results to the issue tracker", zap.Error(err))
 	}
 
-	return url.Parse(octolintConfig.Url)
+	// Write to stderr so machine-readable reports written to stdout are not affected
+	fmt.Fprintln(os.Stderr, "Issue sync created "+fmt.Sprint(len(syncResult.Created))+", updated "+fmt.Sprint(len(syncResult.Updated))+
+		", reopened "+fmt.Sprint(len(syncResult.Reopened))+" and closed "+fmt.Sprint(len(syncResult.Closed))+" issues")
 }
 
-func createHttpClient(octolintConfig *config.OctolintConfig) (*http.Client, error) {
-	if octolintConfig.UseRedirector {
-		parsedUrl, err := url.Parse(octolintConfig.Url)
+// remediate plans the changes that fix the findings, and saves them to the remediation plan or applies them.
+func remediate(octolintConfig *config.OctolintConfig, results []checks.OctopusCheckResult, s *spinner.Spinner) error {
+	httpClient, err := octolint.NewHttpClient(context.Background(), octolintConfig)
 
-		if err != nil {
-			return nil, err
+	if err != nil {
+		return errors.New("Failed to create the HTTP client. Check that the url is correct.\nThe error was: " + err.Error())
+	}
+
+	client, err := octolint.NewClient(httpClient, octolintConfig)
+
+	if err != nil {
+		return errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+	}
+
+	changes := remediation.PlanRemediations(remediation.BuildAllRemediators(client, octolintConfig), results)
+
+	if octolintConfig.RemediationPlan != "" {
+		if err := remediation.WritePlan(octolintConfig.RemediationPlan, changes); err != nil {
+			zap.L().Error("Failed to write the remediation plan to "+octolintConfig.RemediationPlan, zap.Error(err))
+		} else {
+			fmt.Fprintln(os.Stderr, "Saved "+fmt.Sprint(len(changes))+" proposed changes to "+octolintConfig.RemediationPlan)
 		}
+	}
 
-		headers := map[string]string{
-			"X_REDIRECTION_UPSTREAM_HOST":   parsedUrl.Hostname(),
-			"X_REDIRECTION_REDIRECTIONS":    octolintConfig.RedirectorRedirections,
-			"X_REDIRECTION_API_KEY":         octolintConfig.RedirecrtorApiKey,
-			"X_REDIRECTION_SERVICE_API_KEY": octolintConfig.RedirectorServiceApiKey,
+	if octolintConfig.Fix {
+		// The spinner would overwrite the confirmation prompt
+		if octolintConfig.Spinner && !octolintConfig.Verbose {
+			s.Stop()
 		}
 
-		return &http.Client{
-			Transport: &client_wrapper.HeaderRoundTripper{
-				Transport: http.DefaultTransport,
-				Headers:   headers,
-			},
-		}, nil
+		fixer := remediation.NewOctopusFixer(httpClient, octolintConfig.Url, octolintConfig.FixApiKey, octolintConfig.UndoLog, octolintConfig.Yes, os.Stdin, os.Stderr)
+		if _, err := fixer.Fix(changes); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to apply the fixes. The previous state of the modified resources is saved in "+octolintConfig.UndoLog+".\nThe error was: "+err.Error())
+		}
 	}
 
-	return &http.Client{}, nil
+	return nil
 }
 
-func createClientApiKey(httpClient *http.Client, apiURL *url.URL, spaceId string, apiKey string) (*client.Client, error) {
-	apiKeyCredential, err := client.NewApiKey(apiKey)
+// RequiredPermissions lists the space permissions required by the checks selected by the skipTests and onlyTests
+// arguments.
+func RequiredPermissions(octolintConfig *config.OctolintConfig) (string, error) {
+	checkCollection, err := factory.NewOctopusCheckFactory(nil, "", "").BuildAllChecks(octolintConfig)
+
 	if err != nil {
-		return nil, err
+		return "", errors.New("Failed to create the checks")
 	}
 
-	return client.NewClientWithCredentials(httpClient, apiURL, apiKeyCredential, spaceId, "")
+	return preflight.GenerateRequiredPermissionsReport(checkCollection), nil
 }
 
-func createClientAccessToken(httpClient *http.Client, apiURL *url.URL, spaceId string, accessToken string) (*client.Client, error) {
-	accessTokenCredential, err := client.NewAccessToken(accessToken)
+// ListChecks returns a table of the checks selected by the onlyTests and skipTests arguments, with the category
+// and a description of each check.
+func ListChecks(octolintConfig *config.OctolintConfig) (string, error) {
+	checkCollection, err := factory.NewOctopusCheckFactory(nil, "", "").BuildAllChecks(octolintConfig)
+
 	if err != nil {
-		return nil, err
+		return "", errors.New("Failed to create the checks")
 	}
-	return client.NewClientWithCredentials(httpClient, apiURL, accessTokenCredential, spaceId, "")
-}
 
-func ErrorExit(message string) {
-	fmt.Println(message)
-	os.Exit(1)
-}
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%-50s %-13s %s\n", "Check", "Category", "Description"))
+
+	for _, description := range factory.CheckDescriptions() {
+		if !lo.ContainsBy(checkCollection, func(check checks.OctopusCheck) bool { return check.Id() == description.Id }) {
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("%-50s %-13s %s\n", description.Id, description.Category, description.Description))
+	}
 
-func lookupSpaceAsName(octopusUrl string, spaceName string, apiKey string, accessToken string) (string, error) {
-	if len(strings.TrimSpace(spaceName)) == 0 {
-		return "", errors.New("space can not be empty")
+	for _, check := range checkCollection {
+		if plugin, ok := check.(plugins.OctopusPluginCheck); ok {
+			builder.WriteString(fmt.Sprintf("%-50s %-13s %s\n", plugin.Id(), "Plugin", "Runs the plugin "+plugin.Path()+"."))
+		}
 	}
 
-	requestURL := fmt.Sprintf("%s/api/Spaces?take=1000&partialName=%s", octopusUrl, url.QueryEscape(spaceName))
+	return builder.String(), nil
+}
+
+// ExplainCheck describes a check, including the permissions it needs, whether it supports incremental scans and OCL
+// files, and the arguments that configure it.
+func ExplainCheck(octolintConfig *config.OctolintConfig, checkId string) (string, error) {
+	description, ok := factory.DescribeCheck(checkId)
+
+	if !ok {
+		return "", errors.New("The check " + checkId + " does not exist. Run \"octolint checks list\" to see the available checks")
+	}
 
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	// Every check is built so checks excluded by the onlyTests and skipTests arguments can still be explained
+	allChecksConfig := *octolintConfig
+	allChecksConfig.OnlyTests = ""
+	allChecksConfig.SkipTests = ""
+	checkCollection, err := factory.NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&allChecksConfig)
 
 	if err != nil {
-		return "", err
+		return "", errors.New("Failed to create the checks")
+	}
+
+	check, _ := lo.Find(checkCollection, func(check checks.OctopusCheck) bool { return check.Id() == checkId })
+
+	builder := strings.Builder{}
+	builder.WriteString(description.Id + "\n")
+	builder.WriteString("Category: " + description.Category + "\n\n")
+	builder.WriteString(description.Description + "\n\n")
+
+	if check != nil {
+		permissions := checks.RequiredPermissions(check)
+		if len(permissions) == 0 {
+			permissions = []string{"None"}
+		}
+		builder.WriteString("Required permissions: " + strings.Join(permissions, ", ") + "\n")
+
+		if resourceType := checks.ScopedResourceType(check); resourceType != "" {
+			builder.WriteString("Incremental scans: only re-run for the " + resourceType + " resources that changed\n")
+		} else {
+			builder.WriteString("Incremental scans: always run in full\n")
+		}
+
+		if _, ok := check.(checks.OctopusOfflineCheck); ok {
+			builder.WriteString("OCL files: supported by the oclDirectory argument\n")
+		} else {
+			builder.WriteString("OCL files: not supported\n")
+		}
+	}
+
+	if arguments := args.CheckArguments(checkId); len(arguments) != 0 {
+		builder.WriteString("\nArguments:\n")
+		for _, argument := range arguments {
+			builder.WriteString("  -" + argument.Name)
+			if argument.DefValue != "" {
+				builder.WriteString(" (default " + argument.DefValue + ")")
+			}
+			builder.WriteString("\n      " + argument.Usage + "\n")
+		}
 	}
 
-	if apiKey != "" {
-		req.Header.Set("X-Octopus-ApiKey", apiKey)
-	} else if accessToken != "" {
-		req.Header.Set("Authorization", "Bearer "+accessToken)
+	builder.WriteString("\nDocumentation: " + reporters.WikiUrl + "/" + url.PathEscape(checkId) + "\n")
+
+	return builder.String(), nil
+}
+
+// Trend builds a report of the findings saved in the history file over time.
+func Trend(octolintConfig *config.OctolintConfig) (string, error) {
+	if octolintConfig.HistoryFile == "" {
+		return "", errors.New("You must specify the history file with the -historyFile argument")
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	runs, err := history.NewJsonHistoryStore(octolintConfig.HistoryFile).Runs()
 
 	if err != nil {
-		return "", err
+		return "", errors.New("Failed to read the history file " + octolintConfig.HistoryFile + ".\nThe error was: " + err.Error())
 	}
 
-	if res.StatusCode != 200 {
-		return "", nil
+	return history.GenerateTrendReport(history.BuildTrends(runs), octolintConfig.TrendFormat)
+}
+
+// ShouldFail returns true if any of the results have a severity equal to or higher than the -failOnSeverity argument.
+func ShouldFail(octolintConfig *config.OctolintConfig, results []checks.OctopusCheckResult) (bool, error) {
+	if octolintConfig.FailOnSeverity == "" {
+		return false, nil
 	}
-	defer res.Body.Close()
 
-	collection := resources.Resources[spaces.Space]{}
-	err = json.NewDecoder(res.Body).Decode(&collection)
+	failOnSeverity, err := checks.ParseSeverity(octolintConfig.FailOnSeverity)
 
 	if err != nil {
-		return "", err
+		return false, err
 	}
 
-	for _, space := range collection.Items {
-		if space.Name == spaceName {
-			return space.ID, nil
+	for _, result := range results {
+		if result != nil && result.Severity() > checks.Ok && result.Severity() >= failOnSeverity {
+			return true, nil
 		}
 	}
 
-	return "", errors.New("did not find space with name " + spaceName)
+	return false, nil
+}
+
+// Diff compares two reports generated with the json report format.
+func Diff(diffConfig *config.DiffConfig) (string, error) {
+	oldReport, err := diff.LoadReport(diffConfig.OldReport)
+
+	if err != nil {
+		return "", errors.New("Failed to read the report " + diffConfig.OldReport + ".\nThe error was: " + err.Error())
+	}
+
+	newReport, err := diff.LoadReport(diffConfig.NewReport)
+
+	if err != nil {
+		return "", errors.New("Failed to read the report " + diffConfig.NewReport + ".\nThe error was: " + err.Error())
+	}
+
+	reportDiff, err := diff.Compare(oldReport, newReport, diffConfig.MatchBy)
+
+	if err != nil {
+		return "", err
+	}
+
+	return diff.GenerateDiffReport(reportDiff, diffConfig.Format)
 }
 
-func createLogger(verbose bool) *zap.Logger {
-	encoderCfg := zap.NewProductionEncoderConfig()
-	encoderCfg.TimeKey = "timestamp"
-	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
-
-	level := zap.InfoLevel
-
-	if verbose {
-		level = zap.DebugLevel
-	}
-
-	zapConfig := zap.Config{
-		Level:             zap.NewAtomicLevelAt(level),
-		Development:       false,
-		DisableCaller:     false,
-		DisableStacktrace: false,
-		Sampling:          nil,
-		Encoding:          "console",
-		EncoderConfig:     encoderCfg,
-		OutputPaths: []string{
-			"stderr",
-		},
-		ErrorOutputPaths: []string{
-			"stderr",
-		},
-		InitialFields: map[string]interface{}{
-			"pid": os.Getpid(),
-		},
-	}
-
-	return zap.Must(zapConfig.Build())
+func ErrorExit(message string) {
+	fmt.Println(message)
+	os.Exit(1)
 }
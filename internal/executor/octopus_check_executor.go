@@ -4,8 +4,13 @@ import (
 	"context"
 
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/mathext"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/tracing"
 	"github.com/avast/retry-go/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -16,10 +21,28 @@ const CheckParallelTasks = 2
 // OctopusCheckExecutor is responsible for running each lint check and returning the results. It deals with things
 // like retries and error handling.
 type OctopusCheckExecutor struct {
+	// ctx holds the span that the span of each check is created under
+	ctx context.Context
+	// checkContext returns the check to run under the span held by the context
+	checkContext func(ctx context.Context, check checks.OctopusCheck) checks.OctopusCheck
 }
 
 func NewOctopusCheckExecutor() OctopusCheckExecutor {
-	return OctopusCheckExecutor{}
+	return OctopusCheckExecutor{ctx: context.Background()}
+}
+
+// WithContext returns an executor that creates the span of each check as a child of the span in the context.
+func (o OctopusCheckExecutor) WithContext(ctx context.Context) OctopusCheckExecutor {
+	o.ctx = ctx
+	return o
+}
+
+// WithCheckContext returns an executor that passes the context holding the span of each check to checkContext, and
+// runs the check that it returns. This allows a check to be built with a client that sends its API requests under
+// the span of the check.
+func (o OctopusCheckExecutor) WithCheckContext(checkContext func(ctx context.Context, check checks.OctopusCheck) checks.OctopusCheck) OctopusCheckExecutor {
+	o.checkContext = checkContext
+	return o
 }
 
 // ExecuteChecks executes each check and collects the results.
@@ -30,18 +53,34 @@ func (o OctopusCheckExecutor) ExecuteChecks(checkCollection []checks.OctopusChec
 
 	checkResults := []checks.OctopusCheckResult{}
 
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	g, _ := errgroup.WithContext(context.Background())
 	g.SetLimit(mathext.TopLevelConcurrency(ParallelTasks, len(checkCollection)))
 
 	for _, c := range checkCollection {
 		c := c
 		g.Go(func() error {
+			checkCtx, span := tracing.Tracer().Start(ctx, c.Id(), trace.WithAttributes(attribute.String(tracing.AttributeCheckId, c.Id())))
+			defer span.End()
+
+			if o.checkContext != nil {
+				c = o.checkContext(checkCtx, c)
+			}
+
+			attempts := 0
 			err := retry.Do(
 				func() error {
+					attempts++
 					result, err := c.Execute(mathext.InternalLevelConcurrency(ParallelTasks, CheckParallelTasks, len(checkCollection)))
 
 					if err != nil {
-						zap.L().Error("Check "+c.Id()+" failed to execute", zap.Error(err), zap.Stack("stacktrace"))
+						span.RecordError(err)
+						span.SetStatus(codes.Error, err.Error())
+						zap.L().Error("Check "+c.Id()+" failed to execute", logging.CheckId(c.Id()), zap.Error(err), zap.Stack("stacktrace"))
 						checkResults = append(
 							checkResults,
 							checks.NewOctopusCheckResultImpl(
@@ -53,12 +92,17 @@ func (o OctopusCheckExecutor) ExecuteChecks(checkCollection []checks.OctopusChec
 					}
 
 					if result != nil {
+						span.SetAttributes(
+							attribute.String(tracing.AttributeCheckSeverity, checks.SeverityName(result.Severity())),
+							attribute.Int(tracing.AttributeCheckFindings, len(result.Findings())))
 						checkResults = append(checkResults, result)
 					}
 
 					return nil
 				}, retry.Attempts(3))
 
+			span.SetAttributes(attribute.Int(tracing.AttributeCheckAttempts, attempts))
+
 			if err != nil {
 				err := handleError(c, err)
 				if err != nil {
@@ -1,8 +1,10 @@
 package executor
 
 import (
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"context"
 	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 )
 
 type alwaysFailCheck struct {
@@ -68,3 +70,28 @@ func TestFailAndPassChecks(t *testing.T) {
 		t.Fatal("Should have returned 2 results")
 	}
 }
+
+func TestCheckContext(t *testing.T) {
+	var checkCtx context.Context
+
+	results, err := OctopusCheckExecutor{}.
+		WithCheckContext(func(ctx context.Context, check checks.OctopusCheck) checks.OctopusCheck {
+			checkCtx = ctx
+			return alwaysPassCheck{}
+		}).
+		ExecuteChecks([]checks.OctopusCheck{alwaysFailCheck{}}, func(check checks.OctopusCheck, err error) error {
+			return nil
+		})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if checkCtx == nil {
+		t.Fatal("Should have passed the context of the check")
+	}
+
+	if len(results) != 1 || results[0].Code() != "OctoRecAlwaysPass" {
+		t.Fatal("Should have run the check returned by the check context function")
+	}
+}
@@ -0,0 +1,133 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+// RunRecord captures the results of a single scan of a space.
+type RunRecord struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Url       string        `json:"url"`
+	Space     string        `json:"space"`
+	Checks    []CheckRecord `json:"checks"`
+}
+
+// CheckRecord captures the result of a single check in a scan.
+type CheckRecord struct {
+	Id       string `json:"id"`
+	Category string `json:"category"`
+	Severity int    `json:"severity"`
+	Findings int    `json:"findings"`
+}
+
+// HistoryStore defines the contract used to persist the results of scans between runs.
+type HistoryStore interface {
+	// Record saves the results of a scan.
+	Record(run RunRecord) error
+	// Runs returns all the saved scans in the order they were recorded.
+	Runs() ([]RunRecord, error)
+}
+
+// NewRunRecord converts the results of a scan into a RunRecord.
+func NewRunRecord(url string, space string, timestamp time.Time, results []checks.OctopusCheckResult) RunRecord {
+	checkRecords := []CheckRecord{}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+
+		checkRecords = append(checkRecords, CheckRecord{
+			Id:       r.Code(),
+			Category: r.Category(),
+			Severity: r.Severity(),
+			Findings: checks.FindingCount(r),
+		})
+	}
+
+	return RunRecord{
+		Timestamp: timestamp.UTC(),
+		Url:       strings.TrimSuffix(url, "/"),
+		Space:     space,
+		Checks:    checkRecords,
+	}
+}
+
+// JsonHistoryStore saves each scan as a line of JSON appended to a file. The file is never rewritten, so it is
+// safe to keep under version control or to ship to a log aggregator.
+type JsonHistoryStore struct {
+	path string
+}
+
+func NewJsonHistoryStore(path string) JsonHistoryStore {
+	return JsonHistoryStore{path: path}
+}
+
+func (o JsonHistoryStore) Record(run RunRecord) error {
+	if strings.TrimSpace(o.path) == "" {
+		return errors.New("the history file path can not be empty")
+	}
+
+	line, err := json.Marshal(run)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(o.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func (o JsonHistoryStore) Runs() ([]RunRecord, error) {
+	file, err := os.Open(o.path)
+
+	if err != nil {
+		// No history file means no runs have been recorded yet
+		if errors.Is(err, os.ErrNotExist) {
+			return []RunRecord{}, nil
+		}
+		return nil, err
+	}
+
+	defer file.Close()
+
+	runs := []RunRecord{}
+	scanner := bufio.NewScanner(file)
+	// Allow for large spaces with many checks recorded on a single line
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		run := RunRecord{}
+		if err := json.Unmarshal([]byte(line), &run); err != nil {
+			return nil, errors.New("failed to parse the history file " + o.path + ": " + err.Error())
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,116 @@
+package history
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+func TestNoHistoryFile(t *testing.T) {
+	runs, err := NewJsonHistoryStore(filepath.Join(t.TempDir(), "missing.jsonl")).Runs()
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(runs) != 0 {
+		t.Fatal("Should not have returned any runs")
+	}
+}
+
+func TestRecordAndReadRuns(t *testing.T) {
+	store := NewJsonHistoryStore(filepath.Join(t.TempDir(), "history.jsonl"))
+
+	failedResult := checks.NewOctopusCheckResultImplWithFindings("This check always fails", "OctoRecAlwaysFail", "", checks.Warning, checks.Organization, []checks.OctopusCheckFinding{
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project 1"},
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-2", ResourceName: "Project 2"},
+	})
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	for i := 0; i < 2; i++ {
+		run := NewRunRecord("https://example.org/", "Spaces-1", time.Now(), []checks.OctopusCheckResult{failedResult, passResult})
+		if err := store.Record(run); err != nil {
+			t.Fatal("Should not have returned an error: " + err.Error())
+		}
+	}
+
+	runs, err := store.Runs()
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(runs) != 2 {
+		t.Fatal("Should have returned 2 runs")
+	}
+
+	if runs[0].Url != "https://example.org" || runs[0].Space != "Spaces-1" {
+		t.Fatal("Should have recorded the url and space")
+	}
+
+	if len(runs[0].Checks) != 2 || runs[0].Checks[0].Findings != 2 || runs[0].Checks[1].Findings != 0 {
+		t.Fatal("Should have recorded the number of findings for each check")
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := []RunRecord{
+		{Timestamp: start.Add(time.Hour), Url: "https://example.org", Space: "Spaces-1", Checks: []CheckRecord{
+			{Id: "OctoLintUnusedVariables", Findings: 5},
+			{Id: "OctoLintEmptyProject", Findings: 3},
+		}},
+		{Timestamp: start, Url: "https://example.org", Space: "Spaces-1", Checks: []CheckRecord{
+			{Id: "OctoLintUnusedVariables", Findings: 4},
+			{Id: "OctoLintEmptyProject", Findings: 3},
+		}},
+		{Timestamp: start, Url: "https://example.org", Space: "Spaces-2", Checks: []CheckRecord{
+			{Id: "OctoLintUnusedVariables", Findings: 1},
+		}},
+	}
+
+	trends := BuildTrends(runs)
+
+	if len(trends) != 2 {
+		t.Fatal("Should have returned a trend for each space")
+	}
+
+	if len(trends[0].Regressions) != 1 || trends[0].Regressions[0].Id != "OctoLintUnusedVariables" {
+		t.Fatal("Should have found one regression")
+	}
+
+	if trends[0].Regressions[0].Previous != 4 || trends[0].Regressions[0].Current != 5 {
+		t.Fatal("Should have compared the last two runs in time order")
+	}
+
+	if len(trends[1].Regressions) != 0 {
+		t.Fatal("A space with a single run should not have any regressions")
+	}
+}
+
+func TestTrendReportFormats(t *testing.T) {
+	runs := []RunRecord{
+		{Timestamp: time.Now(), Url: "https://example.org", Space: "Spaces-1", Checks: []CheckRecord{
+			{Id: "OctoLintUnusedVariables", Category: checks.Organization, Findings: 5},
+		}},
+	}
+
+	for _, format := range []string{TrendFormatText, TrendFormatCsv, TrendFormatJson} {
+		report, err := GenerateTrendReport(BuildTrends(runs), format)
+
+		if err != nil {
+			t.Fatal("Should not have returned an error: " + err.Error())
+		}
+
+		if strings.Index(report, "OctoLintUnusedVariables") == -1 {
+			t.Fatal("The " + format + " report should have included the check")
+		}
+	}
+
+	if _, err := GenerateTrendReport(BuildTrends(runs), "xml"); err == nil {
+		t.Fatal("Should have returned an error for an unsupported format")
+	}
+}
@@ -0,0 +1,128 @@
+package history
+
+import (
+	"sort"
+
+	"golang.org/x/exp/slices"
+)
+
+// SpaceTrend describes the number of findings reported by each check over time for a single space.
+type SpaceTrend struct {
+	Url         string       `json:"url"`
+	Space       string       `json:"space"`
+	Runs        []RunSummary `json:"runs"`
+	Checks      []CheckTrend `json:"checks"`
+	Regressions []Regression `json:"regressions"`
+}
+
+// RunSummary describes when a scan was run.
+type RunSummary struct {
+	Timestamp string `json:"timestamp"`
+}
+
+// CheckTrend holds the number of findings for a check in each run. A nil count means the check was not
+// run as part of that scan.
+type CheckTrend struct {
+	Id       string `json:"id"`
+	Category string `json:"category"`
+	Counts   []*int `json:"counts"`
+}
+
+// Regression describes a check that reported more findings in the latest run than in the previous run.
+type Regression struct {
+	Id       string `json:"id"`
+	Previous int    `json:"previous"`
+	Current  int    `json:"current"`
+}
+
+// BuildTrends groups the recorded runs by space and calculates the findings for each check over time.
+func BuildTrends(runs []RunRecord) []SpaceTrend {
+	spaceRuns := map[string][]RunRecord{}
+	spaceKeys := []string{}
+
+	for _, run := range runs {
+		key := run.Url + "|" + run.Space
+		if _, ok := spaceRuns[key]; !ok {
+			spaceKeys = append(spaceKeys, key)
+		}
+		spaceRuns[key] = append(spaceRuns[key], run)
+	}
+
+	trends := []SpaceTrend{}
+	for _, key := range spaceKeys {
+		trends = append(trends, buildSpaceTrend(spaceRuns[key]))
+	}
+
+	return trends
+}
+
+func buildSpaceTrend(runs []RunRecord) SpaceTrend {
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Timestamp.Before(runs[j].Timestamp)
+	})
+
+	trend := SpaceTrend{
+		Url:         runs[0].Url,
+		Space:       runs[0].Space,
+		Runs:        []RunSummary{},
+		Checks:      []CheckTrend{},
+		Regressions: []Regression{},
+	}
+
+	checkIds := []string{}
+	checkCategories := map[string]string{}
+	for _, run := range runs {
+		for _, c := range run.Checks {
+			if slices.Index(checkIds, c.Id) == -1 {
+				checkIds = append(checkIds, c.Id)
+				checkCategories[c.Id] = c.Category
+			}
+		}
+	}
+	slices.Sort(checkIds)
+
+	for _, run := range runs {
+		trend.Runs = append(trend.Runs, RunSummary{Timestamp: run.Timestamp.Format("2006-01-02T15:04:05Z07:00")})
+	}
+
+	for _, id := range checkIds {
+		checkTrend := CheckTrend{
+			Id:       id,
+			Category: checkCategories[id],
+			Counts:   []*int{},
+		}
+
+		for _, run := range runs {
+			checkTrend.Counts = append(checkTrend.Counts, findingsForCheck(run, id))
+		}
+
+		trend.Checks = append(trend.Checks, checkTrend)
+
+		if len(runs) > 1 {
+			previous := checkTrend.Counts[len(runs)-2]
+			current := checkTrend.Counts[len(runs)-1]
+
+			// Only compare checks that ran in both scans
+			if previous != nil && current != nil && *current > *previous {
+				trend.Regressions = append(trend.Regressions, Regression{
+					Id:       id,
+					Previous: *previous,
+					Current:  *current,
+				})
+			}
+		}
+	}
+
+	return trend
+}
+
+func findingsForCheck(run RunRecord, id string) *int {
+	for _, c := range run.Checks {
+		if c.Id == id {
+			count := c.Findings
+			return &count
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package history
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	TrendFormatText = "text"
+	TrendFormatCsv  = "csv"
+	TrendFormatJson = "json"
+)
+
+// GenerateTrendReport renders the trends in the requested format. The text format is designed to be read in a
+// terminal, while the csv and json formats are designed to be imported into other tools.
+func GenerateTrendReport(trends []SpaceTrend, format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", TrendFormatText:
+		return generateTextTrendReport(trends)
+	case TrendFormatCsv:
+		return generateCsvTrendReport(trends)
+	case TrendFormatJson:
+		report, err := json.MarshalIndent(trends, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(report), nil
+	default:
+		return "", errors.New("the trend format " + format + " is not supported. Supported formats are " +
+			strings.Join([]string{TrendFormatText, TrendFormatCsv, TrendFormatJson}, ", "))
+	}
+}
+
+func generateTextTrendReport(trends []SpaceTrend) (string, error) {
+	if len(trends) == 0 {
+		return "No scan history has been recorded", nil
+	}
+
+	var buffer bytes.Buffer
+
+	for i, trend := range trends {
+		if i != 0 {
+			buffer.WriteString("\n")
+		}
+
+		buffer.WriteString("====================================================================================================\n")
+		buffer.WriteString(trend.Url + " " + trend.Space + "\n\n")
+
+		writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+		header := []string{"Check"}
+		for _, run := range trend.Runs {
+			header = append(header, run.Timestamp)
+		}
+		fmt.Fprintln(writer, strings.Join(header, "\t"))
+
+		for _, check := range trend.Checks {
+			row := []string{check.Id}
+			for _, count := range check.Counts {
+				row = append(row, formatCount(count, "-"))
+			}
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+
+		if err := writer.Flush(); err != nil {
+			return "", err
+		}
+
+		buffer.WriteString("\n")
+
+		if len(trend.Runs) < 2 {
+			buffer.WriteString("At least two scans are required to detect regressions\n")
+		} else if len(trend.Regressions) == 0 {
+			buffer.WriteString("No regressions since the previous scan\n")
+		} else {
+			buffer.WriteString("The following checks regressed since the previous scan:\n")
+			for _, regression := range trend.Regressions {
+				buffer.WriteString(fmt.Sprintf("%s: %d -> %d\n", regression.Id, regression.Previous, regression.Current))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+func generateCsvTrendReport(trends []SpaceTrend) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	if err := writer.Write([]string{"url", "space", "timestamp", "check", "category", "findings"}); err != nil {
+		return "", err
+	}
+
+	for _, trend := range trends {
+		for _, check := range trend.Checks {
+			for i, count := range check.Counts {
+				if count == nil {
+					continue
+				}
+
+				record := []string{trend.Url, trend.Space, trend.Runs[i].Timestamp, check.Id, check.Category, formatCount(count, "")}
+				if err := writer.Write(record); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+func formatCount(count *int, missing string) string {
+	if count == nil {
+		return missing
+	}
+	return fmt.Sprint(*count)
+}
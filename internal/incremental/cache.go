@@ -0,0 +1,168 @@
+package incremental
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+)
+
+// cacheVersion is incremented when the format of the cache changes, so caches saved by older versions are ignored.
+const cacheVersion = 1
+
+// Cache holds the results of the last scan of a space, which incremental scans reuse for the resources that have
+// not changed since.
+type Cache struct {
+	Version int    `json:"version"`
+	Url     string `json:"url"`
+	Space   string `json:"space"`
+	// Settings is a fingerprint of the arguments that affect the findings. Cached results are only reused by scans
+	// with the same settings.
+	Settings string `json:"settings"`
+	// ScanTime is the time the last scan started. Resources modified after this time are scanned again.
+	ScanTime time.Time                   `json:"scanTime"`
+	Results  []reporters.JsonCheckResult `json:"results"`
+}
+
+// NewCache saves the results of a scan that started at scanTime.
+func NewCache(url string, space string, settings string, scanTime time.Time, results []checks.OctopusCheckResult) Cache {
+	cachedResults := []reporters.JsonCheckResult{}
+	for _, r := range results {
+		if r != nil {
+			cachedResults = append(cachedResults, reporters.NewJsonCheckResult(r))
+		}
+	}
+
+	return Cache{
+		Version:  cacheVersion,
+		Url:      strings.TrimSuffix(url, "/"),
+		Space:    space,
+		Settings: settings,
+		ScanTime: scanTime.UTC(),
+		Results:  cachedResults,
+	}
+}
+
+// LoadCache reads the cache saved by the last scan. A nil cache is returned if the file does not exist.
+func LoadCache(path string) (*Cache, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cache := Cache{}
+	if err := json.Unmarshal(content, &cache); err != nil {
+		return nil, errors.New("the file " + path + " is not an incremental scan cache: " + err.Error())
+	}
+
+	return &cache, nil
+}
+
+// Save writes the cache to a file, replacing the cache of the previous scan.
+func (o Cache) Save(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("the incremental cache file path can not be empty")
+	}
+
+	content, err := json.Marshal(o)
+
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so an interrupted scan does not leave a truncated cache behind
+	if err := os.WriteFile(path+".tmp", content, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// Matches returns true if the cache was saved by a scan of the same space with the same settings.
+func (o *Cache) Matches(url string, space string, settings string) bool {
+	return o != nil &&
+		o.Version == cacheVersion &&
+		o.Url == strings.TrimSuffix(url, "/") &&
+		o.Space == space &&
+		o.Settings == settings
+}
+
+// Result returns the cached result of a check, or nil if the check was not run by the last scan.
+func (o *Cache) Result(code string) *reporters.JsonCheckResult {
+	if o == nil {
+		return nil
+	}
+
+	for i := range o.Results {
+		if o.Results[i].Code == code {
+			return &o.Results[i]
+		}
+	}
+
+	return nil
+}
+
+// Settings returns a fingerprint of the version of octolint and the arguments that affect the findings of a scan,
+// like the regular expressions and resource limits used by the checks. Credentials and the arguments that only
+// change how the report is displayed are ignored.
+func Settings(octolintConfig *config.OctolintConfig, version string) (string, error) {
+	settings := *octolintConfig
+
+	settings.Help = false
+	settings.ApiKey = ""
+	settings.AccessToken = ""
+	settings.VerboseErrors = false
+	settings.Version = false
+	settings.Spinner = false
+	settings.ConfigFile = ""
+	settings.ConfigPath = ""
+	settings.Verbose = false
+	settings.ReportFormat = ""
+	settings.PrintRequiredPermissions = false
+	settings.PageSize = 0
+	settings.OctopusArtifactFile = ""
+	settings.FailOnSeverity = ""
+	settings.RemediationPlan = ""
+	settings.RemediationRetentionQuantity = 0
+	settings.RemediationRetentionUnit = ""
+	settings.Fix = false
+	settings.FixApiKey = ""
+	settings.UndoLog = ""
+	settings.Yes = false
+	settings.MarkdownMaxLength = 0
+	settings.ScoreWeightSecurity = 0
+	settings.ScoreWeightOrganization = 0
+	settings.ScoreWeightNaming = 0
+	settings.ScoreWeightPerformance = 0
+	settings.ScoreWeightError = 0
+	settings.ScoreWeightWarning = 0
+	settings.ScoreWeightInfo = 0
+	settings.RedirectorServiceApiKey = ""
+	settings.RedirecrtorApiKey = ""
+	settings.HistoryFile = ""
+	settings.Trend = false
+	settings.TrendFormat = ""
+	settings.Incremental = false
+	settings.IncrementalCache = ""
+	settings.Scope = nil
+
+	content, err := json.Marshal(settings)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(append([]byte(version+"\n"), content...))
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,94 @@
+package incremental
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// eventOverlap is subtracted from the time of the last scan when reading events, to allow for differences between
+// the clocks of the machine running octolint and the Octopus server. Reading an event twice only means the resource
+// is inspected again.
+const eventOverlap = 5 * time.Minute
+
+// documentId matches the IDs of the scoped resources. Events relate to documents like "variableset-Projects-1" and
+// "deploymentprocess-Projects-1", so IDs are also found inside the IDs of the documents owned by a resource.
+var documentId = regexp.MustCompile(`\b(Projects|Machines|Tenants|LibraryVariableSets)-\d+\b`)
+
+// FindChanges reads the events recorded since the last scan to find the projects, targets, tenants, and library
+// variable sets that were created, modified, or deleted. Projects that include a changed library variable set are
+// also in scope, as are version controlled projects, because changes committed to git do not create events.
+func FindChanges(client newclient.Client, spaceID string, since time.Time, pageSize int) (*config.ResourceScope, error) {
+	pager := client_wrapper.NewEventPager(
+		client,
+		spaceID,
+		url.Values{
+			"eventCategories": []string{"Created,Modified,Deleted"},
+			"from":            []string{since.Add(-eventOverlap).Format("2006-01-02T15:04:05-0700")},
+		},
+		pageSize,
+		0)
+
+	scope := &config.ResourceScope{}
+
+	for event, err := range pager.Items() {
+		if err != nil {
+			return nil, err
+		}
+
+		for _, relatedId := range event.RelatedDocumentIds {
+			for _, id := range documentId.FindAllString(relatedId, -1) {
+				addChange(scope, id)
+			}
+		}
+	}
+
+	projects, _, err := client_wrapper.GetProjects(0, pageSize, client, spaceID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if project.IsVersionControlled {
+			addChange(scope, project.ID)
+			continue
+		}
+
+		for _, libraryVariableSetId := range project.IncludedLibraryVariableSets {
+			if slices.Contains(scope.LibraryVariableSetIds, libraryVariableSetId) {
+				addChange(scope, project.ID)
+				break
+			}
+		}
+	}
+
+	return scope, nil
+}
+
+func addChange(scope *config.ResourceScope, id string) {
+	var ids *[]string
+
+	switch {
+	case strings.HasPrefix(id, "Projects-"):
+		ids = &scope.ProjectIds
+	case strings.HasPrefix(id, "Machines-"):
+		ids = &scope.TargetIds
+	case strings.HasPrefix(id, "Tenants-"):
+		ids = &scope.TenantIds
+	case strings.HasPrefix(id, "LibraryVariableSets-"):
+		ids = &scope.LibraryVariableSetIds
+	default:
+		return
+	}
+
+	if !slices.Contains(*ids, id) {
+		*ids = append(*ids, id)
+	}
+}
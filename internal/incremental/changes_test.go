@@ -0,0 +1,67 @@
+package incremental
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+)
+
+func TestFindChanges(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	var from string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := []map[string]any{}
+
+		switch r.URL.Path {
+		case "/api/Spaces-1/events":
+			from = r.URL.Query().Get("from")
+			items = []map[string]any{
+				{"RelatedDocumentIds": []string{"Projects-1", "variableset-Projects-2", "Spaces-1"}},
+				{"RelatedDocumentIds": []string{"Machines-1", "Environments-1"}},
+				{"RelatedDocumentIds": []string{"Tenants-1", "Projects-1"}},
+				{"RelatedDocumentIds": []string{"LibraryVariableSets-1"}},
+			}
+		case "/api/Spaces-1/projects":
+			items = []map[string]any{
+				{"Id": "Projects-1", "Name": "Web", "LifecycleId": "Lifecycles-1", "ProjectGroupId": "ProjectGroups-1"},
+				{"Id": "Projects-3", "Name": "Api", "LifecycleId": "Lifecycles-1", "ProjectGroupId": "ProjectGroups-1", "IncludedLibraryVariableSetIds": []string{"LibraryVariableSets-1"}},
+				{"Id": "Projects-4", "Name": "CaC", "LifecycleId": "Lifecycles-1", "ProjectGroupId": "ProjectGroups-1", "IsVersionControlled": true},
+				{"Id": "Projects-5", "Name": "Worker", "LifecycleId": "Lifecycles-1", "ProjectGroupId": "ProjectGroups-1", "IncludedLibraryVariableSetIds": []string{"LibraryVariableSets-2"}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalResults": len(items)})
+	}))
+	defer server.Close()
+
+	baseUrl, _ := url.Parse(server.URL)
+	client := newclient.NewClientS(&newclient.HttpSession{HttpClient: server.Client(), BaseURL: baseUrl}, "Spaces-1")
+
+	scope, err := FindChanges(client, "Spaces-1", since, 0)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !slices.Equal(scope.ProjectIds, []string{"Projects-1", "Projects-2", "Projects-3", "Projects-4"}) {
+		t.Fatal("Should have found the changed projects, the projects that include a changed library variable set, and the version controlled projects")
+	}
+
+	if !slices.Equal(scope.TargetIds, []string{"Machines-1"}) ||
+		!slices.Equal(scope.TenantIds, []string{"Tenants-1"}) ||
+		!slices.Equal(scope.LibraryVariableSetIds, []string{"LibraryVariableSets-1"}) {
+		t.Fatal("Should have found the changed targets, tenants, and library variable sets")
+	}
+
+	if parsed, err := time.Parse("2006-01-02T15:04:05-0700", from); err != nil || !parsed.Before(since) {
+		t.Fatal("Should have read the events from before the last scan")
+	}
+}
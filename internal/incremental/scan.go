@@ -0,0 +1,200 @@
+package incremental
+
+import (
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+)
+
+// Scan combines the results of the checks run by an incremental scan with the results cached by the last scan. The
+// zero value is a full scan, which runs every check and returns the results unchanged.
+type Scan struct {
+	cache *Cache
+	scope *config.ResourceScope
+	// scoped maps the ID of each check limited to the changed resources to the type of resource it inspects
+	scoped map[string]string
+	// reused lists the checks that were not run because none of the resources they inspect changed
+	reused []string
+}
+
+func NewScan(cache *Cache, scope *config.ResourceScope) *Scan {
+	return &Scan{cache: cache, scope: scope, scoped: map[string]string{}}
+}
+
+// Checks returns the checks to run. A check that supports incremental scans is replaced by the matching check from
+// scopedChecks, which were built with the scope of changed resources, if the last scan saved a result for it. The
+// check is not run at all if none of the resources it inspects changed. All other checks are run in full.
+func (o *Scan) Checks(fullChecks []checks.OctopusCheck, scopedChecks []checks.OctopusCheck) []checks.OctopusCheck {
+	scopedChecksById := map[string]checks.OctopusCheck{}
+	for _, check := range scopedChecks {
+		scopedChecksById[check.Id()] = check
+	}
+
+	runnable := []checks.OctopusCheck{}
+	for _, check := range fullChecks {
+		resourceType := checks.ScopedResourceType(check)
+		scopedCheck, found := scopedChecksById[check.Id()]
+
+		if resourceType == "" || !found || !mergeable(o.cache.Result(check.Id())) {
+			runnable = append(runnable, check)
+			continue
+		}
+
+		if !o.changed(resourceType) {
+			o.reused = append(o.reused, check.Id())
+			continue
+		}
+
+		o.scoped[check.Id()] = resourceType
+		runnable = append(runnable, scopedCheck)
+	}
+
+	return runnable
+}
+
+// CheckConfig returns the config that the check returned by Checks was built with, which is limited to the scope of
+// changed resources if the check is scoped.
+func (o *Scan) CheckConfig(checkId string, octolintConfig *config.OctolintConfig) *config.OctolintConfig {
+	if o == nil {
+		return octolintConfig
+	}
+
+	if _, scoped := o.scoped[checkId]; !scoped {
+		return octolintConfig
+	}
+
+	scopedConfig := *octolintConfig
+	scopedConfig.Scope = o.scope
+	return &scopedConfig
+}
+
+// Merge returns the results of a scan. The results of scoped checks are merged with the cached findings of the
+// resources that did not change, and the cached results of the checks that were not run are added.
+func (o *Scan) Merge(results []checks.OctopusCheckResult) []checks.OctopusCheckResult {
+	if o == nil || o.cache == nil {
+		return results
+	}
+
+	merged := []checks.OctopusCheckResult{}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		resourceType, scoped := o.scoped[result.Code()]
+
+		if !scoped || result.Severity() == checks.Permission {
+			merged = append(merged, result)
+			continue
+		}
+
+		merged = append(merged, mergeResult(*o.cache.Result(result.Code()), result, resourceType, o.scope))
+	}
+
+	for _, code := range o.reused {
+		merged = append(merged, o.cache.Result(code).ToResult())
+	}
+
+	return merged
+}
+
+// ScopedChecks returns the number of checks that were limited to the changed resources, and the number of checks
+// whose cached results were reused without running the check.
+func (o *Scan) ScopedChecks() (int, int) {
+	if o == nil {
+		return 0, 0
+	}
+
+	return len(o.scoped), len(o.reused)
+}
+
+// changed returns true if any resources of the type changed since the last scan.
+func (o *Scan) changed(resourceType string) bool {
+	switch resourceType {
+	case checks.ResourceTypeProject:
+		return len(o.scope.ProjectIds) != 0
+	case checks.ResourceTypeTarget:
+		return len(o.scope.TargetIds) != 0
+	case checks.ResourceTypeTenant:
+		return len(o.scope.TenantIds) != 0
+	case checks.ResourceTypeLibraryVariableSet:
+		return len(o.scope.LibraryVariableSetIds) != 0
+	}
+
+	return true
+}
+
+// mergeable returns true if the cached result can be merged with the result of a scoped check. A check that could
+// not be run by the last scan has no findings to reuse.
+func mergeable(cached *reporters.JsonCheckResult) bool {
+	return cached != nil && cached.Severity != checks.Permission
+}
+
+// mergeResult replaces the cached findings of the changed resources with the findings of the scoped check. The
+// description is rebuilt from the first line of the failing result, which all the scoped checks use to introduce
+// the list of findings. The coverage of the cached result is kept, as the scoped check only inspected the changed
+// resources.
+func mergeResult(cached reporters.JsonCheckResult, result checks.OctopusCheckResult, resourceType string, scope *config.ResourceScope) checks.OctopusCheckResult {
+	cachedResult := cached.ToResult()
+
+	findings := []checks.OctopusCheckFinding{}
+	for _, finding := range cachedResult.Findings() {
+		if !inScope(scope, resourceType, owner(finding, resourceType)) {
+			findings = append(findings, finding)
+		}
+	}
+	findings = append(findings, result.Findings()...)
+
+	coverage := checks.Coverage(cachedResult)
+
+	if len(findings) == 0 {
+		if result.Severity() != checks.Ok {
+			return result
+		}
+
+		return checks.NewOctopusCheckResultImpl(result.Description(), result.Code(), result.Link(), checks.Ok, result.Category()).
+			WithCoverage(coverage...)
+	}
+
+	var failing checks.OctopusCheckResult = result
+	if result.Severity() == checks.Ok {
+		failing = cachedResult
+	}
+
+	header, _, _ := strings.Cut(failing.Description(), "\n")
+
+	return checks.NewOctopusCheckResultImplWithFindings(
+		header+"\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
+		result.Code(),
+		result.Link(),
+		failing.Severity(),
+		result.Category(),
+		findings).WithCoverage(coverage...)
+}
+
+// owner returns the ID of the scoped resource a finding relates to. Findings like variables and steps are owned by
+// the project recorded as their parent.
+func owner(finding checks.OctopusCheckFinding, resourceType string) string {
+	if finding.ResourceType == resourceType {
+		return finding.ResourceId
+	}
+
+	return finding.ParentId
+}
+
+func inScope(scope *config.ResourceScope, resourceType string, id string) bool {
+	switch resourceType {
+	case checks.ResourceTypeProject:
+		return scope.IncludesProject(id)
+	case checks.ResourceTypeTarget:
+		return scope.IncludesTarget(id)
+	case checks.ResourceTypeTenant:
+		return scope.IncludesTenant(id)
+	case checks.ResourceTypeLibraryVariableSet:
+		return scope.IncludesLibraryVariableSet(id)
+	}
+
+	return true
+}
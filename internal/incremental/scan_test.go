@@ -0,0 +1,225 @@
+package incremental
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+type scopedCheck struct {
+	id           string
+	resourceType string
+	scope        *config.ResourceScope
+}
+
+func (o scopedCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+	return nil, nil
+}
+
+func (o scopedCheck) Id() string {
+	return o.id
+}
+
+func (o scopedCheck) ScopedResourceType() string {
+	return o.resourceType
+}
+
+func variableFinding(projectId string, variable string) checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{
+		ResourceType: checks.ResourceTypeVariable,
+		ResourceId:   "Variables-" + variable,
+		ResourceName: variable,
+		ParentId:     projectId,
+		ParentName:   projectId,
+		Description:  projectId + ": " + variable,
+	}
+}
+
+func failingResult(code string, findings ...checks.OctopusCheckFinding) checks.OctopusCheckResult {
+	return checks.NewOctopusCheckResultImplWithFindings(
+		"The following variables are invalid:\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
+		code,
+		"",
+		checks.Warning,
+		checks.Naming,
+		findings).WithCoverage(checks.NewFullResourceCoverage(checks.ResourceTypeProject, 3))
+}
+
+func TestMergeReplacesChangedFindings(t *testing.T) {
+	cache := NewCache("https://example.octopus.app/", "Spaces-1", "settings", time.Now(), []checks.OctopusCheckResult{
+		failingResult("OctoLintVariables", variableFinding("Projects-1", "old"), variableFinding("Projects-2", "unchanged")),
+	})
+	scope := &config.ResourceScope{ProjectIds: []string{"Projects-1", "Projects-3"}}
+
+	scan := NewScan(&cache, scope)
+	scan.Checks(
+		[]checks.OctopusCheck{scopedCheck{id: "OctoLintVariables", resourceType: checks.ResourceTypeProject}},
+		[]checks.OctopusCheck{scopedCheck{id: "OctoLintVariables", resourceType: checks.ResourceTypeProject, scope: scope}})
+
+	results := scan.Merge([]checks.OctopusCheckResult{
+		failingResult("OctoLintVariables", variableFinding("Projects-1", "new"), variableFinding("Projects-3", "created")),
+	})
+
+	if len(results) != 1 || len(results[0].Findings()) != 3 {
+		t.Fatal("Should have merged the findings of the changed and unchanged projects")
+	}
+
+	for _, expected := range []string{"Projects-2: unchanged", "Projects-1: new", "Projects-3: created"} {
+		if !strings.Contains(results[0].Description(), expected) {
+			t.Fatal("Should have described the finding " + expected + ", got " + results[0].Description())
+		}
+	}
+
+	if strings.Contains(results[0].Description(), "Projects-1: old") {
+		t.Fatal("Should have removed the cached findings of the changed project")
+	}
+
+	if !strings.HasPrefix(results[0].Description(), "The following variables are invalid:\n") {
+		t.Fatal("Should have kept the first line of the description")
+	}
+
+	if coverage := checks.Coverage(results[0]); len(coverage) != 1 || coverage[0].Scanned != 3 {
+		t.Fatal("Should have kept the coverage of the cached result")
+	}
+}
+
+func TestMergeFixedFindings(t *testing.T) {
+	cache := NewCache("https://example.octopus.app", "Spaces-1", "settings", time.Now(), []checks.OctopusCheckResult{
+		failingResult("OctoLintVariables", variableFinding("Projects-1", "old")),
+	})
+
+	scan := NewScan(&cache, &config.ResourceScope{ProjectIds: []string{"Projects-1"}})
+	scan.Checks(
+		[]checks.OctopusCheck{scopedCheck{id: "OctoLintVariables", resourceType: checks.ResourceTypeProject}},
+		[]checks.OctopusCheck{scopedCheck{id: "OctoLintVariables", resourceType: checks.ResourceTypeProject}})
+
+	results := scan.Merge([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("All variables are valid", "OctoLintVariables", "", checks.Ok, checks.Naming),
+	})
+
+	if len(results) != 1 || results[0].Severity() != checks.Ok || results[0].Description() != "All variables are valid" {
+		t.Fatal("Should have passed once the findings of the changed project were fixed")
+	}
+}
+
+func TestChecksSelection(t *testing.T) {
+	cache := NewCache("https://example.octopus.app", "Spaces-1", "settings", time.Now(), []checks.OctopusCheckResult{
+		failingResult("OctoLintProjects", variableFinding("Projects-1", "old")),
+		failingResult("OctoLintTargets"),
+		checks.NewOctopusCheckResultImpl("No permission", "OctoLintNoPermission", "", checks.Permission, checks.Naming),
+	})
+
+	scope := &config.ResourceScope{ProjectIds: []string{"Projects-1"}}
+	scan := NewScan(&cache, scope)
+
+	runnable := scan.Checks(
+		[]checks.OctopusCheck{
+			scopedCheck{id: "OctoLintProjects", resourceType: checks.ResourceTypeProject},
+			scopedCheck{id: "OctoLintTargets", resourceType: checks.ResourceTypeTarget},
+			scopedCheck{id: "OctoLintNoPermission", resourceType: checks.ResourceTypeProject},
+			scopedCheck{id: "OctoLintSpace"},
+			scopedCheck{id: "OctoLintNew", resourceType: checks.ResourceTypeProject},
+		},
+		[]checks.OctopusCheck{
+			scopedCheck{id: "OctoLintProjects", resourceType: checks.ResourceTypeProject, scope: scope},
+			scopedCheck{id: "OctoLintTargets", resourceType: checks.ResourceTypeTarget, scope: scope},
+			scopedCheck{id: "OctoLintNoPermission", resourceType: checks.ResourceTypeProject, scope: scope},
+			scopedCheck{id: "OctoLintSpace", scope: scope},
+			scopedCheck{id: "OctoLintNew", resourceType: checks.ResourceTypeProject, scope: scope},
+		})
+
+	if len(runnable) != 4 {
+		t.Fatal("Should not have run the check whose resources did not change")
+	}
+
+	if runnable[0].(scopedCheck).scope != scope {
+		t.Fatal("Should have limited the project check to the changed projects")
+	}
+
+	for _, check := range runnable[1:] {
+		if check.(scopedCheck).scope != nil {
+			t.Fatal("Should have run " + check.Id() + " in full")
+		}
+	}
+
+	results := scan.Merge([]checks.OctopusCheckResult{})
+
+	if len(results) != 1 || results[0].Code() != "OctoLintTargets" || results[0].Severity() != checks.Warning {
+		t.Fatal("Should have reused the cached result of the check that was not run")
+	}
+
+	if scoped, reused := scan.ScopedChecks(); scoped != 1 || reused != 1 {
+		t.Fatal("Should have counted the scoped and reused checks")
+	}
+
+	fullConfig := &config.OctolintConfig{Space: "Spaces-1"}
+
+	if scan.CheckConfig("OctoLintProjects", fullConfig).Scope != scope || fullConfig.Scope != nil {
+		t.Fatal("Should have returned a copy of the config limited to the changed resources for the scoped check")
+	}
+
+	if scan.CheckConfig("OctoLintSpace", fullConfig) != fullConfig {
+		t.Fatal("Should have returned the full config for the check run in full")
+	}
+}
+
+func TestFullScanMerge(t *testing.T) {
+	var scan *Scan
+	results := []checks.OctopusCheckResult{failingResult("OctoLintVariables", variableFinding("Projects-1", "old"))}
+
+	if len(scan.Merge(results)) != 1 {
+		t.Fatal("Should have returned the results of a full scan unchanged")
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	if cache, err := LoadCache(path); err != nil || cache != nil {
+		t.Fatal("Should have returned a nil cache if the file does not exist")
+	}
+
+	scanTime := time.Now()
+	if err := NewCache("https://example.octopus.app/", "Spaces-1", "settings", scanTime, []checks.OctopusCheckResult{
+		failingResult("OctoLintVariables", variableFinding("Projects-1", "old")),
+	}).Save(path); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	cache, err := LoadCache(path)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !cache.Matches("https://example.octopus.app", "Spaces-1", "settings") {
+		t.Fatal("Should have matched the space and settings")
+	}
+
+	if cache.Matches("https://example.octopus.app", "Spaces-2", "settings") || cache.Matches("https://example.octopus.app", "Spaces-1", "other") {
+		t.Fatal("Should not have matched another space or other settings")
+	}
+
+	if !cache.ScanTime.Equal(scanTime) || len(cache.Result("OctoLintVariables").Findings) != 1 {
+		t.Fatal("Should have saved the scan time and results")
+	}
+}
+
+func TestSettings(t *testing.T) {
+	settings, _ := Settings(&config.OctolintConfig{ApiKey: "API-1", VariableNameRegex: "^[A-Z]"}, "1.0.0")
+	otherKey, _ := Settings(&config.OctolintConfig{ApiKey: "API-2", ReportFormat: "json", VariableNameRegex: "^[A-Z]"}, "1.0.0")
+	otherRegex, _ := Settings(&config.OctolintConfig{ApiKey: "API-1", VariableNameRegex: "^[a-z]"}, "1.0.0")
+	otherVersion, _ := Settings(&config.OctolintConfig{ApiKey: "API-1", VariableNameRegex: "^[A-Z]"}, "1.0.1")
+
+	if settings != otherKey {
+		t.Fatal("Should have ignored the API key and report format")
+	}
+
+	if settings == otherRegex || settings == otherVersion {
+		t.Fatal("Should have included the check settings and version")
+	}
+}
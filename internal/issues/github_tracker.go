@@ -0,0 +1,111 @@
+package issues
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// githubPageSize is the number of issues requested at a time, which is the maximum allowed by GitHub.
+const githubPageSize = 100
+
+// GithubTracker manages the issues of a GitHub repository with the REST API.
+type GithubTracker struct {
+	api        restClient
+	repository string
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+type githubIssue struct {
+	Number int           `json:"number"`
+	Title  string        `json:"title"`
+	Body   string        `json:"body"`
+	State  string        `json:"state"`
+	Labels []githubLabel `json:"labels"`
+	// PullRequest is set when the issue is a pull request, which are returned by the issues API
+	PullRequest map[string]any `json:"pull_request,omitempty"`
+}
+
+func (o GithubTracker) issuesPath() string {
+	return "/repos/" + o.repository + "/issues"
+}
+
+func (o GithubTracker) Issues(labels []string) ([]Issue, error) {
+	o.api.accept = "application/vnd.github+json"
+	issues := []Issue{}
+
+	for page := 1; ; page++ {
+		query := url.Values{
+			"labels":   []string{strings.Join(labels, ",")},
+			"state":    []string{"all"},
+			"per_page": []string{fmt.Sprint(githubPageSize)},
+			"page":     []string{fmt.Sprint(page)},
+		}
+
+		githubIssues := []githubIssue{}
+		if err := o.api.send(http.MethodGet, o.issuesPath()+"?"+query.Encode(), nil, &githubIssues); err != nil {
+			return nil, err
+		}
+
+		for _, githubIssue := range githubIssues {
+			if githubIssue.PullRequest != nil {
+				continue
+			}
+
+			issues = append(issues, Issue{
+				Id:     fmt.Sprint(githubIssue.Number),
+				Title:  githubIssue.Title,
+				Body:   githubIssue.Body,
+				Labels: lo.Map(githubIssue.Labels, func(label githubLabel, index int) string { return label.Name }),
+				Open:   githubIssue.State == "open",
+			})
+		}
+
+		if len(githubIssues) < githubPageSize {
+			return issues, nil
+		}
+	}
+}
+
+func (o GithubTracker) Create(issue Issue) (string, error) {
+	o.api.accept = "application/vnd.github+json"
+	created := githubIssue{}
+
+	err := o.api.send(http.MethodPost, o.issuesPath(), map[string]any{
+		"title":  issue.Title,
+		"body":   issue.Body,
+		"labels": issue.Labels,
+	}, &created)
+
+	return fmt.Sprint(created.Number), err
+}
+
+func (o GithubTracker) Update(issue Issue) error {
+	o.api.accept = "application/vnd.github+json"
+
+	return o.api.send(http.MethodPatch, o.issuesPath()+"/"+issue.Id, map[string]any{
+		"title": issue.Title,
+		"body":  issue.Body,
+	}, nil)
+}
+
+func (o GithubTracker) SetOpen(issue Issue, open bool) error {
+	o.api.accept = "application/vnd.github+json"
+
+	state := map[string]any{"state": "open"}
+	if !open {
+		state = map[string]any{"state": "closed", "state_reason": "completed"}
+	}
+
+	return o.api.send(http.MethodPatch, o.issuesPath()+"/"+issue.Id, state, nil)
+}
+
+func (o GithubTracker) Markup() Markup {
+	return MarkdownMarkup
+}
@@ -0,0 +1,362 @@
+package issues
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+)
+
+const (
+	GroupByCheck   = "check"
+	GroupByProject = "project"
+)
+
+// Groupings are the supported values of the issueGrouping argument.
+var Groupings = []string{GroupByCheck, GroupByProject}
+
+// ManagedLabel is added to every issue opened by octolint.
+const ManagedLabel = "octolint"
+
+// IssueSync opens an issue for each failed check, or for each project with findings, updates the issue when the
+// findings change, and closes the issue when the findings are resolved.
+//
+// Issues are matched to findings with a fingerprint label, which is a hash of the Octopus URL, the space and the
+// check or project. Issues are also labelled with the space and the grouping, so the issues of other spaces, and
+// issues opened with the other grouping, are left alone.
+type IssueSync struct {
+	config  *config.OctolintConfig
+	tracker Tracker
+}
+
+// SyncResult lists the IDs of the issues changed by a sync.
+type SyncResult struct {
+	Created  []string
+	Updated  []string
+	Reopened []string
+	Closed   []string
+}
+
+// wantedIssue is an issue that should be open.
+type wantedIssue struct {
+	fingerprint string
+	title       string
+	body        string
+}
+
+func NewIssueSync(config *config.OctolintConfig, tracker Tracker) IssueSync {
+	return IssueSync{config: config, tracker: tracker}
+}
+
+// Validate returns an error if the issue tracker arguments are invalid, so the mistake is reported before the scan
+// starts.
+func Validate(octolintConfig *config.OctolintConfig) error {
+	switch octolintConfig.IssueTracker {
+	case "":
+		return nil
+	case TrackerGithub:
+		if octolintConfig.IssueRepository == "" {
+			return errors.New("The -issueRepository argument must be set when -issueTracker is " + TrackerGithub)
+		}
+	case TrackerJira:
+		if octolintConfig.IssueProject == "" {
+			return errors.New("The -issueProject argument must be set when -issueTracker is " + TrackerJira)
+		}
+	default:
+		return errors.New("The -issueTracker argument must be " + TrackerGithub + " or " + TrackerJira + ", but was \"" + octolintConfig.IssueTracker + "\"")
+	}
+
+	if octolintConfig.IssueUrl == "" {
+		return errors.New("The -issueUrl argument must be set when -issueTracker is set")
+	}
+
+	if octolintConfig.IssueGrouping != "" && !lo.Contains(Groupings, octolintConfig.IssueGrouping) {
+		return errors.New("The -issueGrouping argument must be one of " + strings.Join(Groupings, ", ") + ", but was \"" + octolintConfig.IssueGrouping + "\"")
+	}
+
+	for _, label := range octolintConfig.IssueLabels {
+		if strings.ContainsAny(label, " ,") {
+			return errors.New("The -issueLabels argument must not contain spaces or commas, but was \"" + label + "\"")
+		}
+	}
+
+	return nil
+}
+
+// Sync opens, updates, reopens and closes the issues of the space to match the results of a scan.
+func (o IssueSync) Sync(url string, space string, results []checks.OctopusCheckResult) (SyncResult, error) {
+	syncResult := SyncResult{}
+
+	if err := Validate(o.config); err != nil {
+		return syncResult, err
+	}
+
+	url = strings.TrimSuffix(url, "/")
+	results = lo.Filter(results, func(result checks.OctopusCheckResult, index int) bool { return result != nil })
+
+	grouping := o.grouping()
+	scopeLabels := []string{ManagedLabel, hashLabel(ManagedLabel+"-space-", url, space), ManagedLabel + "-by-" + grouping}
+
+	existing, err := o.tracker.Issues(scopeLabels)
+
+	if err != nil {
+		return syncResult, err
+	}
+
+	var wanted []wantedIssue
+	var closable func(issue Issue) bool
+
+	if grouping == GroupByProject {
+		wanted = o.projectIssues(url, space, results)
+		// An issue is closed when none of the findings belong to the project any more. A check that failed to run
+		// may have had findings in any project, so no issues are closed after such a scan. The issues of projects
+		// that the scan did not inspect are left as they are.
+		anyFailedToRun := lo.ContainsBy(results, failedToRun)
+		covered := o.coveredProjects(url, space, results)
+		closable = func(issue Issue) bool { return !anyFailedToRun && covered(issue) }
+	} else {
+		wanted = o.checkIssues(url, space, results)
+
+		// Only the issues of checks that ran and passed are closed. The issues of checks that were excluded, or
+		// that failed to run, are left as they are.
+		passed := lo.FilterMap(results, func(result checks.OctopusCheckResult, index int) (string, bool) {
+			return fingerprint(url, space, GroupByCheck, result.Code()), !failedToRun(result) && checks.FindingCount(result) == 0
+		})
+		closable = func(issue Issue) bool { return lo.Some(issue.Labels, passed) }
+	}
+
+	var syncErrors []error
+
+	for _, wantedIssue := range wanted {
+		labels := append(slices.Clone(scopeLabels), wantedIssue.fingerprint)
+		labels = append(labels, o.config.IssueLabels...)
+
+		issue, found := lo.Find(existing, func(issue Issue) bool { return lo.Contains(issue.Labels, wantedIssue.fingerprint) })
+
+		if !found {
+			id, err := o.tracker.Create(Issue{Title: wantedIssue.title, Body: wantedIssue.body, Labels: labels, Open: true})
+
+			if err != nil {
+				syncErrors = append(syncErrors, err)
+			} else {
+				syncResult.Created = append(syncResult.Created, id)
+			}
+
+			continue
+		}
+
+		if issue.Title != wantedIssue.title || normalize(issue.Body) != normalize(wantedIssue.body) {
+			issue.Title = wantedIssue.title
+			issue.Body = wantedIssue.body
+
+			if err := o.tracker.Update(issue); err != nil {
+				syncErrors = append(syncErrors, err)
+				continue
+			}
+
+			syncResult.Updated = append(syncResult.Updated, issue.Id)
+		}
+
+		if !issue.Open {
+			if err := o.tracker.SetOpen(issue, true); err != nil {
+				syncErrors = append(syncErrors, err)
+				continue
+			}
+
+			syncResult.Reopened = append(syncResult.Reopened, issue.Id)
+		}
+	}
+
+	wantedFingerprints := lo.Map(wanted, func(wantedIssue wantedIssue, index int) string { return wantedIssue.fingerprint })
+
+	for _, issue := range existing {
+		if !issue.Open || lo.Some(issue.Labels, wantedFingerprints) || !closable(issue) {
+			continue
+		}
+
+		if err := o.tracker.SetOpen(issue, false); err != nil {
+			syncErrors = append(syncErrors, err)
+			continue
+		}
+
+		syncResult.Closed = append(syncResult.Closed, issue.Id)
+	}
+
+	return syncResult, errors.Join(syncErrors...)
+}
+
+// coveredProjects returns a function that returns true if an issue opened with the project grouping belongs to the
+// space, or to a project that was inspected by the scan. Projects removed by the excludeProjects and
+// excludeProjectsExcept arguments were not inspected. The checks only record how many projects they inspected, so
+// when a check inspected some of the projects, for example because of its max...Projects argument, the project issues are
+// left open.
+func (o IssueSync) coveredProjects(url string, space string, results []checks.OctopusCheckResult) func(issue Issue) bool {
+	projectFingerprint := func(name string) string { return fingerprint(url, space, GroupByProject, name) }
+
+	spaceIssue := projectFingerprint("")
+	excluded := lo.Map(o.config.ExcludeProjects, func(name string, index int) string { return projectFingerprint(name) })
+	onlyIncluded := lo.ContainsBy(o.config.ExcludeProjectsExcept, func(name string) bool { return strings.TrimSpace(name) != "" })
+	included := lo.Map(o.config.ExcludeProjectsExcept, func(name string, index int) string { return projectFingerprint(name) })
+
+	partial := lo.ContainsBy(results, func(result checks.OctopusCheckResult) bool {
+		return lo.ContainsBy(checks.Coverage(result), func(coverage checks.ResourceCoverage) bool {
+			return coverage.ResourceType == checks.ResourceTypeProject && coverage.IsPartial()
+		})
+	})
+
+	return func(issue Issue) bool {
+		if lo.Contains(issue.Labels, spaceIssue) {
+			return true
+		}
+
+		if partial || lo.Some(issue.Labels, excluded) {
+			return false
+		}
+
+		return !onlyIncluded || lo.Some(issue.Labels, included)
+	}
+}
+
+// failedToRun returns true if the check could not inspect the space, either because it returned an error, or
+// because the API key could not read the resources it inspects. The findings of such a check are unknown.
+func failedToRun(result checks.OctopusCheckResult) bool {
+	return result.Category() == checks.GeneralError || result.Severity() == checks.Permission
+}
+
+func (o IssueSync) grouping() string {
+	if o.config.IssueGrouping == "" {
+		return GroupByCheck
+	}
+
+	return o.config.IssueGrouping
+}
+
+// checkIssues returns an issue for each check that reported findings.
+func (o IssueSync) checkIssues(url string, space string, results []checks.OctopusCheckResult) []wantedIssue {
+	markup := o.tracker.Markup()
+	wanted := []wantedIssue{}
+
+	for _, result := range results {
+		if result.Category() == checks.GeneralError || checks.FindingCount(result) == 0 {
+			continue
+		}
+
+		wanted = append(wanted, wantedIssue{
+			fingerprint: fingerprint(url, space, GroupByCheck, result.Code()),
+			title:       "[octolint] " + result.Code() + " reported " + pluralize(checks.FindingCount(result), "finding") + " in " + space,
+			body:        issueBody(markup, resultSection(markup, result, result.Findings()), url, space),
+		})
+	}
+
+	return wanted
+}
+
+// projectIssues returns an issue for each project with findings. Findings that do not belong to a project, and
+// checks that report on the space as a whole, are collected in an issue for the space.
+func (o IssueSync) projectIssues(url string, space string, results []checks.OctopusCheckResult) []wantedIssue {
+	markup := o.tracker.Markup()
+
+	// owners records the order the projects were first seen in, so the issues are created in a stable order
+	owners := []string{}
+	sections := map[string][]string{}
+	counts := map[string]int{}
+
+	for _, result := range results {
+		if result.Category() == checks.GeneralError || checks.FindingCount(result) == 0 {
+			continue
+		}
+
+		findings := map[string][]checks.OctopusCheckFinding{}
+		resultOwners := []string{}
+
+		if len(result.Findings()) == 0 {
+			findings[""] = nil
+			resultOwners = append(resultOwners, "")
+		}
+
+		for _, finding := range result.Findings() {
+			owner := owningProject(finding)
+
+			if _, found := findings[owner]; !found {
+				resultOwners = append(resultOwners, owner)
+			}
+
+			findings[owner] = append(findings[owner], finding)
+		}
+
+		for _, owner := range resultOwners {
+			if _, found := sections[owner]; !found {
+				owners = append(owners, owner)
+			}
+
+			if len(sections[owner]) != 0 {
+				sections[owner] = append(sections[owner], "")
+			}
+
+			sections[owner] = append(sections[owner], resultSection(markup, result, findings[owner])...)
+			counts[owner] += max(len(findings[owner]), 1)
+		}
+	}
+
+	wanted := []wantedIssue{}
+
+	for _, owner := range owners {
+		title := "[octolint] " + pluralize(counts[owner], "finding") + " in the project " + owner + " in " + space
+		if owner == "" {
+			title = "[octolint] " + pluralize(counts[owner], "finding") + " in " + space
+		}
+
+		wanted = append(wanted, wantedIssue{
+			fingerprint: fingerprint(url, space, GroupByProject, owner),
+			title:       title,
+			body:        issueBody(markup, sections[owner], url, space),
+		})
+	}
+
+	return wanted
+}
+
+// owningProject returns the name of the project that a finding belongs to, or an empty string if the finding
+// does not belong to a project.
+func owningProject(finding checks.OctopusCheckFinding) string {
+	if finding.ResourceType == "Project" {
+		return lo.CoalesceOrEmpty(finding.ResourceName, finding.ResourceId)
+	}
+
+	if strings.HasPrefix(finding.ParentId, "Projects-") {
+		return lo.CoalesceOrEmpty(finding.ParentName, finding.ParentId)
+	}
+
+	return ""
+}
+
+// fingerprint returns the label that identifies the issue of a check or project. Labels are limited to 50
+// characters by GitHub, so a short hash is used rather than the names.
+func fingerprint(url string, space string, grouping string, key string) string {
+	return hashLabel(ManagedLabel+"-", url, space, grouping, key)
+}
+
+func hashLabel(prefix string, values ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(values, "|")))
+	return prefix + hex.EncodeToString(hash[:])[:12]
+}
+
+// normalize removes the differences in line endings and trailing whitespace that trackers introduce when saving
+// the body of an issue.
+func normalize(body string) string {
+	return strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprint(count) + " " + noun + "s"
+}
@@ -0,0 +1,461 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+)
+
+// stubIssue is an issue saved by a stub tracker.
+type stubIssue struct {
+	Id     string
+	Title  string
+	Body   string
+	Labels []string
+	Open   bool
+}
+
+// stubTracker is an in memory issue tracker that serves a subset of the GitHub or Jira REST API.
+type stubTracker struct {
+	mutex  sync.Mutex
+	server *httptest.Server
+	issues []*stubIssue
+	auth   []string
+}
+
+func (o *stubTracker) find(id string) *stubIssue {
+	issue, _ := lo.Find(o.issues, func(issue *stubIssue) bool { return issue.Id == id })
+	return issue
+}
+
+// matching returns the issues that have all the labels.
+func (o *stubTracker) matching(labels []string) []*stubIssue {
+	return lo.Filter(o.issues, func(issue *stubIssue, index int) bool {
+		return lo.Every(issue.Labels, labels)
+	})
+}
+
+func newGithubStub(t *testing.T) *stubTracker {
+	stub := &stubTracker{}
+
+	stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stub.mutex.Lock()
+		defer stub.mutex.Unlock()
+
+		stub.auth = append(stub.auth, req.Header.Get("Authorization"))
+
+		path := strings.TrimPrefix(req.URL.Path, "/repos/owner/repo/issues")
+		body := map[string]any{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+
+		switch {
+		case req.Method == http.MethodGet && path == "":
+			issues := []map[string]any{}
+			for _, issue := range stub.matching(strings.Split(req.URL.Query().Get("labels"), ",")) {
+				number, _ := strconv.Atoi(issue.Id)
+				issues = append(issues, map[string]any{
+					"number": number,
+					"title":  issue.Title,
+					"body":   issue.Body,
+					"state":  lo.Ternary(issue.Open, "open", "closed"),
+					"labels": lo.Map(issue.Labels, func(label string, index int) map[string]any { return map[string]any{"name": label} }),
+				})
+			}
+			_ = json.NewEncoder(w).Encode(issues)
+		case req.Method == http.MethodPost && path == "":
+			issue := &stubIssue{
+				Id:     fmt.Sprint(len(stub.issues) + 1),
+				Title:  body["title"].(string),
+				Body:   body["body"].(string),
+				Labels: lo.Map(body["labels"].([]any), func(label any, index int) string { return label.(string) }),
+				Open:   true,
+			}
+			stub.issues = append(stub.issues, issue)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"number": len(stub.issues)})
+		case req.Method == http.MethodPatch && stub.find(strings.TrimPrefix(path, "/")) != nil:
+			issue := stub.find(strings.TrimPrefix(path, "/"))
+			if title, ok := body["title"].(string); ok {
+				issue.Title = title
+				issue.Body = body["body"].(string)
+			}
+			if state, ok := body["state"].(string); ok {
+				issue.Open = state == "open"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+func newJiraStub(t *testing.T) *stubTracker {
+	stub := &stubTracker{}
+	status := func(open bool) map[string]any {
+		return map[string]any{"statusCategory": map[string]any{"key": lo.Ternary(open, "indeterminate", "done")}}
+	}
+
+	stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stub.mutex.Lock()
+		defer stub.mutex.Unlock()
+
+		stub.auth = append(stub.auth, req.Header.Get("Authorization"))
+
+		body := map[string]any{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		path := strings.TrimPrefix(req.URL.Path, "/rest/api/2/")
+		key := strings.TrimSuffix(strings.TrimPrefix(path, "issue/"), "/transitions")
+
+		switch {
+		case req.Method == http.MethodGet && path == "search":
+			// The labels are parsed from clauses like: labels = "octolint"
+			labels := []string{}
+			for _, clause := range strings.Split(req.URL.Query().Get("jql"), " AND ")[1:] {
+				labels = append(labels, strings.Trim(strings.TrimPrefix(clause, "labels = "), "\""))
+			}
+			issues := lo.Map(stub.matching(labels), func(issue *stubIssue, index int) map[string]any {
+				return map[string]any{"key": issue.Id, "fields": map[string]any{
+					"summary":     issue.Title,
+					"description": issue.Body,
+					"labels":      issue.Labels,
+					"status":      status(issue.Open),
+				}}
+			})
+			_ = json.NewEncoder(w).Encode(map[string]any{"total": len(issues), "issues": issues})
+		case req.Method == http.MethodPost && path == "issue":
+			fields := body["fields"].(map[string]any)
+			issue := &stubIssue{
+				Id:     "OPS-" + fmt.Sprint(len(stub.issues)+1),
+				Title:  fields["summary"].(string),
+				Body:   fields["description"].(string),
+				Labels: lo.Map(fields["labels"].([]any), func(label any, index int) string { return label.(string) }),
+				Open:   true,
+			}
+			stub.issues = append(stub.issues, issue)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"key": issue.Id})
+		case req.Method == http.MethodPut && stub.find(key) != nil:
+			fields := body["fields"].(map[string]any)
+			stub.find(key).Title = fields["summary"].(string)
+			stub.find(key).Body = fields["description"].(string)
+			w.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodGet && strings.HasSuffix(path, "/transitions") && stub.find(key) != nil:
+			_ = json.NewEncoder(w).Encode(map[string]any{"transitions": []map[string]any{
+				{"id": "11", "to": status(true)},
+				{"id": "31", "to": status(false)},
+			}})
+		case req.Method == http.MethodPost && strings.HasSuffix(path, "/transitions") && stub.find(key) != nil:
+			stub.find(key).Open = body["transition"].(map[string]any)["id"] == "11"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+func newTestSync(t *testing.T, octolintConfig *config.OctolintConfig) IssueSync {
+	tracker, err := NewTracker(octolintConfig, nil)
+
+	if err != nil {
+		t.Fatal("Should have created the tracker: " + err.Error())
+	}
+
+	return NewIssueSync(octolintConfig, tracker)
+}
+
+func projectFinding(name string) checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{ResourceType: "Project", ResourceId: "Projects-" + name, ResourceName: name, Description: name}
+}
+
+// emptyProjects returns a result like the one returned by the empty project check, which lists every finding in the
+// description.
+func emptyProjects(names ...string) checks.OctopusCheckResult {
+	findings := lo.Map(names, func(name string, index int) checks.OctopusCheckFinding { return projectFinding(name) })
+
+	return checks.NewOctopusCheckResultImplWithFindings(
+		"The following projects have no runbooks and no deployment process:\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
+		"OctoLintEmptyProject", "https://example.org/empty", checks.Warning, checks.Organization, findings)
+}
+
+func passed(code string) checks.OctopusCheckResult {
+	return checks.NewOctopusCheckResultImpl("No problems were found", code, "", checks.Ok, checks.Organization)
+}
+
+func TestGithubSyncLifecycle(t *testing.T) {
+	stub := newGithubStub(t)
+	sync := newTestSync(t, &config.OctolintConfig{
+		IssueTracker:    TrackerGithub,
+		IssueUrl:        stub.server.URL + "/",
+		IssueToken:      "token",
+		IssueRepository: "owner/repo",
+		IssueLabels:     config.StringSliceArgs{"platform"},
+	})
+
+	// The first scan opens an issue for the failed check
+	result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A"), passed("OctoLintEnvironmentCount")})
+
+	if err != nil || len(result.Created) != 1 || len(stub.issues) != 1 {
+		t.Fatal("Should have opened an issue for the failed check")
+	}
+
+	if !lo.Contains(stub.issues[0].Labels, "platform") || !lo.Contains(stub.issues[0].Labels, ManagedLabel) ||
+		!strings.Contains(stub.issues[0].Body, "`A`") || !strings.Contains(stub.issues[0].Title, "OctoLintEmptyProject") {
+		t.Fatal("Should have labelled and described the issue, got: " + stub.issues[0].Title + "\n" + stub.issues[0].Body)
+	}
+
+	if stub.auth[0] != "Bearer token" {
+		t.Fatal("Should have sent the token as a bearer token")
+	}
+
+	// The same findings do not change the issue
+	result, err = sync.Sync("https://example.octopus.app/", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A")})
+
+	if err != nil || len(result.Created)+len(result.Updated)+len(result.Closed) != 0 || len(stub.issues) != 1 {
+		t.Fatal("Should have matched the existing issue with the fingerprint label")
+	}
+
+	// New findings update the body
+	result, err = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A", "B")})
+
+	if err != nil || len(result.Updated) != 1 || !strings.Contains(stub.issues[0].Body, "`B`") {
+		t.Fatal("Should have updated the body of the issue")
+	}
+
+	// A check that was not run leaves the issue open
+	result, err = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{passed("OctoLintEnvironmentCount")})
+
+	if err != nil || len(result.Closed) != 0 || !stub.issues[0].Open {
+		t.Fatal("Should not have closed the issue of a check that did not run")
+	}
+
+	// A check that could not read the space leaves the issue open
+	permission := checks.NewOctopusCheckResultImpl("The API key can not read the projects", "OctoLintEmptyProject", "", checks.Permission, checks.Organization)
+	result, err = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{permission})
+
+	if err != nil || len(result.Closed) != 0 || !stub.issues[0].Open {
+		t.Fatal("Should not have closed the issue of a check that reported a permission error")
+	}
+
+	// A passing check closes the issue
+	result, err = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{passed("OctoLintEmptyProject")})
+
+	if err != nil || len(result.Closed) != 1 || stub.issues[0].Open {
+		t.Fatal("Should have closed the issue when the check passed")
+	}
+
+	// A failing check reopens the closed issue rather than opening a duplicate
+	result, err = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("C")})
+
+	if err != nil || len(result.Reopened) != 1 || len(result.Created) != 0 || !stub.issues[0].Open || len(stub.issues) != 1 {
+		t.Fatal("Should have reopened the issue")
+	}
+
+	// The issues of another space are separate
+	if result, _ = sync.Sync("https://example.octopus.app", "Spaces-2", []checks.OctopusCheckResult{emptyProjects("A")}); len(result.Created) != 1 {
+		t.Fatal("Should have opened a separate issue for another space")
+	}
+}
+
+func TestJiraSyncLifecycle(t *testing.T) {
+	stub := newJiraStub(t)
+	sync := newTestSync(t, &config.OctolintConfig{
+		IssueTracker:  TrackerJira,
+		IssueUrl:      stub.server.URL,
+		IssueToken:    "token",
+		IssueUsername: "user@example.org",
+		IssueProject:  "OPS",
+		IssueType:     "Task",
+	})
+
+	if result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A")}); err != nil || len(result.Created) != 1 {
+		t.Fatal("Should have opened an issue for the failed check")
+	}
+
+	if !strings.HasPrefix(stub.auth[0], "Basic ") {
+		t.Fatal("Should have used basic authentication with the username")
+	}
+
+	if !strings.Contains(stub.issues[0].Body, "{{A}}") || !strings.Contains(stub.issues[0].Body, "h3. OctoLintEmptyProject") {
+		t.Fatal("Should have written the description with wiki markup, got: " + stub.issues[0].Body)
+	}
+
+	if result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A", "B")}); err != nil || len(result.Updated) != 1 {
+		t.Fatal("Should have updated the issue")
+	}
+
+	if result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{passed("OctoLintEmptyProject")}); err != nil || len(result.Closed) != 1 || stub.issues[0].Open {
+		t.Fatal("Should have transitioned the issue to done")
+	}
+
+	if result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A")}); err != nil || len(result.Reopened) != 1 || !stub.issues[0].Open {
+		t.Fatal("Should have reopened the issue")
+	}
+}
+
+func TestSyncByProject(t *testing.T) {
+	stub := newGithubStub(t)
+	sync := newTestSync(t, &config.OctolintConfig{
+		IssueTracker:    TrackerGithub,
+		IssueUrl:        stub.server.URL,
+		IssueRepository: "owner/repo",
+		IssueGrouping:   GroupByProject,
+	})
+
+	variableFindings := []checks.OctopusCheckFinding{
+		{ResourceType: "Variable", ResourceName: "Password", ParentId: "Projects-A", ParentName: "A", Description: "A: Password"},
+		{ResourceType: "Variable", ResourceName: "ConnectionString", ParentId: "Projects-B", ParentName: "B", Description: "B: ConnectionString"},
+	}
+	unscopedVariables := checks.NewOctopusCheckResultImplWithFindings(
+		"The following variables may be unused:\n"+strings.Join(checks.FindingDescriptions(variableFindings), "\n"),
+		"OctoLintUnusedVariables", "", checks.Warning, checks.Organization, variableFindings)
+	environments := checks.NewOctopusCheckResultImpl("There are too many environments", "OctoLintEnvironmentCount", "", checks.Warning, checks.Organization)
+
+	result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A", "B"), unscopedVariables, environments})
+
+	if err != nil || len(result.Created) != 3 {
+		t.Fatal("Should have opened an issue for each project, and one for the space")
+	}
+
+	projectA, _ := lo.Find(stub.issues, func(issue *stubIssue) bool { return strings.Contains(issue.Title, "project A") })
+	if projectA == nil || !strings.Contains(projectA.Body, "OctoLintEmptyProject") || !strings.Contains(projectA.Body, "`Password`") ||
+		strings.Contains(projectA.Body, "`B`") || strings.Contains(projectA.Body, "ConnectionString") || strings.Contains(projectA.Body, "\nB\n") {
+		t.Fatal("Should have collected the findings of project A in one issue")
+	}
+
+	// A check that failed to run may have had findings in any project, so nothing is closed
+	failed := checks.NewOctopusCheckResultImpl("The check failed", "OctoLintEmptyProject", "", checks.Error, checks.GeneralError)
+	if result, _ = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{failed}); len(result.Closed) != 0 {
+		t.Fatal("Should not have closed issues when a check failed to run")
+	}
+
+	permission := checks.NewOctopusCheckResultImpl("The API key can not read the projects", "OctoLintEmptyProject", "", checks.Permission, checks.Organization)
+	if result, _ = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{permission}); len(result.Closed) != 0 {
+		t.Fatal("Should not have closed issues when a check reported a permission error")
+	}
+
+	result, err = sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("B")})
+
+	if err != nil || len(result.Closed) != 2 || len(lo.Filter(stub.issues, func(issue *stubIssue, index int) bool { return issue.Open })) != 1 {
+		t.Fatal("Should have closed the issues of projects without findings")
+	}
+}
+
+func TestSyncByProjectOnlyClosesCoveredProjects(t *testing.T) {
+	stub := newGithubStub(t)
+	octolintConfig := &config.OctolintConfig{
+		IssueTracker:    TrackerGithub,
+		IssueUrl:        stub.server.URL,
+		IssueRepository: "owner/repo",
+		IssueGrouping:   GroupByProject,
+	}
+	sync := newTestSync(t, octolintConfig)
+
+	if result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{emptyProjects("A", "B", "C")}); err != nil || len(result.Created) != 3 {
+		t.Fatal("Should have opened an issue for each project")
+	}
+
+	// A check that inspected some of the projects may not have inspected the projects with issues
+	limited := checks.NewOctopusCheckResultImpl("No problems were found", "OctoLintEmptyProject", "", checks.Ok, checks.Organization).WithCoverage(
+		checks.NewResourceCoverage(checks.ResourceTypeProject, 250, 100, 0))
+	if result, _ := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{limited}); len(result.Closed) != 0 {
+		t.Fatal("Should not have closed issues when a check inspected some of the projects")
+	}
+
+	// Projects removed by the project filter were not inspected
+	octolintConfig.ExcludeProjects = config.StringSliceArgs{"A"}
+	octolintConfig.ExcludeProjectsExcept = config.StringSliceArgs{"A", "B"}
+	result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{passed("OctoLintEmptyProject")})
+
+	if err != nil || len(result.Closed) != 1 {
+		t.Fatal("Should have only closed the issue of the project that was inspected")
+	}
+
+	openProjects := lo.FilterMap(stub.issues, func(issue *stubIssue, index int) (string, bool) {
+		return issue.Title, issue.Open
+	})
+	if len(openProjects) != 2 || lo.ContainsBy(openProjects, func(title string) bool { return strings.Contains(title, "project B") }) {
+		t.Fatal("Should have left the issues of the excluded projects open, got " + strings.Join(openProjects, ", "))
+	}
+}
+
+func TestSyncLimitsBodyLength(t *testing.T) {
+	stub := newJiraStub(t)
+	sync := newTestSync(t, &config.OctolintConfig{
+		IssueTracker: TrackerJira,
+		IssueUrl:     stub.server.URL,
+		IssueProject: "OPS",
+	})
+
+	findings := []checks.OctopusCheckFinding{}
+	for i := 0; i < maxListedFindings*2; i++ {
+		name := fmt.Sprint("Project ", i)
+		findings = append(findings, checks.OctopusCheckFinding{
+			ResourceType: "Action",
+			ResourceName: "Deploy",
+			ParentId:     "Projects-" + fmt.Sprint(i),
+			ParentName:   name,
+			Description:  name + "/Deploy: " + strings.Repeat("The step references a variable that is not defined. ", 10),
+		})
+	}
+
+	undefinedVariables := checks.NewOctopusCheckResultImplWithFindings(
+		"The following steps reference undefined variables:\n"+strings.Join(checks.FindingDescriptions(findings), "\n"),
+		"OctoLintUndefinedVariables", "", checks.Warning, checks.Organization, findings)
+
+	if result, err := sync.Sync("https://example.octopus.app", "Spaces-1", []checks.OctopusCheckResult{undefinedVariables}); err != nil || len(result.Created) != 1 {
+		t.Fatal("Should have opened an issue for the failed check")
+	}
+
+	body := stub.issues[0].Body
+
+	if len([]rune(body)) > WikiMarkup.MaxLength || !strings.Contains(body, "were not listed") || !strings.Contains(body, "managed by octolint") {
+		t.Fatal("Should have cut the body to the length accepted by Jira, and kept the footer")
+	}
+
+	if strings.Count(body, "Project 0/Deploy") != 0 || strings.Count(body, "{{Project 0}}") != 1 {
+		t.Fatal("Should have listed each finding once, without repeating the description of the check")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if Validate(&config.OctolintConfig{}) != nil {
+		t.Fatal("Should have accepted a disabled issue sync")
+	}
+
+	if Validate(&config.OctolintConfig{IssueTracker: "gitlab", IssueUrl: "https://example.org"}) == nil {
+		t.Fatal("Should have rejected an unsupported tracker")
+	}
+
+	if Validate(&config.OctolintConfig{IssueTracker: TrackerGithub, IssueUrl: "https://api.github.com"}) == nil {
+		t.Fatal("Should have required the repository")
+	}
+
+	if Validate(&config.OctolintConfig{IssueTracker: TrackerJira, IssueUrl: "https://example.atlassian.net"}) == nil {
+		t.Fatal("Should have required the project")
+	}
+
+	if Validate(&config.OctolintConfig{IssueTracker: TrackerJira, IssueUrl: "https://example.atlassian.net", IssueProject: "OPS", IssueGrouping: "environment"}) == nil {
+		t.Fatal("Should have rejected an unsupported grouping")
+	}
+
+	if Validate(&config.OctolintConfig{IssueTracker: TrackerGithub, IssueUrl: "https://api.github.com", IssueRepository: "owner/repo", IssueLabels: config.StringSliceArgs{"tech debt"}}) == nil {
+		t.Fatal("Should have rejected a label with a space")
+	}
+}
@@ -0,0 +1,147 @@
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// jiraPageSize is the number of issues requested at a time by a search.
+const jiraPageSize = 100
+
+// jiraDoneCategory is the key of the status category of resolved issues.
+const jiraDoneCategory = "done"
+
+// JiraTracker manages the issues of a Jira project with the version 2 REST API, which accepts wiki markup in the
+// description.
+type JiraTracker struct {
+	api       restClient
+	project   string
+	issueType string
+}
+
+type jiraStatus struct {
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string     `json:"summary"`
+		Description string     `json:"description"`
+		Labels      []string   `json:"labels"`
+		Status      jiraStatus `json:"status"`
+	} `json:"fields"`
+}
+
+type jiraSearch struct {
+	Total  int         `json:"total"`
+	Issues []jiraIssue `json:"issues"`
+}
+
+type jiraTransition struct {
+	Id string     `json:"id"`
+	To jiraStatus `json:"to"`
+}
+
+type jiraTransitions struct {
+	Transitions []jiraTransition `json:"transitions"`
+}
+
+func (o JiraTracker) Issues(labels []string) ([]Issue, error) {
+	clauses := []string{"project = " + jqlString(o.project)}
+	for _, label := range labels {
+		clauses = append(clauses, "labels = "+jqlString(label))
+	}
+
+	issues := []Issue{}
+
+	for startAt := 0; ; startAt += jiraPageSize {
+		query := url.Values{
+			"jql":        []string{strings.Join(clauses, " AND ")},
+			"fields":     []string{"summary,description,labels,status"},
+			"startAt":    []string{fmt.Sprint(startAt)},
+			"maxResults": []string{fmt.Sprint(jiraPageSize)},
+		}
+
+		search := jiraSearch{}
+		if err := o.api.send(http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &search); err != nil {
+			return nil, err
+		}
+
+		for _, jiraIssue := range search.Issues {
+			issues = append(issues, Issue{
+				Id:     jiraIssue.Key,
+				Title:  jiraIssue.Fields.Summary,
+				Body:   jiraIssue.Fields.Description,
+				Labels: jiraIssue.Fields.Labels,
+				Open:   jiraIssue.Fields.Status.StatusCategory.Key != jiraDoneCategory,
+			})
+		}
+
+		if len(search.Issues) == 0 || startAt+len(search.Issues) >= search.Total {
+			return issues, nil
+		}
+	}
+}
+
+func (o JiraTracker) Create(issue Issue) (string, error) {
+	created := jiraIssue{}
+
+	err := o.api.send(http.MethodPost, "/rest/api/2/issue", map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]any{"key": o.project},
+			"issuetype":   map[string]any{"name": o.issueType},
+			"summary":     issue.Title,
+			"description": issue.Body,
+			"labels":      issue.Labels,
+		},
+	}, &created)
+
+	return created.Key, err
+}
+
+func (o JiraTracker) Update(issue Issue) error {
+	return o.api.send(http.MethodPut, "/rest/api/2/issue/"+issue.Id, map[string]any{
+		"fields": map[string]any{
+			"summary":     issue.Title,
+			"description": issue.Body,
+		},
+	}, nil)
+}
+
+// SetOpen moves the issue with the first transition into, or out of, the done status category. Workflows are
+// configured per project, so the transitions are looked up rather than assuming a status name.
+func (o JiraTracker) SetOpen(issue Issue, open bool) error {
+	transitions := jiraTransitions{}
+	if err := o.api.send(http.MethodGet, "/rest/api/2/issue/"+issue.Id+"/transitions", nil, &transitions); err != nil {
+		return err
+	}
+
+	transition, found := lo.Find(transitions.Transitions, func(transition jiraTransition) bool {
+		return (transition.To.StatusCategory.Key == jiraDoneCategory) != open
+	})
+
+	if !found {
+		return errors.New("The Jira issue " + issue.Id + " has no transition to " + lo.Ternary(open, "reopen", "close") + " it")
+	}
+
+	return o.api.send(http.MethodPost, "/rest/api/2/issue/"+issue.Id+"/transitions", map[string]any{
+		"transition": map[string]any{"id": transition.Id},
+	}, nil)
+}
+
+func (o JiraTracker) Markup() Markup {
+	return WikiMarkup
+}
+
+// jqlString quotes a value in a JQL query.
+func jqlString(value string) string {
+	return "\"" + strings.ReplaceAll(strings.ReplaceAll(value, "\\", "\\\\"), "\"", "\\\"") + "\""
+}
@@ -0,0 +1,157 @@
+package issues
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+// maxListedFindings is the number of findings of each check listed in the body of an issue. The body is also cut to
+// the MaxLength of the markup, as an issue grouped by project can list many checks.
+const maxListedFindings = 100
+
+// Markup formats the body of an issue for a tracker.
+type Markup struct {
+	// MaxLength is the number of characters accepted in the body of an issue by the tracker
+	MaxLength int
+	Heading   func(text string) string
+	Bold      func(text string) string
+	Code      func(text string) string
+	Link      func(text string, url string) string
+	Item      func(text string) string
+}
+
+// MarkdownMarkup is the GitHub flavored markdown used by GitHub issues.
+var MarkdownMarkup = Markup{
+	MaxLength: 65536,
+	Heading:   func(text string) string { return "### " + text },
+	Bold:      func(text string) string { return "**" + text + "**" },
+	Code:      func(text string) string { return "`" + strings.ReplaceAll(text, "`", "'") + "`" },
+	Link:      func(text string, url string) string { return "[" + text + "](" + url + ")" },
+	Item:      func(text string) string { return "- " + text },
+}
+
+// WikiMarkup is the wiki markup used by the version 2 Jira REST API.
+var WikiMarkup = Markup{
+	MaxLength: 32767,
+	Heading:   func(text string) string { return "h3. " + text },
+	Bold:      func(text string) string { return "*" + text + "*" },
+	Code:      func(text string) string { return "{{" + strings.ReplaceAll(text, "}", ")") + "}}" },
+	Link: func(text string, url string) string {
+		return "[" + strings.ReplaceAll(text, "|", "/") + "|" + url + "]"
+	},
+	Item: func(text string) string { return "* " + text },
+}
+
+// resultSection describes a failed check and lists its findings. Checks list every finding in the space in their
+// description, so only the first line, which introduces the findings, is included when the findings are listed.
+func resultSection(markup Markup, result checks.OctopusCheckResult, findings []checks.OctopusCheckFinding) []string {
+	description := result.Description()
+	if len(findings) != 0 {
+		description, _, _ = strings.Cut(description, "\n")
+	}
+
+	lines := []string{
+		markup.Heading(result.Code()),
+		"",
+		strings.TrimSpace(description),
+		"",
+		markup.Bold("Severity:") + " " + checks.SeverityName(result.Severity()) + ", " + markup.Bold("Category:") + " " + result.Category(),
+	}
+
+	if result.Link() != "" {
+		lines = append(lines, "", markup.Link("Read more about this check", result.Link()))
+	}
+
+	if len(findings) == 0 {
+		return lines
+	}
+
+	lines = append(lines, "")
+	for i, finding := range findings {
+		if i == maxListedFindings {
+			lines = append(lines, markup.Item("and "+fmt.Sprint(len(findings)-maxListedFindings)+" more"))
+			break
+		}
+
+		lines = append(lines, markup.Item(findingText(markup, finding)))
+	}
+
+	return lines
+}
+
+func findingText(markup Markup, finding checks.OctopusCheckFinding) string {
+	text := finding.ResourceType + " " + markup.Code(finding.ResourceName)
+
+	if finding.ResourceId != "" {
+		text += " (" + finding.ResourceId + ")"
+	}
+
+	if finding.ParentName != "" {
+		text += " in " + markup.Code(finding.ParentName)
+	}
+
+	if details := findingDetails(finding); details != "" {
+		text += ": " + details
+	}
+
+	return text
+}
+
+// findingDetails returns the description of a finding without the names of the resource and its parent, which
+// most checks start the description with, and which are already included in the text of the finding.
+func findingDetails(finding checks.OctopusCheckFinding) string {
+	for _, prefix := range []string{finding.ParentName + "/" + finding.ResourceName, finding.ParentName + ": " + finding.ResourceName, finding.ResourceName} {
+		if prefix != "" && strings.HasPrefix(finding.Description, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(finding.Description, prefix), ":"))
+		}
+	}
+
+	return finding.Description
+}
+
+// issueBody joins the sections of an issue with a link to the space and the footer. Lines are dropped from the end
+// of the sections if the body would be longer than the tracker accepts.
+func issueBody(markup Markup, sections []string, url string, space string) string {
+	tail := append([]string{"", markup.Link("Open the space", url+"/app#/"+space)}, footer()...)
+	truncated := []string{"", markup.Item("The remaining findings were not listed because the description is limited to " + fmt.Sprint(markup.MaxLength) + " characters")}
+
+	budget := markup.MaxLength - length(tail) - length(truncated)
+	if length(sections) <= budget+length(truncated) {
+		return strings.Join(slices.Concat(sections, tail), "\n")
+	}
+
+	lines := []string{}
+	for _, line := range sections {
+		if length(lines)+length([]string{line}) > budget {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(slices.Concat(lines, truncated, tail), "\n")
+}
+
+// length returns the number of characters in the lines when they are joined with line breaks.
+func length(lines []string) int {
+	count := 0
+	for _, line := range lines {
+		count += utf8.RuneCountInString(line) + 1
+	}
+
+	return count
+}
+
+// footer is added to every issue, so readers know not to edit the body.
+func footer() []string {
+	return []string{
+		"",
+		"----",
+		"",
+		"This issue is managed by octolint. The description is replaced when the findings change, and the issue is closed when the findings are resolved.",
+	}
+}
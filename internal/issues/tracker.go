@@ -0,0 +1,138 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+const (
+	TrackerGithub = "github"
+	TrackerJira   = "jira"
+)
+
+// trackerTimeout is the time allowed for each request to the issue tracker.
+const trackerTimeout = 30 * time.Second
+
+// Issue is an issue in the tracker.
+type Issue struct {
+	// Id is the number of a GitHub issue, or the key of a Jira issue
+	Id     string
+	Title  string
+	Body   string
+	Labels []string
+	Open   bool
+}
+
+// Tracker reads and writes the issues of a GitHub repository or Jira project.
+type Tracker interface {
+	// Issues returns the open and closed issues that have all the labels
+	Issues(labels []string) ([]Issue, error)
+	// Create opens a new issue and returns its ID
+	Create(issue Issue) (string, error)
+	// Update replaces the title and body of an issue
+	Update(issue Issue) error
+	// SetOpen reopens or closes an issue
+	SetOpen(issue Issue, open bool) error
+	// Markup returns the markup used in the body of issues
+	Markup() Markup
+}
+
+// NewTracker creates the tracker selected by the issueTracker argument.
+func NewTracker(octolintConfig *config.OctolintConfig, httpClient *http.Client) (Tracker, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: trackerTimeout}
+	}
+
+	api := restClient{
+		httpClient: httpClient,
+		baseUrl:    strings.TrimSuffix(octolintConfig.IssueUrl, "/"),
+		token:      octolintConfig.IssueToken,
+		username:   octolintConfig.IssueUsername,
+	}
+
+	switch octolintConfig.IssueTracker {
+	case TrackerGithub:
+		return GithubTracker{api: api, repository: octolintConfig.IssueRepository}, nil
+	case TrackerJira:
+		return JiraTracker{api: api, project: octolintConfig.IssueProject, issueType: octolintConfig.IssueType}, nil
+	}
+
+	return nil, errors.New("The -issueTracker argument must be " + TrackerGithub + " or " + TrackerJira)
+}
+
+// restClient sends JSON requests to the issue tracker.
+type restClient struct {
+	httpClient *http.Client
+	baseUrl    string
+	token      string
+	username   string
+	// accept is the value of the Accept header
+	accept string
+}
+
+// send calls the API, and unmarshals the response into output if it is not nil.
+func (o restClient) send(method string, path string, input any, output any) error {
+	var body io.Reader
+	if input != nil {
+		content, err := json.Marshal(input)
+
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequest(method, o.baseUrl+path, body)
+
+	if err != nil {
+		return err
+	}
+
+	accept := o.accept
+	if accept == "" {
+		accept = "application/json"
+	}
+
+	req.Header.Set("Accept", accept)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "octolint")
+
+	if o.username != "" {
+		req.SetBasicAuth(o.username, o.token)
+	} else if o.token != "" {
+		req.Header.Set("Authorization", "Bearer "+o.token)
+	}
+
+	resp, err := o.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("The issue tracker responded to " + method + " " + path + " with the status code " + fmt.Sprint(resp.StatusCode) + ": " + strings.TrimSpace(string(content)))
+	}
+
+	if output == nil || len(content) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(content, output)
+}
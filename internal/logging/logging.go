@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	FormatConsole = "console"
+	FormatJson    = "json"
+)
+
+// The names of the fields added to log lines. Log aggregation tools can rely on these fields being present on the log
+// lines written by checks rather than parsing the messages.
+const (
+	FieldCheckId     = "checkId"
+	FieldProject     = "project"
+	FieldSpace       = "space"
+	FieldRequestPath = "requestPath"
+)
+
+// NewLogger builds the logger used by a scan. The level is one of debug, info, warn or error, and defaults to debug if
+// verbose is true or info otherwise. Logs are written to stderr, or to the file if it is set.
+func NewLogger(format string, level string, file string, verbose bool) (*zap.Logger, error) {
+	if format == "" {
+		format = FormatConsole
+	}
+
+	if format != FormatConsole && format != FormatJson {
+		return nil, errors.New("The -logFormat argument must be " + FormatConsole + " or " + FormatJson)
+	}
+
+	zapLevel, err := ParseLevel(level, verbose)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "timestamp"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	outputPath := "stderr"
+	if file != "" {
+		outputPath = file
+	}
+
+	zapConfig := zap.Config{
+		Level:             zap.NewAtomicLevelAt(zapLevel),
+		Development:       false,
+		DisableCaller:     false,
+		DisableStacktrace: false,
+		Sampling:          nil,
+		Encoding:          format,
+		EncoderConfig:     encoderCfg,
+		OutputPaths: []string{
+			outputPath,
+		},
+		ErrorOutputPaths: []string{
+			"stderr",
+		},
+		InitialFields: map[string]interface{}{
+			"pid": os.Getpid(),
+		},
+	}
+
+	return zapConfig.Build()
+}
+
+// ParseLevel converts the name of a log level to a zap level. The verbose argument was the only way to enable debug
+// logs before the log level could be set, so it is still respected when no level is set.
+func ParseLevel(level string, verbose bool) (zapcore.Level, error) {
+	switch strings.ToLower(level) {
+	case "":
+		if verbose {
+			return zap.DebugLevel, nil
+		}
+		return zap.InfoLevel, nil
+	case "debug":
+		return zap.DebugLevel, nil
+	case "info":
+		return zap.InfoLevel, nil
+	case "warn":
+		return zap.WarnLevel, nil
+	case "error":
+		return zap.ErrorLevel, nil
+	}
+
+	return zap.InfoLevel, errors.New("The -logLevel argument must be debug, info, warn or error")
+}
+
+// Check returns the logger used by a check, which adds the check ID to every log line.
+func Check(checkId string) *zap.Logger {
+	return zap.L().With(CheckId(checkId))
+}
+
+func CheckId(checkId string) zap.Field {
+	return zap.String(FieldCheckId, checkId)
+}
+
+func Project(projectName string) zap.Field {
+	return zap.String(FieldProject, projectName)
+}
+
+func Space(spaceId string) zap.Field {
+	return zap.String(FieldSpace, spaceId)
+}
+
+func RequestPath(path string) zap.Field {
+	return zap.String(FieldRequestPath, path)
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observeLogs replaces the global logger with one that records the log lines written by a test.
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zap.DebugLevel)
+	restore := zap.ReplaceGlobals(zap.New(core))
+	t.Cleanup(restore)
+	return logs
+}
+
+func TestParseLevel(t *testing.T) {
+	if level, err := ParseLevel("", false); err != nil || level != zap.InfoLevel {
+		t.Fatal("Should have defaulted to the info level")
+	}
+
+	if level, err := ParseLevel("", true); err != nil || level != zap.DebugLevel {
+		t.Fatal("Should have used the debug level for verbose logs")
+	}
+
+	if level, err := ParseLevel("WARN", true); err != nil || level != zap.WarnLevel {
+		t.Fatal("Should have preferred the log level over the verbose argument")
+	}
+
+	if _, err := ParseLevel("trace", false); err == nil {
+		t.Fatal("Should have rejected an unknown level")
+	}
+}
+
+func TestJsonLogFile(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "octolint.log")
+
+	logger, err := NewLogger(FormatJson, "warn", logFile, false)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	logger.Info("Not written")
+	logger.With(Space("Spaces-1")).Warn("Written", CheckId("OctoLintEmptyProject"), Project("Web"))
+	_ = logger.Sync()
+
+	contents, err := os.ReadFile(logFile)
+
+	if err != nil {
+		t.Fatal("Should have written the log file: " + err.Error())
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+
+	if len(lines) != 1 {
+		t.Fatal("Should have only written the lines at or above the log level")
+	}
+
+	line := map[string]any{}
+	if err := json.Unmarshal([]byte(lines[0]), &line); err != nil {
+		t.Fatal("Should have written the log line as JSON: " + err.Error())
+	}
+
+	if line["msg"] != "Written" || line[FieldSpace] != "Spaces-1" || line[FieldCheckId] != "OctoLintEmptyProject" || line[FieldProject] != "Web" {
+		t.Fatal("Should have written the message and fields, got " + lines[0])
+	}
+}
+
+func TestInvalidFormat(t *testing.T) {
+	if _, err := NewLogger("xml", "", "", false); err == nil {
+		t.Fatal("Should have rejected an unknown format")
+	}
+}
+
+func TestCheckLogger(t *testing.T) {
+	logs := observeLogs(t)
+
+	Check("OctoLintEmptyProject").Debug("Starting check")
+
+	entries := logs.FilterField(CheckId("OctoLintEmptyProject")).All()
+
+	if len(entries) != 1 {
+		t.Fatal("Should have added the check ID to the log line")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	logs := observeLogs(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	resp, err := client.Get(server.URL + "/api/Spaces-1/projects")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+	resp.Body.Close()
+
+	entries := logs.FilterField(RequestPath("/api/Spaces-1/projects")).All()
+
+	if len(entries) != 1 || entries[0].Level != zapcore.DebugLevel || entries[0].ContextMap()["status"] != int64(http.StatusNotFound) {
+		t.Fatal("Should have logged the request path and status")
+	}
+}
+
+func TestCheckTransport(t *testing.T) {
+	logs := observeLogs(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewCheckTransport(NewTransport(nil), "OctoLintEmptyProject")}
+	resp, err := client.Get(server.URL + "/api/Spaces-1/projects")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+	resp.Body.Close()
+
+	entries := logs.FilterField(RequestPath("/api/Spaces-1/projects")).FilterField(CheckId("OctoLintEmptyProject")).All()
+
+	if len(entries) != 1 {
+		t.Fatal("Should have logged the request with the ID of the check that sent it")
+	}
+}
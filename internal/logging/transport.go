@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkIdKey is the context key holding the ID of the check that sent a request.
+type checkIdKey struct{}
+
+// Transport writes a debug log line for each request sent to the Octopus API, recording the request path, the
+// response status and how long the request took. Requests sent through a CheckTransport are logged with the check ID.
+type Transport struct {
+	Transport http.RoundTripper
+}
+
+func NewTransport(transport http.RoundTripper) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Transport{Transport: transport}
+}
+
+func (o *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := zap.L()
+	if checkId, ok := req.Context().Value(checkIdKey{}).(string); ok {
+		logger = Check(checkId)
+	}
+
+	start := time.Now()
+	resp, err := o.Transport.RoundTrip(req)
+
+	fields := []zap.Field{
+		RequestPath(req.URL.Path),
+		zap.String("method", req.Method),
+		zap.Duration("duration", time.Since(start)),
+	}
+
+	if err != nil {
+		logger.Debug("Octopus API request failed", append(fields, zap.Error(err))...)
+		return nil, err
+	}
+
+	logger.Debug("Octopus API request", append(fields, zap.Int("status", resp.StatusCode))...)
+
+	return resp, nil
+}
+
+// CheckTransport records the ID of a check in the context of each request it sends, so the log lines written by
+// Transport identify the check. The Octopus client does not pass a context with its requests, so a client is created
+// for each check with its own CheckTransport.
+type CheckTransport struct {
+	Transport http.RoundTripper
+	CheckId   string
+}
+
+func NewCheckTransport(transport http.RoundTripper, checkId string) *CheckTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &CheckTransport{Transport: transport, CheckId: checkId}
+}
+
+func (o *CheckTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return o.Transport.RoundTrip(req.WithContext(context.WithValue(req.Context(), checkIdKey{}, o.CheckId)))
+}
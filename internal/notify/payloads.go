@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatJson  = "json"
+	FormatSlack = "slack"
+	FormatTeams = "teams"
+)
+
+// Formats are the supported webhook body formats.
+var Formats = []string{FormatJson, FormatSlack, FormatTeams}
+
+// maxListedResults is the number of results listed in chat messages. The json format lists every result.
+const maxListedResults = 10
+
+// maxDescriptionLength is the number of characters of a result description included in chat messages.
+const maxDescriptionLength = 200
+
+// BuildBody returns the webhook body for the summary in the supplied format.
+func BuildBody(format string, summary Summary) ([]byte, error) {
+	switch format {
+	case FormatJson:
+		return json.Marshal(summary)
+	case FormatSlack:
+		return json.Marshal(slackMessage(summary))
+	case FormatTeams:
+		return json.Marshal(teamsMessage(summary))
+	}
+
+	return nil, errors.New("The webhook format must be one of " + strings.Join(Formats, ", ") + ", but was \"" + format + "\"")
+}
+
+// slackMessage builds a message with Block Kit blocks, which is accepted by Slack incoming webhooks.
+func slackMessage(summary Summary) map[string]any {
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{"type": "plain_text", "text": truncate(summary.Title(), 150)},
+		},
+		{
+			"type": "section",
+			"fields": []map[string]any{
+				{"type": "mrkdwn", "text": "*Space*\n<" + summary.Url + "/app#/" + summary.Space + "|" + summary.Space + ">"},
+				{"type": "mrkdwn", "text": "*Reason*\n" + summary.Reason},
+				{"type": "mrkdwn", "text": "*Checks*\n" + fmt.Sprint(summary.Checks) + " run, " + fmt.Sprint(summary.FailedChecks) + " failed"},
+				{"type": "mrkdwn", "text": "*Changes since the last scan*\n" + changes(summary)},
+			},
+		},
+	}
+
+	lines := []string{}
+	for _, result := range listedResults(summary) {
+		lines = append(lines, "• *"+result.Severity+"* `"+result.Code+"`: "+truncate(result.Description, maxDescriptionLength))
+	}
+
+	if len(lines) != 0 {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": strings.Join(lines, "\n")},
+		})
+	}
+
+	if more := len(summary.Results) - maxListedResults; more > 0 {
+		blocks = append(blocks, map[string]any{
+			"type":     "context",
+			"elements": []map[string]any{{"type": "mrkdwn", "text": "and " + pluralize(more, "more check")}},
+		})
+	}
+
+	return map[string]any{
+		// The text is displayed in notifications, where blocks are not rendered
+		"text":   summary.Title(),
+		"blocks": blocks,
+	}
+}
+
+// teamsMessage builds a message with an Adaptive Card, which is accepted by Teams workflows and incoming webhooks.
+func teamsMessage(summary Summary) map[string]any {
+	body := []map[string]any{
+		{"type": "TextBlock", "text": summary.Title(), "size": "Large", "weight": "Bolder", "wrap": true},
+		{"type": "FactSet", "facts": []map[string]any{
+			{"title": "Space", "value": summary.Space},
+			{"title": "Reason", "value": summary.Reason},
+			{"title": "Checks", "value": fmt.Sprint(summary.Checks) + " run, " + fmt.Sprint(summary.FailedChecks) + " failed"},
+			{"title": "Changes", "value": changes(summary)},
+		}},
+	}
+
+	for _, result := range listedResults(summary) {
+		body = append(body, map[string]any{
+			"type": "TextBlock",
+			"text": "**" + result.Severity + "** " + result.Code + ": " + truncate(result.Description, maxDescriptionLength),
+			"wrap": true,
+		})
+	}
+
+	if more := len(summary.Results) - maxListedResults; more > 0 {
+		body = append(body, map[string]any{"type": "TextBlock", "text": "and " + pluralize(more, "more check"), "isSubtle": true})
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+				"actions": []map[string]any{{"type": "Action.OpenUrl", "title": "Open the space", "url": summary.Url + "/app#/" + summary.Space}},
+			},
+		}},
+	}
+}
+
+func listedResults(summary Summary) []SummaryResult {
+	if len(summary.Results) > maxListedResults {
+		return summary.Results[:maxListedResults]
+	}
+
+	return summary.Results
+}
+
+func changes(summary Summary) string {
+	return fmt.Sprint(summary.AddedFindings) + " new, " + fmt.Sprint(summary.RemovedFindings) + " resolved"
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+
+	if len(runes) <= length {
+		return text
+	}
+
+	return string(runes[:length-3]) + "..."
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprint(count) + " " + noun + "s"
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/diff"
+)
+
+// Summary describes a scan, and is the body posted to webhooks in the json format.
+type Summary struct {
+	Url       string    `json:"url"`
+	Space     string    `json:"space"`
+	Timestamp time.Time `json:"timestamp"`
+	// Reason explains why the notification was sent
+	Reason       string `json:"reason"`
+	Checks       int    `json:"checks"`
+	FailedChecks int    `json:"failedChecks"`
+	Findings     int    `json:"findings"`
+	// Severities counts the checks that reported each severity
+	Severities map[string]int `json:"severities"`
+	// AddedFindings and RemovedFindings count the changes since the last scan saved in the state file
+	AddedFindings   int             `json:"addedFindings"`
+	RemovedFindings int             `json:"removedFindings"`
+	Results         []SummaryResult `json:"results"`
+}
+
+// SummaryResult describes a check that reported a severity of Info or higher.
+type SummaryResult struct {
+	Code        string `json:"code"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Link        string `json:"link,omitempty"`
+	Findings    int    `json:"findings"`
+}
+
+// NewSummary summarizes the results of a scan. The changes are nil if the findings were not compared to the last
+// scan.
+func NewSummary(url string, space string, timestamp time.Time, results []checks.OctopusCheckResult, changes *diff.ReportDiff) Summary {
+	summary := Summary{
+		Url:        strings.TrimSuffix(url, "/"),
+		Space:      space,
+		Timestamp:  timestamp.UTC(),
+		Severities: map[string]int{},
+		Results:    []SummaryResult{},
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		summary.Checks++
+		summary.Severities[checks.SeverityName(result.Severity())]++
+
+		if result.Severity() < checks.Info {
+			continue
+		}
+
+		findings := checks.FindingCount(result)
+		if findings != 0 {
+			summary.FailedChecks++
+			summary.Findings += findings
+		}
+
+		summary.Results = append(summary.Results, SummaryResult{
+			Code:        result.Code(),
+			Category:    result.Category(),
+			Severity:    checks.SeverityName(result.Severity()),
+			Description: result.Description(),
+			Link:        result.Link(),
+			Findings:    findings,
+		})
+	}
+
+	// The most severe results are listed first, as chat messages only display the first few
+	sort.SliceStable(summary.Results, func(i, j int) bool {
+		iSeverity, _ := checks.ParseSeverity(summary.Results[i].Severity)
+		jSeverity, _ := checks.ParseSeverity(summary.Results[j].Severity)
+
+		if iSeverity != jSeverity {
+			return iSeverity > jSeverity
+		}
+
+		return summary.Results[i].Code < summary.Results[j].Code
+	})
+
+	if changes != nil {
+		summary.AddedFindings = changes.Summary.Added
+		summary.RemovedFindings = changes.Summary.Removed
+	}
+
+	return summary
+}
+
+// Title is the heading of the chat messages.
+func (o Summary) Title() string {
+	return "Octolint found " + pluralize(o.Findings, "finding") + " in " + pluralize(o.FailedChecks, "check") + " in " + o.Space
+}
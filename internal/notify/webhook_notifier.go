@@ -0,0 +1,267 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/diff"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"github.com/avast/retry-go/v4"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// SignatureHeader holds the HMAC-SHA256 signature of the body, as "sha256=" followed by the hex encoded signature,
+// when the webhookSecret argument is set.
+const SignatureHeader = "X-Octolint-Signature"
+
+// webhookTimeout is the time allowed for each post to a webhook.
+const webhookTimeout = 30 * time.Second
+
+// webhookState is the file that records the results of the last scan, so the next scan can report what changed.
+type webhookState struct {
+	Url    string               `json:"url"`
+	Space  string               `json:"space"`
+	Report reporters.JsonReport `json:"report"`
+}
+
+// WebhookNotifier posts a summary of a scan to the webhooks in the config. A post is only sent when the conditions
+// set by the webhookSeverity and webhookOnChange arguments are met, or after every scan when neither is set.
+type WebhookNotifier struct {
+	config     *config.OctolintConfig
+	httpClient *http.Client
+	retryDelay time.Duration
+}
+
+func NewWebhookNotifier(config *config.OctolintConfig) WebhookNotifier {
+	return WebhookNotifier{config: config, httpClient: &http.Client{Timeout: webhookTimeout}, retryDelay: time.Second}
+}
+
+// WithRetryDelay returns a notifier that waits for the supplied delay before the first retry. The delay doubles
+// with each retry.
+func (o WebhookNotifier) WithRetryDelay(delay time.Duration) WebhookNotifier {
+	o.retryDelay = delay
+	return o
+}
+
+// Validate returns an error if the webhook arguments are invalid, so the mistake is reported before the scan starts.
+func Validate(octolintConfig *config.OctolintConfig) error {
+	if len(octolintConfig.WebhookUrls) == 0 {
+		return nil
+	}
+
+	if octolintConfig.WebhookSeverity != "" {
+		if _, err := checks.ParseSeverity(octolintConfig.WebhookSeverity); err != nil {
+			return err
+		}
+	}
+
+	for _, webhook := range octolintConfig.WebhookUrls {
+		format, _ := parseWebhook(webhook, octolintConfig.WebhookFormat)
+
+		if !lo.Contains(Formats, format) {
+			return errors.New("The -webhookFormat argument must be one of " + strings.Join(Formats, ", ") + ", but was \"" + format + "\"")
+		}
+	}
+
+	return nil
+}
+
+// Notify posts the summary of the scan to each webhook, and returns true if the summary was sent. The results are
+// saved to the webhookStateFile unless a post failed, so the changes are reported again by the next scan.
+func (o WebhookNotifier) Notify(url string, space string, timestamp time.Time, results []checks.OctopusCheckResult) (bool, error) {
+	if len(o.config.WebhookUrls) == 0 {
+		return false, nil
+	}
+
+	if err := Validate(o.config); err != nil {
+		return false, err
+	}
+
+	report := reporters.JsonReport{Results: []reporters.JsonCheckResult{}}
+	for _, result := range results {
+		if result != nil {
+			report.Results = append(report.Results, reporters.NewJsonCheckResult(result))
+		}
+	}
+
+	var changes *diff.ReportDiff
+	if o.config.WebhookStateFile != "" {
+		previous, err := o.loadState(url, space)
+
+		if err != nil {
+			return false, err
+		}
+
+		changes, err = diff.Compare(previous, &report, diff.MatchById)
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	summary := NewSummary(url, space, timestamp, results, changes)
+	summary.Reason = o.reason(results, changes)
+
+	if summary.Reason == "" {
+		zap.L().Info("Did not post to the webhooks because the notification conditions were not met")
+		return false, o.saveState(url, space, report)
+	}
+
+	sendErrors := []error{}
+	for _, webhook := range o.config.WebhookUrls {
+		format, webhookUrl := parseWebhook(webhook, o.config.WebhookFormat)
+
+		body, err := BuildBody(format, summary)
+
+		if err == nil {
+			err = o.post(webhookUrl, body)
+		}
+
+		if err != nil {
+			sendErrors = append(sendErrors, err)
+		}
+	}
+
+	if len(sendErrors) != 0 {
+		return false, errors.Join(sendErrors...)
+	}
+
+	return true, o.saveState(url, space, report)
+}
+
+// reason explains why the summary is sent, or returns an empty string if it should not be sent.
+func (o WebhookNotifier) reason(results []checks.OctopusCheckResult, changes *diff.ReportDiff) string {
+	if o.config.WebhookSeverity == "" && !o.config.WebhookOnChange {
+		return "The scan completed"
+	}
+
+	if o.config.WebhookSeverity != "" {
+		threshold, _ := checks.ParseSeverity(o.config.WebhookSeverity)
+
+		if lo.ContainsBy(results, func(result checks.OctopusCheckResult) bool { return result != nil && result.Severity() >= threshold }) {
+			return "A check failed with a severity of " + checks.SeverityName(threshold) + " or higher"
+		}
+	}
+
+	if o.config.WebhookOnChange && changes != nil && changes.Summary.Added+changes.Summary.Removed != 0 {
+		return "The findings changed since the last scan"
+	}
+
+	return ""
+}
+
+// post sends the body to the webhook, retrying server errors and failed connections.
+func (o WebhookNotifier) post(webhookUrl string, body []byte) error {
+	return retry.Do(
+		func() error {
+			req, err := http.NewRequest(http.MethodPost, webhookUrl, bytes.NewReader(body))
+
+			if err != nil {
+				return retry.Unrecoverable(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "octolint")
+
+			if o.config.WebhookSecret != "" {
+				req.Header.Set(SignatureHeader, Sign(o.config.WebhookSecret, body))
+			}
+
+			resp, err := o.httpClient.Do(req)
+
+			if err != nil {
+				return err
+			}
+
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, resp.Body)
+
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return nil
+			}
+
+			// The URL of a webhook often contains a secret, so only the host is included in the error
+			err = errors.New("The webhook " + req.URL.Host + " responded with the status code " + fmt.Sprint(resp.StatusCode))
+
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+				return err
+			}
+
+			return retry.Unrecoverable(err)
+		},
+		retry.Attempts(uint(max(o.config.WebhookRetries, 0))+1),
+		retry.Delay(o.retryDelay),
+		retry.LastErrorOnly(true))
+}
+
+// Sign returns the value of the SignatureHeader for the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseWebhook splits a webhook argument into the format and the URL. The format can be set for each webhook by
+// prefixing the URL with the format and an equals sign.
+func parseWebhook(webhook string, defaultFormat string) (string, string) {
+	if format, webhookUrl, found := strings.Cut(webhook, "="); found && !strings.Contains(format, "/") {
+		return strings.ToLower(format), webhookUrl
+	}
+
+	if defaultFormat == "" {
+		return FormatJson, webhook
+	}
+
+	return strings.ToLower(defaultFormat), webhook
+}
+
+// loadState returns the report saved by the last scan of the space, or nil if the space has not been scanned.
+func (o WebhookNotifier) loadState(url string, space string) (*reporters.JsonReport, error) {
+	content, err := os.ReadFile(o.config.WebhookStateFile)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	state := webhookState{}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, errors.New("The webhook state file " + o.config.WebhookStateFile + " is not valid: " + err.Error())
+	}
+
+	if state.Url != strings.TrimSuffix(url, "/") || state.Space != space {
+		return nil, nil
+	}
+
+	return &state.Report, nil
+}
+
+func (o WebhookNotifier) saveState(url string, space string, report reporters.JsonReport) error {
+	if o.config.WebhookStateFile == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(webhookState{Url: strings.TrimSuffix(url, "/"), Space: space, Report: report})
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(o.config.WebhookStateFile, content, 0644)
+}
@@ -0,0 +1,252 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// receiver records the requests posted to a local webhook, and responds with the next status code in the list.
+type receiver struct {
+	mutex    sync.Mutex
+	server   *httptest.Server
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		body, _ := io.ReadAll(req.Body)
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+
+		status := http.StatusOK
+		if len(r.statuses) != 0 {
+			status = r.statuses[0]
+			r.statuses = r.statuses[1:]
+		}
+
+		w.WriteHeader(status)
+	}))
+
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+func warningResult(resourceIds ...string) checks.OctopusCheckResult {
+	findings := []checks.OctopusCheckFinding{}
+	for _, id := range resourceIds {
+		findings = append(findings, checks.OctopusCheckFinding{ResourceType: "Project", ResourceId: id, ResourceName: id})
+	}
+
+	return checks.NewOctopusCheckResultImplWithFindings("Projects are empty", "OctoLintEmptyProject", "", checks.Warning, checks.Organization, findings)
+}
+
+func okResult() checks.OctopusCheckResult {
+	return checks.NewOctopusCheckResultImpl("The number of environments is OK", "OctoLintEnvironmentCount", "", checks.Ok, checks.Organization)
+}
+
+func newTestNotifier(t *testing.T, octolintConfig *config.OctolintConfig) WebhookNotifier {
+	if octolintConfig.WebhookStateFile == "" {
+		octolintConfig.WebhookStateFile = filepath.Join(t.TempDir(), "state.json")
+	}
+
+	return NewWebhookNotifier(octolintConfig).WithRetryDelay(time.Millisecond)
+}
+
+func TestNotifyPostsSignedSummary(t *testing.T) {
+	webhook := newReceiver(t)
+	notifier := newTestNotifier(t, &config.OctolintConfig{
+		WebhookUrls:     config.StringSliceArgs{webhook.server.URL},
+		WebhookSeverity: "Warning",
+		WebhookSecret:   "secret",
+	})
+
+	sent, err := notifier.Notify("https://example.octopus.app/", "Spaces-1", time.Now(), []checks.OctopusCheckResult{warningResult("Projects-1", "Projects-2"), okResult()})
+
+	if err != nil || !sent || len(webhook.bodies) != 1 {
+		t.Fatal("Should have posted the summary")
+	}
+
+	if webhook.requests[0].Header.Get(SignatureHeader) != Sign("secret", webhook.bodies[0]) {
+		t.Fatal("Should have signed the body with the secret")
+	}
+
+	summary := Summary{}
+	if err := json.Unmarshal(webhook.bodies[0], &summary); err != nil {
+		t.Fatal("Should have posted a JSON summary: " + err.Error())
+	}
+
+	if summary.Url != "https://example.octopus.app" || summary.Space != "Spaces-1" || summary.Checks != 2 || summary.FailedChecks != 1 ||
+		summary.Findings != 2 || summary.Severities["Warning"] != 1 || len(summary.Results) != 1 || summary.Results[0].Code != "OctoLintEmptyProject" {
+		t.Fatal("Should have summarized the results, got: " + string(webhook.bodies[0]))
+	}
+
+	if !strings.Contains(summary.Reason, "Warning") {
+		t.Fatal("Should have explained why the summary was sent")
+	}
+}
+
+func TestNotifySeverityThreshold(t *testing.T) {
+	webhook := newReceiver(t)
+	notifier := newTestNotifier(t, &config.OctolintConfig{
+		WebhookUrls:     config.StringSliceArgs{webhook.server.URL},
+		WebhookSeverity: "Error",
+	})
+
+	sent, err := notifier.Notify("https://example.octopus.app", "Spaces-1", time.Now(), []checks.OctopusCheckResult{warningResult("Projects-1")})
+
+	if err != nil || sent || len(webhook.bodies) != 0 {
+		t.Fatal("Should not have posted results below the severity threshold")
+	}
+}
+
+func TestNotifyOnChange(t *testing.T) {
+	webhook := newReceiver(t)
+	notifier := newTestNotifier(t, &config.OctolintConfig{
+		WebhookUrls:     config.StringSliceArgs{webhook.server.URL},
+		WebhookOnChange: true,
+	})
+
+	runs := []struct {
+		results []checks.OctopusCheckResult
+		sent    bool
+		added   int
+		removed int
+	}{
+		{[]checks.OctopusCheckResult{warningResult("Projects-1")}, true, 1, 0},
+		{[]checks.OctopusCheckResult{warningResult("Projects-1")}, false, 0, 0},
+		{[]checks.OctopusCheckResult{warningResult("Projects-2")}, true, 1, 1},
+	}
+
+	for i, run := range runs {
+		posts := len(webhook.bodies)
+		sent, err := notifier.Notify("https://example.octopus.app", "Spaces-1", time.Now(), run.results)
+
+		if err != nil || sent != run.sent {
+			t.Fatalf("Should have posted the summary of run %d only if the findings changed", i)
+		}
+
+		if !run.sent {
+			continue
+		}
+
+		summary := Summary{}
+		_ = json.Unmarshal(webhook.bodies[posts], &summary)
+
+		if summary.AddedFindings != run.added || summary.RemovedFindings != run.removed {
+			t.Fatalf("Should have counted the changes in run %d, got: %s", i, string(webhook.bodies[posts]))
+		}
+	}
+
+	// The state is saved per space, so the first scan of another space is a change
+	if sent, _ := notifier.Notify("https://example.octopus.app", "Spaces-2", time.Now(), []checks.OctopusCheckResult{warningResult("Projects-2")}); !sent {
+		t.Fatal("Should have compared the findings to the last scan of the same space")
+	}
+}
+
+func TestNotifyRetries(t *testing.T) {
+	webhook := newReceiver(t, http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK)
+	notifier := newTestNotifier(t, &config.OctolintConfig{
+		WebhookUrls:    config.StringSliceArgs{webhook.server.URL},
+		WebhookRetries: 3,
+	})
+
+	if sent, err := notifier.Notify("https://example.octopus.app", "Spaces-1", time.Now(), []checks.OctopusCheckResult{okResult()}); err != nil || !sent {
+		t.Fatal("Should have retried the failed posts")
+	}
+
+	if len(webhook.bodies) != 3 {
+		t.Fatal("Should have posted until the webhook succeeded")
+	}
+}
+
+func TestNotifyDoesNotRetryClientErrors(t *testing.T) {
+	webhook := newReceiver(t, http.StatusBadRequest)
+	octolintConfig := &config.OctolintConfig{
+		WebhookUrls:     config.StringSliceArgs{webhook.server.URL},
+		WebhookOnChange: true,
+		WebhookRetries:  3,
+	}
+	notifier := newTestNotifier(t, octolintConfig)
+
+	sent, err := notifier.Notify("https://example.octopus.app", "Spaces-1", time.Now(), []checks.OctopusCheckResult{warningResult("Projects-1")})
+
+	if err == nil || sent || len(webhook.bodies) != 1 {
+		t.Fatal("Should have returned the error without retrying")
+	}
+
+	if strings.Contains(err.Error(), webhook.server.URL) {
+		t.Fatal("Should not have included the webhook URL in the error")
+	}
+
+	// The state was not saved, so the same findings are reported again
+	if sent, err := notifier.Notify("https://example.octopus.app", "Spaces-1", time.Now(), []checks.OctopusCheckResult{warningResult("Projects-1")}); err != nil || !sent {
+		t.Fatal("Should have posted the changes that failed to send")
+	}
+}
+
+func TestNotifyChatFormats(t *testing.T) {
+	slack := newReceiver(t)
+	teams := newReceiver(t)
+	notifier := newTestNotifier(t, &config.OctolintConfig{
+		WebhookUrls: config.StringSliceArgs{"slack=" + slack.server.URL, "teams=" + teams.server.URL},
+	})
+
+	results := []checks.OctopusCheckResult{}
+	for i := 0; i < maxListedResults+2; i++ {
+		results = append(results, checks.NewOctopusCheckResultImpl("Found a problem", "Check"+string(rune('A'+i)), "", checks.Warning, checks.Security))
+	}
+
+	if _, err := notifier.Notify("https://example.octopus.app", "Spaces-1", time.Now(), results); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	slackMessage := map[string]any{}
+	if err := json.Unmarshal(slack.bodies[0], &slackMessage); err != nil || slackMessage["blocks"] == nil || slackMessage["text"] == nil {
+		t.Fatal("Should have posted Slack blocks, got: " + string(slack.bodies[0]))
+	}
+
+	if !strings.Contains(string(slack.bodies[0]), "and 2 more checks") {
+		t.Fatal("Should have limited the number of checks listed in the message")
+	}
+
+	teamsMessage := map[string]any{}
+	if err := json.Unmarshal(teams.bodies[0], &teamsMessage); err != nil || !strings.Contains(string(teams.bodies[0]), "application/vnd.microsoft.card.adaptive") {
+		t.Fatal("Should have posted a Teams adaptive card, got: " + string(teams.bodies[0]))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if Validate(&config.OctolintConfig{WebhookUrls: config.StringSliceArgs{"https://example.org/hook"}, WebhookFormat: "xml"}) == nil {
+		t.Fatal("Should have rejected an unsupported format")
+	}
+
+	if Validate(&config.OctolintConfig{WebhookUrls: config.StringSliceArgs{"discord=https://example.org/hook"}}) == nil {
+		t.Fatal("Should have rejected an unsupported format prefix")
+	}
+
+	if Validate(&config.OctolintConfig{WebhookUrls: config.StringSliceArgs{"https://example.org/hook"}, WebhookSeverity: "Critical"}) == nil {
+		t.Fatal("Should have rejected an unsupported severity")
+	}
+
+	if Validate(&config.OctolintConfig{WebhookUrls: config.StringSliceArgs{"https://example.org/hook?token=abc"}, WebhookSeverity: "Error"}) != nil {
+		t.Fatal("Should have accepted a URL with a query string")
+	}
+}
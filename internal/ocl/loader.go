@@ -0,0 +1,297 @@
+package ocl
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+const DeploymentProcessFile = "deployment_process.ocl"
+const VariablesFile = "variables.ocl"
+const RunbooksDirectory = "runbooks"
+
+// LoadProjects finds the Config-as-Code projects saved under a directory and loads their deployment processes,
+// runbooks, and variables. A project is any directory with a deployment_process.ocl file, a variables.ocl file,
+// or a runbooks directory, which is typically the .octopus directory of a git repository. Projects are named
+// after their directory, or the parent directory of a .octopus directory.
+//
+// Resources are given IDs that identify the file and line they were defined on, and file names are relative to
+// the supplied directory.
+func LoadProjects(directory string) ([]checks.OctopusOfflineProject, error) {
+	projectDirectories := []string{}
+
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		if entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if isProjectDirectory(path) {
+			projectDirectories = append(projectDirectories, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	offlineProjects := []checks.OctopusOfflineProject{}
+	for _, projectDirectory := range projectDirectories {
+		offlineProject, err := loadProject(directory, projectDirectory)
+
+		if err != nil {
+			return nil, err
+		}
+
+		offlineProjects = append(offlineProjects, offlineProject)
+	}
+
+	return offlineProjects, nil
+}
+
+func isProjectDirectory(path string) bool {
+	for _, name := range []string{DeploymentProcessFile, VariablesFile} {
+		if info, err := os.Stat(filepath.Join(path, name)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+
+	runbookFiles, _ := filepath.Glob(filepath.Join(path, RunbooksDirectory, "*.ocl"))
+	return len(runbookFiles) != 0
+}
+
+func loadProject(root string, projectDirectory string) (checks.OctopusOfflineProject, error) {
+	relativeDirectory, err := filepath.Rel(root, projectDirectory)
+
+	if err != nil {
+		return checks.OctopusOfflineProject{}, err
+	}
+
+	project := projects.NewProject(projectName(projectDirectory), "", "")
+	project.ID = filepath.ToSlash(relativeDirectory)
+	if project.ID == "." {
+		project.ID = project.Name
+	}
+
+	offlineProject := checks.OctopusOfflineProject{
+		Project:   project,
+		Locations: map[string]checks.SourceLocation{},
+	}
+
+	relativePath := func(name string) string {
+		return filepath.ToSlash(filepath.Join(relativeDirectory, name))
+	}
+
+	deploymentProcessFile := filepath.Join(projectDirectory, DeploymentProcessFile)
+	if deploymentProcess, found, err := parseFile(deploymentProcessFile, relativePath(DeploymentProcessFile)); err != nil {
+		return checks.OctopusOfflineProject{}, err
+	} else if found {
+		offlineProject.DeploymentProcess = deployments.NewDeploymentProcess(project.ID)
+		offlineProject.DeploymentProcess.Steps = buildSteps(relativePath(DeploymentProcessFile), deploymentProcess, offlineProject.Locations)
+		offlineProject.Locations[project.ID] = checks.SourceLocation{File: relativePath(DeploymentProcessFile), Line: 1}
+	}
+
+	runbookFiles, err := filepath.Glob(filepath.Join(projectDirectory, RunbooksDirectory, "*.ocl"))
+
+	if err != nil {
+		return checks.OctopusOfflineProject{}, err
+	}
+
+	sort.Strings(runbookFiles)
+
+	for _, runbookFile := range runbookFiles {
+		file := relativePath(filepath.Join(RunbooksDirectory, filepath.Base(runbookFile)))
+		runbook, _, err := parseFile(runbookFile, file)
+
+		if err != nil {
+			return checks.OctopusOfflineProject{}, err
+		}
+
+		runbookProcess := runbooks.NewRunbookProcess()
+		runbookProcess.ProjectID = project.ID
+		runbookProcess.RunbookID = strings.TrimSuffix(filepath.Base(runbookFile), ".ocl")
+		runbookProcess.Steps = buildSteps(file, runbook, offlineProject.Locations)
+
+		// The steps of a runbook may be defined at the top level or in a process block
+		for _, process := range runbook.BlocksOfType("process") {
+			runbookProcess.Steps = append(runbookProcess.Steps, buildSteps(file, process, offlineProject.Locations)...)
+		}
+
+		offlineProject.RunbookProcesses = append(offlineProject.RunbookProcesses, runbookProcess)
+	}
+
+	variablesFile := filepath.Join(projectDirectory, VariablesFile)
+	if variableSet, found, err := parseFile(variablesFile, relativePath(VariablesFile)); err != nil {
+		return checks.OctopusOfflineProject{}, err
+	} else if found {
+		offlineProject.Variables = variables.NewVariableSet()
+		offlineProject.Variables.OwnerID = project.ID
+		offlineProject.Variables.Variables = buildVariables(relativePath(VariablesFile), variableSet, offlineProject.Locations)
+	}
+
+	return offlineProject, nil
+}
+
+// projectName returns the name of the directory holding the project files, or the name of the parent directory
+// if the files are in a .octopus directory.
+func projectName(projectDirectory string) string {
+	absolute, err := filepath.Abs(projectDirectory)
+
+	if err != nil {
+		absolute = projectDirectory
+	}
+
+	if filepath.Base(absolute) == ".octopus" {
+		return filepath.Base(filepath.Dir(absolute))
+	}
+
+	return filepath.Base(absolute)
+}
+
+// parseFile parses an OCL file, returning false if the file does not exist.
+func parseFile(path string, name string) (*Block, bool, error) {
+	content, err := os.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	block, err := Parse(name, string(content))
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return block, true, nil
+}
+
+// locate records where a resource was defined, returning the location as the ID of the resource.
+func locate(file string, line int, locations map[string]checks.SourceLocation) string {
+	location := checks.SourceLocation{File: file, Line: line}
+	locations[location.String()] = location
+	return location.String()
+}
+
+func buildSteps(file string, parent *Block, locations map[string]checks.SourceLocation) []*deployments.DeploymentStep {
+	steps := []*deployments.DeploymentStep{}
+
+	for _, stepBlock := range parent.BlocksOfType("step") {
+		name := stepBlock.String("name")
+		if name == "" {
+			name = stepBlock.Label()
+		}
+
+		step := deployments.NewDeploymentStep(name)
+		step.ID = locate(file, stepBlock.Line, locations)
+		step.Properties = propertyValues(stepBlock.Map("properties"))
+
+		for _, actionBlock := range stepBlock.BlocksOfType("action") {
+			// Steps with a single action typically leave the action unnamed, in which case it shares the step name
+			actionName := actionBlock.String("name")
+			if actionName == "" {
+				actionName = name
+			}
+
+			action := deployments.NewDeploymentAction(actionName, actionBlock.String("action_type"))
+			action.ID = locate(file, actionBlock.Line, locations)
+			action.Slug = actionBlock.Label()
+			action.Properties = propertyValues(actionBlock.Map("properties"))
+			action.WorkerPool = actionBlock.String("worker_pool")
+			action.WorkerPoolVariable = actionBlock.String("worker_pool_variable")
+			action.IsDisabled = strings.EqualFold(actionBlock.String("is_disabled"), "true")
+			action.Environments = actionBlock.Strings("environments")
+			action.ExcludedEnvironments = actionBlock.Strings("excluded_environments")
+			action.Channels = actionBlock.Strings("channels")
+
+			for _, containerBlock := range actionBlock.BlocksOfType("container") {
+				action.Container = &deployments.DeploymentActionContainer{
+					FeedID: containerBlock.String("feed"),
+					Image:  containerBlock.String("image"),
+				}
+			}
+
+			for _, packageBlock := range actionBlock.BlocksOfType("packages") {
+				action.Packages = append(action.Packages, &packages.PackageReference{
+					AcquisitionLocation: packageBlock.String("acquisition_location"),
+					FeedID:              packageBlock.String("feed"),
+					Name:                packageBlock.Label(),
+					PackageID:           packageBlock.String("package_id"),
+					Properties:          packageBlock.Map("properties"),
+				})
+			}
+
+			step.Actions = append(step.Actions, action)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+func buildVariables(file string, parent *Block, locations map[string]checks.SourceLocation) []*variables.Variable {
+	projectVariables := []*variables.Variable{}
+
+	for _, variableBlock := range parent.BlocksOfType("variable") {
+		valueBlocks := variableBlock.BlocksOfType("value")
+
+		// A variable without any values still defines the name, so treat it as a single empty value
+		if len(valueBlocks) == 0 {
+			valueBlocks = []*Block{{Line: variableBlock.Line}}
+		}
+
+		for _, valueBlock := range valueBlocks {
+			variable := variables.NewVariable(variableBlock.Label())
+			variable.ID = locate(file, valueBlock.Line, locations)
+			variable.Value = valueBlock.Label()
+			variable.Description = valueBlock.String("description")
+			variable.Scope = variables.VariableScope{
+				Environments: valueBlock.Strings("environment"),
+				Machines:     valueBlock.Strings("machine"),
+				Actions:      valueBlock.Strings("action"),
+				Roles:        valueBlock.Strings("role"),
+				Channels:     valueBlock.Strings("channel"),
+				TenantTags:   valueBlock.Strings("tenant_tag"),
+			}
+
+			if variableType := valueBlock.String("type"); variableType != "" {
+				variable.Type = variableType
+			}
+
+			projectVariables = append(projectVariables, variable)
+		}
+	}
+
+	return projectVariables
+}
+
+func propertyValues(properties map[string]string) map[string]core.PropertyValue {
+	values := map[string]core.PropertyValue{}
+	for key, value := range properties {
+		values[key] = core.NewPropertyValue(value, false)
+	}
+	return values
+}
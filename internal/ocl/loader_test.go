@@ -0,0 +1,63 @@
+package ocl
+
+import (
+	"testing"
+)
+
+func TestLoadProjects(t *testing.T) {
+	offlineProjects, err := LoadProjects("testdata")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if len(offlineProjects) != 1 {
+		t.Fatal("Should have found 1 project")
+	}
+
+	project := offlineProjects[0]
+
+	if project.Project.Name != "app" {
+		t.Fatal("Should have named the project after the parent of the .octopus directory")
+	}
+
+	if project.DeploymentProcess == nil || len(project.DeploymentProcess.Steps) != 2 {
+		t.Fatal("Should have loaded 2 deployment process steps")
+	}
+
+	action := project.DeploymentProcess.Steps[0].Actions[0]
+
+	if action.Name != "Run a Script" || action.WorkerPool != "hosted-windows" || action.Container.Image != "octopusdeploy/worker-tools:latest" {
+		t.Fatal("Should have loaded the action of a single action step with the step name")
+	}
+
+	if location := project.Locations[action.ID]; location.File != "app/.octopus/deployment_process.ocl" || location.Line != 4 {
+		t.Fatal("Should have recorded the location of the action, got " + location.String())
+	}
+
+	if project.DeploymentProcess.Steps[1].Actions[0].Packages[0].PackageID != "#{Package.Id}" {
+		t.Fatal("Should have loaded the packages")
+	}
+
+	if len(project.RunbookProcesses) != 1 || len(project.RunbookProcesses[0].Steps) != 1 {
+		t.Fatal("Should have loaded the runbook steps from the process block")
+	}
+
+	if len(project.AllSteps()) != 3 {
+		t.Fatal("Should have returned the deployment process and runbook steps")
+	}
+
+	if project.Variables == nil || len(project.Variables.Variables) != 5 {
+		t.Fatal("Should have loaded a variable for each value")
+	}
+
+	variable := project.Variables.Variables[0]
+
+	if variable.Name != "Database.Name" || variable.Value != "app" || len(variable.Scope.Environments) != 1 {
+		t.Fatal("Should have loaded the variable value and scope")
+	}
+
+	if location := project.Locations[variable.ID]; location.Line != 2 {
+		t.Fatal("Should have recorded the location of the variable value, got " + location.String())
+	}
+}
@@ -0,0 +1,500 @@
+package ocl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ValueKind identifies the type of value assigned to an attribute.
+type ValueKind int
+
+const (
+	StringValue ValueKind = iota
+	ListValue
+	MapValue
+)
+
+// Block is a block in an OCL file, like `step "deploy" { ... }`. The contents of a file are returned as a
+// block with an empty type.
+type Block struct {
+	Type       string
+	Labels     []string
+	Attributes []*Attribute
+	Blocks     []*Block
+	Line       int
+}
+
+// Attribute is an assignment in an OCL file, like `name = "Deploy"`.
+type Attribute struct {
+	Name  string
+	Value Value
+	Line  int
+}
+
+// Value is the value assigned to an attribute. Strings, heredocs, numbers, and booleans are all returned as
+// strings.
+type Value struct {
+	Kind   ValueKind
+	String string
+	List   []Value
+	Map    []*Attribute
+}
+
+// Label returns the first label of the block, or an empty string if the block has no labels.
+func (o *Block) Label() string {
+	if len(o.Labels) == 0 {
+		return ""
+	}
+
+	return o.Labels[0]
+}
+
+// Attribute returns the attribute with the supplied name, or nil if the block does not define it.
+func (o *Block) Attribute(name string) *Attribute {
+	for _, attribute := range o.Attributes {
+		if attribute.Name == name {
+			return attribute
+		}
+	}
+
+	return nil
+}
+
+// String returns the string value of an attribute, or an empty string if the block does not define it.
+func (o *Block) String(name string) string {
+	attribute := o.Attribute(name)
+
+	if attribute == nil {
+		return ""
+	}
+
+	return attribute.Value.String
+}
+
+// Strings returns the values of a list attribute, or nil if the block does not define it.
+func (o *Block) Strings(name string) []string {
+	attribute := o.Attribute(name)
+
+	if attribute == nil {
+		return nil
+	}
+
+	if attribute.Value.Kind != ListValue {
+		return []string{attribute.Value.String}
+	}
+
+	values := make([]string, len(attribute.Value.List))
+	for i, value := range attribute.Value.List {
+		values[i] = value.String
+	}
+	return values
+}
+
+// Map returns the string values of a map attribute, like the properties of a step, or an empty map if the block
+// does not define it.
+func (o *Block) Map(name string) map[string]string {
+	values := map[string]string{}
+	attribute := o.Attribute(name)
+
+	if attribute == nil {
+		return values
+	}
+
+	for _, item := range attribute.Value.Map {
+		values[item.Name] = item.Value.String
+	}
+	return values
+}
+
+// BlocksOfType returns the child blocks with the supplied type.
+func (o *Block) BlocksOfType(blockType string) []*Block {
+	blocks := []*Block{}
+	for _, block := range o.Blocks {
+		if block.Type == blockType {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// Parse parses the contents of an OCL file. The file name is only used in error messages.
+func Parse(file string, content string) (*Block, error) {
+	tokens, err := tokenize(file, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{file: file, tokens: tokens}
+	root := &Block{Line: 1}
+
+	if err := p.parseBody(root, tokenEOF); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenNewline
+	tokenIdent
+	tokenString
+	tokenEquals
+	tokenComma
+	tokenOpenBrace
+	tokenCloseBrace
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	Type  tokenType
+	Value string
+	Line  int
+}
+
+func (o token) describe() string {
+	switch o.Type {
+	case tokenEOF:
+		return "end of file"
+	case tokenNewline:
+		return "new line"
+	case tokenString:
+		return "string \"" + o.Value + "\""
+	default:
+		return "\"" + o.Value + "\""
+	}
+}
+
+// tokenize splits the content of an OCL file into tokens. Comments are discarded, and heredocs are returned as
+// string tokens.
+func tokenize(file string, content string) ([]token, error) {
+	runes := []rune(content)
+	tokens := []token{}
+	line := 1
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case c == '\n':
+			tokens = append(tokens, token{Type: tokenNewline, Value: "\n", Line: line})
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#' || (c == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case c == '=':
+			tokens = append(tokens, token{Type: tokenEquals, Value: "=", Line: line})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{Type: tokenComma, Value: ",", Line: line})
+			i++
+		case c == '{':
+			tokens = append(tokens, token{Type: tokenOpenBrace, Value: "{", Line: line})
+			i++
+		case c == '}':
+			tokens = append(tokens, token{Type: tokenCloseBrace, Value: "}", Line: line})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{Type: tokenOpenBracket, Value: "[", Line: line})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{Type: tokenCloseBracket, Value: "]", Line: line})
+			i++
+		case c == '"':
+			value, end, err := readString(runes, i+1)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+
+			tokens = append(tokens, token{Type: tokenString, Value: value, Line: line})
+			i = end
+		case c == '<' && i+1 < len(runes) && runes[i+1] == '<':
+			value, end, lines, err := readHeredoc(runes, i+2)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+
+			tokens = append(tokens, token{Type: tokenString, Value: value, Line: line})
+			line += lines
+			i = end
+		case isIdentRune(c):
+			start := i
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{Type: tokenIdent, Value: string(runes[start:i]), Line: line})
+		default:
+			return nil, fmt.Errorf("%s:%d: unexpected character %q", file, line, c)
+		}
+	}
+
+	return append(tokens, token{Type: tokenEOF, Line: line}), nil
+}
+
+func isIdentRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_-.:", c)
+}
+
+// readString reads a double-quoted string starting after the opening quote, returning the unescaped value and
+// the index after the closing quote.
+func readString(runes []rune, start int) (string, int, error) {
+	builder := strings.Builder{}
+
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case '"':
+			return builder.String(), i + 1, nil
+		case '\n':
+			return "", 0, errors.New("unterminated string")
+		case '\\':
+			if i+1 >= len(runes) {
+				return "", 0, errors.New("unterminated string")
+			}
+			i++
+			switch runes[i] {
+			case 'n':
+				builder.WriteRune('\n')
+			case 'r':
+				builder.WriteRune('\r')
+			case 't':
+				builder.WriteRune('\t')
+			case '"', '\\':
+				builder.WriteRune(runes[i])
+			default:
+				builder.WriteRune('\\')
+				builder.WriteRune(runes[i])
+			}
+		default:
+			builder.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, errors.New("unterminated string")
+}
+
+// readHeredoc reads a heredoc like `<<-EOT` starting after the angle brackets, returning the value, the index
+// after the closing marker, and the number of lines that were read. The indentation of heredocs that start
+// with `<<-` is removed.
+func readHeredoc(runes []rune, start int) (string, int, int, error) {
+	i := start
+	indented := i < len(runes) && runes[i] == '-'
+	if indented {
+		i++
+	}
+
+	markerStart := i
+	for i < len(runes) && runes[i] != '\n' {
+		i++
+	}
+	marker := strings.TrimSpace(string(runes[markerStart:i]))
+
+	if marker == "" {
+		return "", 0, 0, errors.New("heredoc is missing a marker")
+	}
+
+	if i >= len(runes) {
+		return "", 0, 0, errors.New("unterminated heredoc " + marker)
+	}
+
+	i++
+	lines := []string{}
+	lineCount := 1
+
+	for i < len(runes) {
+		lineStart := i
+		for i < len(runes) && runes[i] != '\n' {
+			i++
+		}
+		text := strings.TrimSuffix(string(runes[lineStart:i]), "\r")
+
+		if strings.TrimSpace(text) == marker {
+			if indented {
+				lines = removeIndentation(lines)
+			}
+			return strings.Join(lines, "\n"), i, lineCount, nil
+		}
+
+		lines = append(lines, text)
+
+		if i < len(runes) {
+			i++
+			lineCount++
+		}
+	}
+
+	return "", 0, 0, errors.New("unterminated heredoc " + marker)
+}
+
+func removeIndentation(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent == -1 || lineIndent < indent {
+			indent = lineIndent
+		}
+	}
+
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			trimmed[i] = line[indent:]
+		} else {
+			trimmed[i] = strings.TrimLeft(line, " \t")
+		}
+	}
+	return trimmed
+}
+
+type parser struct {
+	file   string
+	tokens []token
+	pos    int
+}
+
+func (o *parser) peek() token {
+	return o.tokens[o.pos]
+}
+
+func (o *parser) next() token {
+	t := o.tokens[o.pos]
+	if t.Type != tokenEOF {
+		o.pos++
+	}
+	return t
+}
+
+func (o *parser) skipNewlines() {
+	for o.peek().Type == tokenNewline {
+		o.next()
+	}
+}
+
+func (o *parser) unexpected(t token) error {
+	return fmt.Errorf("%s:%d: unexpected %s", o.file, t.Line, t.describe())
+}
+
+// parseBody parses the attributes and blocks of a block until the end token is found.
+func (o *parser) parseBody(block *Block, end tokenType) error {
+	for {
+		o.skipNewlines()
+
+		t := o.next()
+
+		if t.Type == end {
+			return nil
+		}
+
+		if t.Type != tokenIdent && t.Type != tokenString {
+			return o.unexpected(t)
+		}
+
+		if o.peek().Type == tokenEquals {
+			o.next()
+			value, err := o.parseValue()
+
+			if err != nil {
+				return err
+			}
+
+			block.Attributes = append(block.Attributes, &Attribute{Name: t.Value, Value: value, Line: t.Line})
+			continue
+		}
+
+		child := &Block{Type: t.Value, Line: t.Line}
+
+		for o.peek().Type == tokenIdent || o.peek().Type == tokenString {
+			child.Labels = append(child.Labels, o.next().Value)
+		}
+
+		if brace := o.next(); brace.Type != tokenOpenBrace {
+			return o.unexpected(brace)
+		}
+
+		if err := o.parseBody(child, tokenCloseBrace); err != nil {
+			return err
+		}
+
+		block.Blocks = append(block.Blocks, child)
+	}
+}
+
+func (o *parser) parseValue() (Value, error) {
+	t := o.next()
+
+	switch t.Type {
+	case tokenString, tokenIdent:
+		return Value{Kind: StringValue, String: t.Value}, nil
+	case tokenOpenBracket:
+		list := []Value{}
+		for {
+			o.skipNewlines()
+
+			if o.peek().Type == tokenCloseBracket {
+				o.next()
+				return Value{Kind: ListValue, List: list}, nil
+			}
+
+			item, err := o.parseValue()
+
+			if err != nil {
+				return Value{}, err
+			}
+
+			list = append(list, item)
+
+			o.skipNewlines()
+			if o.peek().Type == tokenComma {
+				o.next()
+			}
+		}
+	case tokenOpenBrace:
+		items := []*Attribute{}
+		for {
+			o.skipNewlines()
+
+			key := o.next()
+
+			if key.Type == tokenCloseBrace {
+				return Value{Kind: MapValue, Map: items}, nil
+			}
+
+			if key.Type != tokenIdent && key.Type != tokenString {
+				return Value{}, o.unexpected(key)
+			}
+
+			if equals := o.next(); equals.Type != tokenEquals {
+				return Value{}, o.unexpected(equals)
+			}
+
+			item, err := o.parseValue()
+
+			if err != nil {
+				return Value{}, err
+			}
+
+			items = append(items, &Attribute{Name: key.Value, Value: item, Line: key.Line})
+
+			if o.peek().Type == tokenComma {
+				o.next()
+			}
+		}
+	default:
+		return Value{}, o.unexpected(t)
+	}
+}
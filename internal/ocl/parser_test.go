@@ -0,0 +1,77 @@
+package ocl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBlocksAndAttributes(t *testing.T) {
+	content := `# A comment
+step "run-a-script" {
+    name = "Run a \"Script\""
+    properties = {
+        Octopus.Action.TargetRoles = "web"
+    }
+
+    action {
+        environments = ["development", "production"]
+        script = <<-EOT
+            echo "hi"
+              echo "indented"
+            EOT
+        worker_pool = "hosted-ubuntu" // trailing comment
+    }
+}`
+
+	root, err := Parse("deployment_process.ocl", content)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	steps := root.BlocksOfType("step")
+
+	if len(steps) != 1 || steps[0].Label() != "run-a-script" || steps[0].Line != 2 {
+		t.Fatal("Should have returned the step on line 2")
+	}
+
+	if steps[0].String("name") != "Run a \"Script\"" {
+		t.Fatal("Should have unescaped the step name")
+	}
+
+	if steps[0].Map("properties")["Octopus.Action.TargetRoles"] != "web" {
+		t.Fatal("Should have returned the step properties")
+	}
+
+	actions := steps[0].BlocksOfType("action")
+
+	if len(actions) != 1 || actions[0].Line != 8 {
+		t.Fatal("Should have returned the action on line 8")
+	}
+
+	if strings.Join(actions[0].Strings("environments"), ",") != "development,production" {
+		t.Fatal("Should have returned the environments list")
+	}
+
+	if actions[0].String("script") != "echo \"hi\"\n  echo \"indented\"" {
+		t.Fatal("Should have removed the common indentation of the heredoc")
+	}
+
+	workerPool := actions[0].Attribute("worker_pool")
+
+	if workerPool == nil || workerPool.Value.String != "hosted-ubuntu" || workerPool.Line != 14 {
+		t.Fatal("Should have counted the lines of the heredoc")
+	}
+}
+
+func TestParseErrorsIncludeLine(t *testing.T) {
+	_, err := Parse("variables.ocl", "variable \"Name\" {\n    value \"x\" {\n}")
+
+	if err == nil {
+		t.Fatal("Should have returned an error")
+	}
+
+	if !strings.HasPrefix(err.Error(), "variables.ocl:3:") {
+		t.Fatal("Should have reported the file and line of the error, got " + err.Error())
+	}
+}
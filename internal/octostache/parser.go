@@ -0,0 +1,264 @@
+// Package octostache finds the variables referenced by text that uses the Octopus variable substitution syntax,
+// also known as Octostache.
+package octostache
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reference is a variable referenced by some text.
+type Reference struct {
+	// Name is the name of the variable, like "Database.Name" or "Octopus.Action[Deploy].Output.Url". Indexes that
+	// are themselves variable references are left in the name, like "Endpoint[#{Region}].Url".
+	Name string
+	// Script is true if the variable was referenced by a script accessor, like $OctopusParameters["Name"], rather than
+	// a substitution expression
+	Script bool
+}
+
+// scriptAccessors match the functions and collections that scripts use to read variables.
+var scriptAccessors = []*regexp.Regexp{
+	// PowerShell $OctopusParameters["Name"], C# Octopus.Parameters["Name"], and Python octopusvariables["Name"]
+	regexp.MustCompile(`(?i)(?:OctopusParameters|Octopus\.Parameters|octopusvariables)\[\s*["']([^"']+)["']\s*]`),
+	// Bash get_octopusvariable "Name" and Python get_octopusvariable("Name")
+	regexp.MustCompile(`get_octopusvariable\s*\(?\s*["']([^"']+)["']`),
+	// F# Octopus.findVariable "Name"
+	regexp.MustCompile(`Octopus\.(?:findVariable|findVariableOrDefault|tryFindVariable)\s*\(?\s*"([^"]+)"`),
+}
+
+var conditionOperators = regexp.MustCompile(`\s*(?:==|!=)\s*`)
+var calculationOperators = regexp.MustCompile(`[-+*/%()]`)
+
+// References returns the variables referenced by the text, in the order they first appear. Substitution
+// expressions like #{Name}, #{Name | Filter}, #{if Name}, #{unless Name}, #{each item in Name}, and nested
+// expressions like #{Octopus.Action[#{StepName}].Output.Value} are parsed, as are script accessors like
+// $OctopusParameters["Name"]. Escaped expressions like ##{Name} and the iterators of #{each} blocks are not
+// returned. Names are compared without regard to case, as Octopus does.
+func References(text string) []Reference {
+	references := referenceList{}
+	parseTemplate(text, []string{}, &references)
+
+	// Script accessors are sorted by where they appear in the text
+	matches := [][]int{}
+	for _, accessor := range scriptAccessors {
+		matches = append(matches, accessor.FindAllStringSubmatchIndex(text, -1)...)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i][0] < matches[j][0]
+	})
+
+	for _, match := range matches {
+		references.add(Reference{Name: strings.TrimSpace(text[match[2]:match[3]]), Script: true})
+	}
+
+	return references.items
+}
+
+// Names returns the names of the variables referenced by the text.
+func Names(text string) []string {
+	names := []string{}
+	for _, reference := range References(text) {
+		names = append(names, reference.Name)
+	}
+	return names
+}
+
+type referenceList struct {
+	items []Reference
+}
+
+func (o *referenceList) add(reference Reference) {
+	if reference.Name == "" {
+		return
+	}
+
+	for _, existing := range o.items {
+		if strings.EqualFold(existing.Name, reference.Name) {
+			return
+		}
+	}
+
+	o.items = append(o.items, reference)
+}
+
+// parseTemplate finds the expressions in the text. The iterators are the names of the items of the #{each}
+// blocks that the text is nested in.
+func parseTemplate(text string, iterators []string, references *referenceList) []string {
+	for i := 0; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], "##{") {
+			// An escaped expression is printed as is
+			end := findClose(text, i+3)
+			if end == -1 {
+				return iterators
+			}
+			i = end
+			continue
+		}
+
+		if !strings.HasPrefix(text[i:], "#{") {
+			continue
+		}
+
+		end := findClose(text, i+2)
+
+		if end == -1 {
+			return iterators
+		}
+
+		iterators = parseExpression(text[i+2:end], iterators, references)
+		i = end
+	}
+
+	return iterators
+}
+
+// findClose returns the index of the brace that closes an expression starting at the supplied index, skipping
+// over nested expressions and quoted strings, or -1 if the expression is not closed.
+func findClose(text string, start int) int {
+	depth := 0
+	quote := rune(0)
+
+	for i, c := range text[start:] {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"':
+			quote = c
+		case c == '{' && i > 0 && text[start+i-1] == '#':
+			depth++
+		case c == '}':
+			if depth == 0 {
+				return start + i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// parseExpression records the variables referenced by the contents of a #{...} expression, returning the
+// iterators that are in scope after the expression.
+func parseExpression(expression string, iterators []string, references *referenceList) []string {
+	// Nested expressions are evaluated first, and are references in their own right
+	parseTemplate(expression, iterators, references)
+
+	expression = strings.TrimSpace(expression)
+	keyword, rest := splitKeyword(expression)
+
+	switch keyword {
+	case "/each":
+		if len(iterators) != 0 {
+			return iterators[:len(iterators)-1]
+		}
+		return iterators
+	case "else", "/if", "/unless", "/reverse":
+		return iterators
+	case "if", "unless":
+		for _, operand := range conditionOperators.Split(rest, -1) {
+			addSymbol(operand, iterators, references)
+		}
+		return iterators
+	case "each":
+		// #{each item in Collection}
+		fields := strings.Fields(rest)
+		if len(fields) >= 3 && strings.EqualFold(fields[1], "in") {
+			addSymbol(strings.Join(fields[2:], " "), iterators, references)
+			return append(append([]string{}, iterators...), fields[0])
+		}
+		return iterators
+	case "reverse":
+		addSymbol(rest, iterators, references)
+		return iterators
+	case "calc":
+		for _, operand := range calculationOperators.Split(rest, -1) {
+			addSymbol(operand, iterators, references)
+		}
+		return iterators
+	}
+
+	addSymbol(expression, iterators, references)
+	return iterators
+}
+
+// splitKeyword splits the keyword, like "if" or "each", from the start of an expression. An empty keyword is
+// returned for expressions that are a plain variable reference.
+func splitKeyword(expression string) (string, string) {
+	if strings.HasPrefix(expression, "/") {
+		return strings.ToLower(strings.TrimSpace(expression)), ""
+	}
+
+	fields := strings.SplitN(expression, " ", 2)
+	keyword := strings.ToLower(fields[0])
+
+	switch keyword {
+	case "if", "unless", "each", "reverse", "calc":
+		if len(fields) == 2 {
+			return keyword, strings.TrimSpace(fields[1])
+		}
+	case "else":
+		if len(fields) == 1 {
+			return keyword, ""
+		}
+	}
+
+	return "", expression
+}
+
+// addSymbol records the variable at the start of an operand, ignoring any filters, quoted strings, numbers,
+// and references to the iterators of #{each} blocks.
+func addSymbol(operand string, iterators []string, references *referenceList) {
+	symbol := strings.TrimSpace(stripFilters(operand))
+
+	if symbol == "" || strings.HasPrefix(symbol, "\"") || isNumber(symbol) ||
+		strings.EqualFold(symbol, "true") || strings.EqualFold(symbol, "false") {
+		return
+	}
+
+	root := symbol
+	if index := strings.IndexAny(root, ".["); index != -1 {
+		root = root[:index]
+	}
+
+	for _, iterator := range iterators {
+		if strings.EqualFold(root, iterator) {
+			return
+		}
+	}
+
+	references.add(Reference{Name: symbol})
+}
+
+// stripFilters removes the filters, like "| ToUpper", from an operand. Pipes inside indexes and nested
+// expressions are not treated as filters.
+func stripFilters(operand string) string {
+	depth := 0
+	for i, c := range operand {
+		switch c {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case '|':
+			if depth == 0 {
+				return operand[:i]
+			}
+		}
+	}
+	return operand
+}
+
+func isNumber(value string) bool {
+	for _, c := range value {
+		if !unicode.IsDigit(c) && c != '.' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package octostache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReferences(t *testing.T) {
+	tests := map[string]string{
+		"#{Database.Name}": "Database.Name",
+		"Server=#{Server};Database=#{Database | ToLower}":                           "Server,Database",
+		"#{if Octopus.Environment.Name == \"Production\"}x#{/if}":                   "Octopus.Environment.Name",
+		"#{unless Feature.Enabled}off#{else}on#{/unless}":                           "Feature.Enabled",
+		"#{if A != B}x#{/if}":                                                       "A,B",
+		"#{each endpoint in Endpoints}#{endpoint.Url} #{Region}#{/each}#{endpoint}": "Endpoints,Region,endpoint",
+		"#{Octopus.Action[Deploy Web].Output.Url}":                                  "Octopus.Action[Deploy Web].Output.Url",
+		"#{Octopus.Action[#{StepName}].Output.Url}":                                 "StepName,Octopus.Action[#{StepName}].Output.Url",
+		"##{Escaped} #{NotEscaped}":                                                 "NotEscaped",
+		"#{Name | Replace \"}\" \"x\"}":                                             "Name",
+		"#{calc Count * 2 + Offset}":                                                "Count,Offset",
+		"#{a} #{A}":                                                                 "a",
+		"#{Unterminated":                                                            "",
+		"no variables here":                                                         "",
+	}
+
+	for text, expected := range tests {
+		actual := strings.Join(Names(text), ",")
+		if actual != expected {
+			t.Fatal("Expected \"" + expected + "\" for \"" + text + "\", got \"" + actual + "\"")
+		}
+	}
+}
+
+func TestScriptReferences(t *testing.T) {
+	script := `$name = $OctopusParameters["Database.Name"]
+name=$(get_octopusvariable "Server")
+value = get_octopusvariable("Python.Value")
+var x = Octopus.Parameters["CSharp.Value"];
+let y = Octopus.findVariable "FSharp.Value"
+$url = $OctopusParameters['Octopus.Action[Deploy Web].Output.Url']`
+
+	references := References(script)
+
+	if strings.Join(Names(script), ",") != "Database.Name,Server,Python.Value,CSharp.Value,FSharp.Value,Octopus.Action[Deploy Web].Output.Url" {
+		t.Fatal("Should have found the script references, got " + strings.Join(Names(script), ","))
+	}
+
+	for _, reference := range references {
+		if !reference.Script {
+			t.Fatal("Should have marked " + reference.Name + " as a script reference")
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package preflight compares the space and system permissions granted to the API key with the permissions declared by
+// each check, so checks that can not run are reported before the scan starts rather than failing part way through.
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+var linksTemplate = regexp.MustCompile(`\{.+\}`)
+
+// userPermissionSet is the subset of the response from /api/users/{id}/permissions used to find the space and
+// system permissions granted to a user.
+type userPermissionSet struct {
+	SpacePermissions  map[string][]permissionRestriction `json:"SpacePermissions"`
+	SystemPermissions []string                           `json:"SystemPermissions"`
+}
+
+type permissionRestriction struct {
+	SpaceId string `json:"SpaceId"`
+}
+
+// SkippedCheck is a check that can not run because the API key is missing one or more of the permissions the check
+// requires.
+type SkippedCheck struct {
+	Check              checks.OctopusCheck
+	MissingPermissions []string
+}
+
+// Description explains why the check was skipped.
+func (o SkippedCheck) Description() string {
+	return "The check was skipped because the API key does not have the following permissions in the space: " +
+		strings.Join(o.MissingPermissions, ", ")
+}
+
+// Result returns the result reported in place of the skipped check.
+func (o SkippedCheck) Result() checks.OctopusCheckResult {
+	return checks.NewOctopusCheckResultImpl(
+		o.Description(),
+		o.Check.Id(),
+		"",
+		checks.Permission,
+		checks.GeneralError)
+}
+
+// GetGrantedPermissions returns the permissions granted to the user that owns the API key in the space. Permissions
+// like UserView and TeamView are often granted at the system level, so system permissions are included.
+func GetGrantedPermissions(client *client.Client, spaceId string) (map[string]bool, error) {
+	user, err := client.Users.GetMe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	permissionsLink := linksTemplate.ReplaceAllString(user.Links["Permissions"], "")
+
+	if permissionsLink == "" {
+		return nil, errors.New("the current user does not link to its permissions")
+	}
+
+	permissionSet, err := newclient.Get[userPermissionSet](client.HttpSession(), permissionsLink)
+
+	if err != nil {
+		return nil, err
+	}
+
+	granted := map[string]bool{}
+	for _, permission := range permissionSet.SystemPermissions {
+		granted[permission] = true
+	}
+
+	for permission, restrictions := range permissionSet.SpacePermissions {
+		for _, restriction := range restrictions {
+			// Permissions granted without a space apply to every space
+			if restriction.SpaceId == "" || restriction.SpaceId == spaceId {
+				granted[permission] = true
+				break
+			}
+		}
+	}
+
+	return granted, nil
+}
+
+// Filter splits the checks into the checks that can run with the granted permissions, and the checks that are
+// missing one or more of the permissions they require. Checks that don't declare their permissions are always run.
+func Filter(checkCollection []checks.OctopusCheck, granted map[string]bool) ([]checks.OctopusCheck, []SkippedCheck) {
+	runnable := []checks.OctopusCheck{}
+	skipped := []SkippedCheck{}
+
+	for _, check := range checkCollection {
+		missing := []string{}
+		for _, permission := range checks.RequiredPermissions(check) {
+			if !granted[permission] {
+				missing = append(missing, permission)
+			}
+		}
+
+		if len(missing) == 0 {
+			runnable = append(runnable, check)
+		} else {
+			skipped = append(skipped, SkippedCheck{Check: check, MissingPermissions: missing})
+		}
+	}
+
+	return runnable, skipped
+}
+
+// RequiredPermissions returns the sorted list of permissions required to run all the checks.
+func RequiredPermissions(checkCollection []checks.OctopusCheck) []string {
+	required := map[string]bool{}
+	for _, check := range checkCollection {
+		for _, permission := range checks.RequiredPermissions(check) {
+			required[permission] = true
+		}
+	}
+
+	permissions := []string{}
+	for permission := range required {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+
+	return permissions
+}
+
+// GenerateRequiredPermissionsReport lists the permissions required to run all the checks, followed by the
+// permissions required by each check.
+func GenerateRequiredPermissionsReport(checkCollection []checks.OctopusCheck) string {
+	report := []string{"The selected checks require the following space permissions:"}
+	report = append(report, RequiredPermissions(checkCollection)...)
+	report = append(report, "")
+
+	for _, check := range checkCollection {
+		permissions := append([]string{}, checks.RequiredPermissions(check)...)
+		sort.Strings(permissions)
+
+		if len(permissions) == 0 {
+			report = append(report, fmt.Sprintf("%s: no permissions required", check.Id()))
+		} else {
+			report = append(report, fmt.Sprintf("%s: %s", check.Id(), strings.Join(permissions, ", ")))
+		}
+	}
+
+	return strings.Join(report, "\n")
+}
@@ -0,0 +1,140 @@
+package preflight
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/factory"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+type permissionCheck struct {
+	id          string
+	permissions []string
+}
+
+func (o permissionCheck) Id() string {
+	return o.id
+}
+
+func (o permissionCheck) RequiredPermissions() []string {
+	return o.permissions
+}
+
+func (o permissionCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+	return checks.NewOctopusCheckResultImpl("Passed", o.id, "", checks.Ok, checks.Organization), nil
+}
+
+func TestFilter(t *testing.T) {
+	checkCollection := []checks.OctopusCheck{
+		permissionCheck{id: "Granted", permissions: []string{checks.PermissionProjectView}},
+		permissionCheck{id: "Missing", permissions: []string{checks.PermissionProjectView, checks.PermissionUserView}},
+		permissionCheck{id: "None", permissions: []string{}},
+	}
+
+	runnable, skipped := Filter(checkCollection, map[string]bool{checks.PermissionProjectView: true})
+
+	if len(runnable) != 2 || runnable[0].Id() != "Granted" || runnable[1].Id() != "None" {
+		t.Fatal("Should have run the checks with all their permissions granted")
+	}
+
+	if len(skipped) != 1 || skipped[0].Check.Id() != "Missing" || strings.Join(skipped[0].MissingPermissions, ",") != checks.PermissionUserView {
+		t.Fatal("Should have skipped the check missing the UserView permission")
+	}
+
+	if skipped[0].Result().Severity() != checks.Permission || skipped[0].Result().Code() != "Missing" {
+		t.Fatal("Should have reported the skipped check as a permission result")
+	}
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	checkCollection := []checks.OctopusCheck{
+		permissionCheck{id: "First", permissions: []string{checks.PermissionVariableView, checks.PermissionProjectView}},
+		permissionCheck{id: "Second", permissions: []string{checks.PermissionProjectView, checks.PermissionEventView}},
+	}
+
+	if strings.Join(RequiredPermissions(checkCollection), ",") != "EventView,ProjectView,VariableView" {
+		t.Fatal("Should have returned the sorted union of the permissions")
+	}
+
+	report := GenerateRequiredPermissionsReport(checkCollection)
+
+	if !strings.Contains(report, "First: ProjectView, VariableView") || !strings.Contains(report, "Second: EventView, ProjectView") {
+		t.Fatal("Should have listed the permissions of each check, got " + report)
+	}
+}
+
+// TestServiceAccountRole ensures the role created by the serviceaccount Terraform module grants every permission
+// required by the checks.
+func TestServiceAccountRole(t *testing.T) {
+	terraform, err := os.ReadFile("../../serviceaccount/terraform.tf")
+
+	if err != nil {
+		t.Fatal("Failed to read the service account module: " + err.Error())
+	}
+
+	checkCollection, err := factory.NewOctopusCheckFactory(nil, "", "").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal("Failed to build the checks: " + err.Error())
+	}
+
+	for _, permission := range RequiredPermissions(checkCollection) {
+		if !strings.Contains(string(terraform), "\""+permission+"\"") {
+			t.Fatal("The service account role does not grant the " + permission + " permission")
+		}
+	}
+}
+
+func TestGetGrantedPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api", "/api/", "/api/Spaces-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Links": map[string]string{"Users": "/api/users{/id}{?skip,take,ids,filter}"}})
+		case "/api/users/me":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Id": "Users-1", "Username": "octolint", "Links": map[string]string{"Permissions": "/api/users/Users-1/permissions{?spaces}"}})
+		case "/api/users/Users-1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"SpacePermissions": map[string]any{
+					checks.PermissionProjectView:     []map[string]string{{"SpaceId": "Spaces-1"}},
+					checks.PermissionEnvironmentView: []map[string]string{{"SpaceId": "Spaces-2"}},
+				},
+				"SystemPermissions": []string{checks.PermissionUserView, checks.PermissionTeamView},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	octopusClient, err := client.NewClient(nil, serverUrl, "API-TEST", "Spaces-1")
+
+	if err != nil {
+		t.Fatal("Should have created the client: " + err.Error())
+	}
+
+	granted, err := GetGrantedPermissions(octopusClient, "Spaces-1")
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !granted[checks.PermissionProjectView] || granted[checks.PermissionEnvironmentView] {
+		t.Fatal("Should have only granted the space permissions in the space")
+	}
+
+	if !granted[checks.PermissionUserView] || !granted[checks.PermissionTeamView] {
+		t.Fatal("Should have granted the permissions granted at the system level")
+	}
+}
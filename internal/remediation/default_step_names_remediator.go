@@ -0,0 +1,237 @@
+package remediation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// DefaultStepNamesRemediator renames steps that use the default step names. The new names are derived from
+// the package or script used by the step, so they are a starting point for a reviewer rather than a final name.
+type DefaultStepNamesRemediator struct {
+	client *client.Client
+}
+
+func NewDefaultStepNamesRemediator(client *client.Client) DefaultStepNamesRemediator {
+	return DefaultStepNamesRemediator{client: client}
+}
+
+func (o DefaultStepNamesRemediator) Id() string {
+	return naming.OctoLintProjectDefaultStepNames
+}
+
+func (o DefaultStepNamesRemediator) Plan(result checks.OctopusCheckResult) ([]RemediationChange, error) {
+	changes := []RemediationChange{}
+	projectIds := []string{}
+
+	for _, finding := range result.Findings() {
+		if finding.ResourceType == checks.ResourceTypeAction && finding.ParentId != "" && slices.Index(projectIds, finding.ParentId) == -1 {
+			projectIds = append(projectIds, finding.ParentId)
+		}
+	}
+
+	for _, projectId := range projectIds {
+		project, err := o.client.Projects.GetByID(projectId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The deployment process of a version controlled project is saved in Git rather than the REST API
+		if project.IsVersionControlled || project.DeploymentProcessID == "" {
+			zap.L().Debug("Skipping the remediation of "+o.Id()+" for project "+project.Name+" because the deployment process is not in the database", logging.CheckId(o.Id()), logging.Project(project.Name))
+			continue
+		}
+
+		process, err := o.client.DeploymentProcesses.GetByID(project.DeploymentProcessID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		references, err := o.projectText(project)
+
+		if err != nil {
+			return nil, err
+		}
+
+		change := defaultStepNamesChange(o.client.GetSpaceID(), project.Name, process, references)
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, nil
+}
+
+// projectText returns the values of the project variables and the properties of the runbook steps, which can
+// reference the output variables of the deployment process steps.
+func (o DefaultStepNamesRemediator) projectText(project *projects.Project) ([]string, error) {
+	text := []string{}
+
+	variableSet, err := o.client.Variables.GetAll(project.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variable := range variableSet.Variables {
+		if variable != nil {
+			text = append(text, variable.Value)
+		}
+	}
+
+	runbookProcesses, err := client_wrapper.NewProcessLoader(o.client, "", 0).GetRunbookProcesses(project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, runbookProcess := range runbookProcesses {
+		text = append(text, stepText(runbookProcess.Steps)...)
+	}
+
+	return text, nil
+}
+
+// stepReference matches references to steps and actions, like "Octopus.Action[Deploy].Output.Url" or
+// "Octopus.Step[Deploy].Status.Code", capturing the name of the step or action.
+var stepReference = regexp.MustCompile(`(?i)Octopus\.(?:Action|Step)\[([^\]]+)\]`)
+
+// referencedStepNames returns the lower case names of the steps and actions referenced in the text.
+func referencedStepNames(text []string) map[string]bool {
+	names := map[string]bool{}
+
+	for _, value := range text {
+		for _, match := range stepReference.FindAllStringSubmatch(value, -1) {
+			names[strings.ToLower(strings.TrimSpace(match[1]))] = true
+		}
+	}
+
+	return names
+}
+
+// stepText returns the values of the step and action properties, which include scripts and templated settings.
+func stepText(steps []*deployments.DeploymentStep) []string {
+	text := []string{}
+
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+
+		for _, property := range step.Properties {
+			text = append(text, property.Value)
+		}
+
+		for _, action := range step.Actions {
+			if action == nil {
+				continue
+			}
+
+			for _, property := range action.Properties {
+				text = append(text, property.Value)
+			}
+		}
+	}
+
+	return text
+}
+
+// defaultStepNamesChange builds the change that renames every action in the deployment process that uses a default
+// step name. The step is renamed with the action when they share a name, which is the case for single-action steps.
+// Steps and actions referenced by name, for example to read their output variables, are not renamed, as the
+// references would break. The references are found in the deployment process and the supplied project text.
+func defaultStepNamesChange(spaceId string, projectName string, process *deployments.DeploymentProcess, projectText []string) *RemediationChange {
+	patch := []PatchOperation{}
+	renamed := []string{}
+	usedNames := map[string]bool{}
+	referenced := referencedStepNames(append(stepText(process.Steps), projectText...))
+
+	for _, step := range process.Steps {
+		usedNames[step.Name] = true
+		for _, action := range step.Actions {
+			usedNames[action.Name] = true
+		}
+	}
+
+	for i, step := range process.Steps {
+		for j, action := range step.Actions {
+			if slices.Index(checks.DefaultStepNames, action.Name) == -1 {
+				continue
+			}
+
+			if referenced[strings.ToLower(action.Name)] {
+				zap.L().Debug("Skipping the rename of the step "+action.Name+" in project "+projectName+" because it is referenced by name", logging.CheckId(naming.OctoLintProjectDefaultStepNames), logging.Project(projectName))
+				continue
+			}
+
+			newName := uniqueName(proposedStepName(action, i+1), usedNames)
+			usedNames[newName] = true
+
+			if step.Name == action.Name {
+				patch = append(patch,
+					PatchOperation{Op: "test", Path: fmt.Sprintf("/Steps/%d/Name", i), Value: step.Name},
+					PatchOperation{Op: "replace", Path: fmt.Sprintf("/Steps/%d/Name", i), Value: newName})
+			}
+
+			patch = append(patch,
+				PatchOperation{Op: "test", Path: fmt.Sprintf("/Steps/%d/Actions/%d/Name", i, j), Value: action.Name},
+				PatchOperation{Op: "replace", Path: fmt.Sprintf("/Steps/%d/Actions/%d/Name", i, j), Value: newName})
+			renamed = append(renamed, "\""+action.Name+"\" to \""+newName+"\"")
+		}
+	}
+
+	if len(patch) == 0 {
+		return nil
+	}
+
+	return &RemediationChange{
+		CheckId:      naming.OctoLintProjectDefaultStepNames,
+		ResourceType: checks.ResourceTypeProject,
+		ResourceId:   process.ProjectID,
+		ResourceName: projectName,
+		ApiPath:      "/api/" + spaceId + "/deploymentprocesses/" + process.ID,
+		Summary:      "Rename the steps in project " + projectName + " from " + strings.Join(renamed, ", "),
+		Patch:        patch,
+	}
+}
+
+// proposedStepName suggests a name that describes what the action does.
+func proposedStepName(action *deployments.DeploymentAction, stepNumber int) string {
+	for _, p := range action.Packages {
+		if p != nil && p.PackageID != "" {
+			return action.Name + " (" + p.PackageID + ")"
+		}
+	}
+
+	if scriptFile, ok := action.Properties["Octopus.Action.Script.ScriptFileName"]; ok && scriptFile.Value != "" {
+		return action.Name + " (" + scriptFile.Value + ")"
+	}
+
+	return action.Name + " (step " + fmt.Sprint(stepNumber) + ")"
+}
+
+// uniqueName appends a number to the name if it is already used in the deployment process.
+func uniqueName(name string, usedNames map[string]bool) string {
+	if !usedNames[name] {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		candidate := name + " " + fmt.Sprint(i)
+		if !usedNames[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package remediation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+)
+
+// InsecureFeedsRemediator replaces the HTTP URLs of feeds with HTTPS URLs. The feed must support HTTPS for
+// the change to work, so the generated changes must be reviewed before they are applied.
+type InsecureFeedsRemediator struct {
+	client *client.Client
+}
+
+func NewInsecureFeedsRemediator(client *client.Client) InsecureFeedsRemediator {
+	return InsecureFeedsRemediator{client: client}
+}
+
+func (o InsecureFeedsRemediator) Id() string {
+	return security.OctoLintInsecureFeeds
+}
+
+func (o InsecureFeedsRemediator) Plan(result checks.OctopusCheckResult) ([]RemediationChange, error) {
+	changes := []RemediationChange{}
+
+	for _, finding := range result.Findings() {
+		if finding.ResourceType != checks.ResourceTypeFeed || finding.ResourceId == "" {
+			continue
+		}
+
+		feed, err := o.client.Feeds.GetByID(finding.ResourceId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		change := insecureFeedChange(o.client.GetSpaceID(), feed)
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, nil
+}
+
+// insecureFeedChange builds the change that replaces the HTTP URL of a feed with a HTTPS URL. The patch tests
+// the existing URL so it fails if the feed was modified after the plan was generated.
+func insecureFeedChange(spaceId string, feed feeds.IFeed) *RemediationChange {
+	feedUri, terraformType := feedUriAndTerraformType(feed)
+
+	if !strings.HasPrefix(feedUri, "http://") {
+		return nil
+	}
+
+	secureUri := "https://" + strings.TrimPrefix(feedUri, "http://")
+	resourceName := terraformResourceName(feed.GetName())
+
+	return &RemediationChange{
+		CheckId:      security.OctoLintInsecureFeeds,
+		ResourceType: checks.ResourceTypeFeed,
+		ResourceId:   feed.GetID(),
+		ResourceName: feed.GetName(),
+		ApiPath:      "/api/" + spaceId + "/feeds/" + feed.GetID(),
+		Summary:      "Change the URL of feed " + feed.GetName() + " from " + feedUri + " to " + secureUri,
+		Patch: []PatchOperation{
+			{Op: "test", Path: "/FeedUri", Value: feedUri},
+			{Op: "replace", Path: "/FeedUri", Value: secureUri},
+		},
+		Terraform: "# Merge this URL into the " + terraformType + " resource for " + feed.GetName() + ".\n" +
+			"# The import block brings the feed under Terraform management if it is not already managed.\n" +
+			"import {\n  to = " + terraformType + "." + resourceName + "\n  id = " + fmt.Sprintf("%q", feed.GetID()) + "\n}\n\n" +
+			"resource \"" + terraformType + "\" \"" + resourceName + "\" {\n" +
+			"  name     = " + fmt.Sprintf("%q", feed.GetName()) + "\n" +
+			"  feed_uri = " + fmt.Sprintf("%q", secureUri) + "\n" +
+			"}\n",
+	}
+}
+
+// feedUriAndTerraformType returns the URL of the feed types inspected by the insecure feeds check, and the
+// name of the Terraform resource that manages the feed.
+func feedUriAndTerraformType(feed feeds.IFeed) (string, string) {
+	switch typedFeed := feed.(type) {
+	case *feeds.ArtifactoryGenericFeed:
+		return typedFeed.FeedURI, "octopusdeploy_artifactory_generic_feed"
+	case *feeds.NuGetFeed:
+		return typedFeed.FeedURI, "octopusdeploy_nuget_feed"
+	case *feeds.MavenFeed:
+		return typedFeed.FeedURI, "octopusdeploy_maven_feed"
+	case *feeds.HelmFeed:
+		return typedFeed.FeedURI, "octopusdeploy_helm_feed"
+	case *feeds.GitHubRepositoryFeed:
+		return typedFeed.FeedURI, "octopusdeploy_github_repository_feed"
+	case *feeds.DockerContainerRegistry:
+		return typedFeed.FeedURI, "octopusdeploy_docker_container_registry"
+	}
+
+	return "", ""
+}
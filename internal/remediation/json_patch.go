@@ -0,0 +1,177 @@
+package remediation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// applyPatch applies the JSON Patch operations to a document decoded by encoding/json. Only the operations
+// generated by the remediators are supported: test, add, replace, and remove. The original document is not modified.
+func applyPatch(document interface{}, patch []PatchOperation) (interface{}, error) {
+	result, err := normalizeJson(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range patch {
+		value, err := normalizeJson(operation.Value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch operation.Op {
+		case "test":
+			existing, err := getPointer(result, operation.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(existing, value) {
+				return nil, errors.New("the value at " + operation.Path + " has changed since the plan was created")
+			}
+		case "add", "replace", "remove":
+			result, err = setPointer(result, operation.Path, operation.Op, value)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.New("the patch operation " + operation.Op + " is not supported")
+		}
+	}
+
+	return result, nil
+}
+
+// normalizeJson converts a value to the types produced by decoding JSON, so values can be compared and copied.
+func normalizeJson(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(encoded, &decoded)
+	return decoded, err
+}
+
+func splitPointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("the path " + pointer + " is not a valid JSON pointer")
+	}
+
+	segments := strings.Split(pointer[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return segments, nil
+}
+
+func getPointer(document interface{}, pointer string) (interface{}, error) {
+	segments, err := splitPointer(pointer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	current := document
+	for _, segment := range segments {
+		current, err = child(current, segment, pointer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return current, nil
+}
+
+func child(parent interface{}, segment string, pointer string) (interface{}, error) {
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		value, ok := typed[segment]
+		if !ok {
+			return nil, errors.New("the path " + pointer + " does not exist")
+		}
+		return value, nil
+	case []interface{}:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(typed) {
+			return nil, errors.New("the path " + pointer + " does not exist")
+		}
+		return typed[index], nil
+	}
+
+	return nil, errors.New("the path " + pointer + " does not exist")
+}
+
+// setPointer applies an add, replace, or remove operation and returns the modified document. Arrays are
+// copied when their length changes, so the modified array is assigned back to its parent.
+func setPointer(document interface{}, pointer string, op string, value interface{}) (interface{}, error) {
+	segments, err := splitPointer(pointer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return setSegments(document, segments, pointer, op, value)
+}
+
+func setSegments(parent interface{}, segments []string, pointer string, op string, value interface{}) (interface{}, error) {
+	segment := segments[0]
+
+	if len(segments) > 1 {
+		next, err := child(parent, segment, pointer)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := setSegments(next, segments[1:], pointer, op, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return setSegments(parent, []string{segment}, pointer, "replace", updated)
+	}
+
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := typed[segment]; !ok && op != "add" {
+			return nil, errors.New("the path " + pointer + " does not exist")
+		}
+		if op == "remove" {
+			delete(typed, segment)
+		} else {
+			typed[segment] = value
+		}
+		return typed, nil
+	case []interface{}:
+		if op == "add" && segment == "-" {
+			return append(typed, value), nil
+		}
+
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index > len(typed) || (op != "add" && index == len(typed)) {
+			return nil, errors.New("the path " + pointer + " does not exist")
+		}
+
+		switch op {
+		case "remove":
+			return append(append([]interface{}{}, typed[:index]...), typed[index+1:]...), nil
+		case "add":
+			return append(append(append([]interface{}{}, typed[:index]...), value), typed[index:]...), nil
+		default:
+			typed[index] = value
+			return typed, nil
+		}
+	}
+
+	return nil, errors.New("the path " + pointer + " does not exist")
+}
@@ -0,0 +1,124 @@
+package remediation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// LifecycleRetentionRemediator replaces retention policies that keep releases or files forever with the
+// retention policy defined by the remediationRetentionQuantity and remediationRetentionUnit arguments.
+type LifecycleRetentionRemediator struct {
+	client *client.Client
+	config *config.OctolintConfig
+}
+
+func NewLifecycleRetentionRemediator(client *client.Client, config *config.OctolintConfig) LifecycleRetentionRemediator {
+	return LifecycleRetentionRemediator{client: client, config: config}
+}
+
+func (o LifecycleRetentionRemediator) Id() string {
+	return organization.OctoRecLifecycleRetention
+}
+
+func (o LifecycleRetentionRemediator) Plan(result checks.OctopusCheckResult) ([]RemediationChange, error) {
+	changes := []RemediationChange{}
+
+	for _, finding := range result.Findings() {
+		if finding.ResourceType != checks.ResourceTypeLifecycle || finding.ResourceId == "" {
+			continue
+		}
+
+		lifecycle, err := o.client.Lifecycles.GetByID(finding.ResourceId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		change := lifecycleRetentionChange(o.client.GetSpaceID(), lifecycle, o.retentionPolicy())
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, nil
+}
+
+func (o LifecycleRetentionRemediator) retentionPolicy() *core.RetentionPeriod {
+	return core.CountBasedRetentionPeriod(int32(o.config.RemediationRetentionQuantity), o.config.RemediationRetentionUnit)
+}
+
+// lifecycleRetentionChange builds the change that replaces every retention policy that keeps releases or files
+// forever. Phases that inherit the lifecycle retention policy are left unchanged.
+func lifecycleRetentionChange(spaceId string, lifecycle *lifecycles.Lifecycle, policy *core.RetentionPeriod) *RemediationChange {
+	patch := []PatchOperation{}
+	terraform := []string{}
+
+	if keepsForever(lifecycle.ReleaseRetentionPolicy) {
+		patch = append(patch, PatchOperation{Op: "replace", Path: "/ReleaseRetentionPolicy", Value: policy})
+		terraform = append(terraform, terraformRetentionPolicy("  ", "release_retention_policy", policy))
+	}
+
+	if keepsForever(lifecycle.TentacleRetentionPolicy) {
+		patch = append(patch, PatchOperation{Op: "replace", Path: "/TentacleRetentionPolicy", Value: policy})
+		terraform = append(terraform, terraformRetentionPolicy("  ", "tentacle_retention_policy", policy))
+	}
+
+	for i, phase := range lifecycle.Phases {
+		phaseTerraform := []string{}
+
+		if keepsForever(phase.ReleaseRetentionPolicy) {
+			patch = append(patch, PatchOperation{Op: "replace", Path: fmt.Sprintf("/Phases/%d/ReleaseRetentionPolicy", i), Value: policy})
+			phaseTerraform = append(phaseTerraform, terraformRetentionPolicy("    ", "release_retention_policy", policy))
+		}
+
+		if keepsForever(phase.TentacleRetentionPolicy) {
+			patch = append(patch, PatchOperation{Op: "replace", Path: fmt.Sprintf("/Phases/%d/TentacleRetentionPolicy", i), Value: policy})
+			phaseTerraform = append(phaseTerraform, terraformRetentionPolicy("    ", "tentacle_retention_policy", policy))
+		}
+
+		if len(phaseTerraform) != 0 {
+			terraform = append(terraform, "  phase {\n    name = "+fmt.Sprintf("%q", phase.Name)+"\n\n"+strings.Join(phaseTerraform, "\n")+"  }\n")
+		}
+	}
+
+	if len(patch) == 0 {
+		return nil
+	}
+
+	resourceName := terraformResourceName(lifecycle.Name)
+
+	return &RemediationChange{
+		CheckId:      organization.OctoRecLifecycleRetention,
+		ResourceType: checks.ResourceTypeLifecycle,
+		ResourceId:   lifecycle.ID,
+		ResourceName: lifecycle.Name,
+		ApiPath:      "/api/" + spaceId + "/lifecycles/" + lifecycle.ID,
+		Summary: "Keep " + fmt.Sprint(policy.QuantityToKeep) + " " + strings.ToLower(policy.Unit) +
+			" of releases and files in lifecycle " + lifecycle.Name,
+		Patch: patch,
+		Terraform: "# Merge these retention policies into the octopusdeploy_lifecycle resource for " + lifecycle.Name + ".\n" +
+			"# The import block brings the lifecycle under Terraform management if it is not already managed.\n" +
+			"import {\n  to = octopusdeploy_lifecycle." + resourceName + "\n  id = " + fmt.Sprintf("%q", lifecycle.ID) + "\n}\n\n" +
+			"resource \"octopusdeploy_lifecycle\" \"" + resourceName + "\" {\n  name = " + fmt.Sprintf("%q", lifecycle.Name) + "\n\n" +
+			strings.Join(terraform, "\n") + "}\n",
+	}
+}
+
+func keepsForever(policy *core.RetentionPeriod) bool {
+	return policy != nil && policy.ShouldKeepForever
+}
+
+func terraformRetentionPolicy(indent string, block string, policy *core.RetentionPeriod) string {
+	return indent + block + " {\n" +
+		indent + "  quantity_to_keep    = " + fmt.Sprint(policy.QuantityToKeep) + "\n" +
+		indent + "  should_keep_forever = false\n" +
+		indent + "  unit                = " + fmt.Sprintf("%q", policy.Unit) + "\n" +
+		indent + "}\n"
+}
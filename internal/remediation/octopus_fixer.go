@@ -0,0 +1,228 @@
+package remediation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"golang.org/x/exp/slices"
+)
+
+// FixableChecks lists the checks whose changes are considered safe to apply with the -fix argument. Other
+// checks, like replacing HTTP feed URLs, can only be planned because the result depends on external systems.
+var FixableChecks = []string{
+	organization.OctoRecLifecycleRetention,
+	organization.OctoLintUnusedVariables,
+	naming.OctoLintProjectDefaultStepNames,
+}
+
+// UndoRecord captures the state of a resource before it was modified by the fixer.
+type UndoRecord struct {
+	Timestamp time.Time       `json:"timestamp"`
+	CheckId   string          `json:"checkId"`
+	Method    string          `json:"method"`
+	ApiPath   string          `json:"apiPath"`
+	Previous  json.RawMessage `json:"previous"`
+}
+
+// OctopusFixer applies the changes proposed by the remediators. Every change is displayed before it is applied,
+// and the previous state of every resource is appended to an undo log before the resource is modified.
+type OctopusFixer struct {
+	httpClient *http.Client
+	url        string
+	apiKey     string
+	undoLog    string
+	yes        bool
+	in         io.Reader
+	out        io.Writer
+}
+
+func NewOctopusFixer(httpClient *http.Client, url string, apiKey string, undoLog string, yes bool, in io.Reader, out io.Writer) OctopusFixer {
+	return OctopusFixer{
+		httpClient: httpClient,
+		url:        strings.TrimSuffix(url, "/"),
+		apiKey:     apiKey,
+		undoLog:    undoLog,
+		yes:        yes,
+		in:         in,
+		out:        out,
+	}
+}
+
+type pendingFix struct {
+	change   RemediationChange
+	previous []byte
+	updated  []byte
+}
+
+// Fix displays the changes for the fixable checks, asks for confirmation unless the yes argument was set, and
+// applies them. It returns the number of changes that were applied.
+func (o OctopusFixer) Fix(changes []RemediationChange) (int, error) {
+	pending := []pendingFix{}
+
+	for _, change := range changes {
+		if slices.Index(FixableChecks, change.CheckId) == -1 {
+			continue
+		}
+
+		fix, err := o.prepare(change)
+
+		if err != nil {
+			fmt.Fprintln(o.out, "Skipping "+change.Summary+": "+err.Error())
+			continue
+		}
+
+		pending = append(pending, *fix)
+	}
+
+	if len(pending) == 0 {
+		fmt.Fprintln(o.out, "There are no changes to apply")
+		return 0, nil
+	}
+
+	if !o.yes && !o.confirm(len(pending)) {
+		fmt.Fprintln(o.out, "No changes were applied")
+		return 0, nil
+	}
+
+	for i, fix := range pending {
+		if err := o.recordUndo(fix); err != nil {
+			return i, errors.New("failed to write the undo log " + o.undoLog + ": " + err.Error())
+		}
+
+		if _, err := o.request(http.MethodPut, fix.change.ApiPath, fix.updated); err != nil {
+			return i, errors.New("failed to apply the change to " + fix.change.ApiPath + ": " + err.Error())
+		}
+
+		fmt.Fprintln(o.out, "Applied: "+fix.change.Summary)
+	}
+
+	return len(pending), nil
+}
+
+// prepare loads the current state of the resource, applies the patch, and prints the dry run of the change.
+func (o OctopusFixer) prepare(change RemediationChange) (*pendingFix, error) {
+	previous, err := o.request(http.MethodGet, change.ApiPath, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(previous, &document); err != nil {
+		return nil, err
+	}
+
+	updatedDocument, err := applyPatch(document, change.Patch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := json.Marshal(updatedDocument)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(o.out, "PUT "+change.ApiPath+" ("+change.Summary+")")
+	for _, operation := range change.Patch {
+		if operation.Op == "test" {
+			continue
+		}
+
+		oldValue, _ := getPointer(document, operation.Path)
+
+		switch operation.Op {
+		case "remove":
+			fmt.Fprintln(o.out, "  - "+operation.Path+": "+compactJson(oldValue))
+		case "add":
+			fmt.Fprintln(o.out, "  + "+operation.Path+": "+compactJson(operation.Value))
+		default:
+			fmt.Fprintln(o.out, "  ~ "+operation.Path+": "+compactJson(oldValue)+" -> "+compactJson(operation.Value))
+		}
+	}
+
+	return &pendingFix{change: change, previous: previous, updated: updated}, nil
+}
+
+func (o OctopusFixer) confirm(count int) bool {
+	fmt.Fprint(o.out, "Apply "+fmt.Sprint(count)+" changes? Only 'yes' will be accepted: ")
+	answer, _ := bufio.NewReader(o.in).ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
+
+func (o OctopusFixer) recordUndo(fix pendingFix) error {
+	record, err := json.Marshal(UndoRecord{
+		Timestamp: time.Now().UTC(),
+		CheckId:   fix.change.CheckId,
+		Method:    http.MethodPut,
+		ApiPath:   fix.change.ApiPath,
+		Previous:  fix.previous,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(o.undoLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(append(record, '\n'))
+	return err
+}
+
+func (o OctopusFixer) request(method string, apiPath string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, o.url+apiPath, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Octopus-ApiKey", o.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := o.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, errors.New("the server responded with " + res.Status + ": " + string(responseBody))
+	}
+
+	return responseBody, nil
+}
+
+func compactJson(value interface{}) string {
+	encoded, err := json.Marshal(value)
+
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(encoded)
+}
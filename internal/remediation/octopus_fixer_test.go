@@ -0,0 +1,160 @@
+package remediation
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+)
+
+func TestApplyPatch(t *testing.T) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(`{"Name":"Test","Variables":[{"Id":"a"},{"Id":"b"},{"Id":"c"}]}`), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := applyPatch(document, []PatchOperation{
+		{Op: "test", Path: "/Variables/2/Id", Value: "c"},
+		{Op: "remove", Path: "/Variables/2"},
+		{Op: "test", Path: "/Variables/0/Id", Value: "a"},
+		{Op: "remove", Path: "/Variables/0"},
+		{Op: "replace", Path: "/Name", Value: "Updated"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if compactJson(updated) != `{"Name":"Updated","Variables":[{"Id":"b"}]}` {
+		t.Fatal("Should have applied the patch, but returned " + compactJson(updated))
+	}
+
+	if compactJson(document) != `{"Name":"Test","Variables":[{"Id":"a"},{"Id":"b"},{"Id":"c"}]}` {
+		t.Fatal("Should not have modified the original document")
+	}
+}
+
+func TestApplyPatchFailedTest(t *testing.T) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(`{"FeedUri":"https://example.org"}`), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := applyPatch(document, []PatchOperation{{Op: "test", Path: "/FeedUri", Value: "http://example.org"}}); err == nil {
+		t.Fatal("Should have returned an error")
+	}
+}
+
+type fakeOctopus struct {
+	resource string
+	apiKeys  []string
+	puts     []string
+}
+
+func (o *fakeOctopus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.apiKeys = append(o.apiKeys, r.Header.Get("X-Octopus-ApiKey"))
+
+	if r.Method == http.MethodPut {
+		body, _ := io.ReadAll(r.Body)
+		o.puts = append(o.puts, string(body))
+		o.resource = string(body)
+	}
+
+	w.Write([]byte(o.resource))
+}
+
+func lifecycleChange() RemediationChange {
+	return RemediationChange{
+		CheckId: organization.OctoRecLifecycleRetention,
+		ApiPath: "/api/Spaces-1/lifecycles/Lifecycles-1",
+		Summary: "Keep 30 days of releases",
+		Patch: []PatchOperation{{Op: "replace", Path: "/ReleaseRetentionPolicy", Value: map[string]interface{}{
+			"QuantityToKeep": 30, "ShouldKeepForever": false, "Unit": "Days",
+		}}},
+	}
+}
+
+func TestFixWithConfirmation(t *testing.T) {
+	octopus := &fakeOctopus{resource: `{"Id":"Lifecycles-1","ReleaseRetentionPolicy":{"QuantityToKeep":0,"ShouldKeepForever":true,"Unit":"Days"}}`}
+	server := httptest.NewServer(octopus)
+	defer server.Close()
+
+	undoLog := filepath.Join(t.TempDir(), "undo.jsonl")
+	out := &bytes.Buffer{}
+	fixer := NewOctopusFixer(server.Client(), server.URL, "API-WRITE", undoLog, false, strings.NewReader("yes\n"), out)
+
+	applied, err := fixer.Fix([]RemediationChange{lifecycleChange()})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if applied != 1 || len(octopus.puts) != 1 || !strings.Contains(octopus.puts[0], `"QuantityToKeep":30`) {
+		t.Fatal("Should have applied the change")
+	}
+
+	if !strings.Contains(out.String(), "PUT /api/Spaces-1/lifecycles/Lifecycles-1") ||
+		!strings.Contains(out.String(), `~ /ReleaseRetentionPolicy: {"QuantityToKeep":0,"ShouldKeepForever":true,"Unit":"Days"}`) {
+		t.Fatal("Should have displayed the dry run")
+	}
+
+	for _, apiKey := range octopus.apiKeys {
+		if apiKey != "API-WRITE" {
+			t.Fatal("Should have used the write API key")
+		}
+	}
+
+	undo, err := os.ReadFile(undoLog)
+
+	if err != nil || !strings.Contains(string(undo), `"previous":{"Id":"Lifecycles-1","ReleaseRetentionPolicy":{"QuantityToKeep":0`) {
+		t.Fatal("Should have recorded the previous resource in the undo log")
+	}
+}
+
+func TestFixDeclined(t *testing.T) {
+	octopus := &fakeOctopus{resource: `{"Id":"Lifecycles-1","ReleaseRetentionPolicy":{"QuantityToKeep":0,"ShouldKeepForever":true,"Unit":"Days"}}`}
+	server := httptest.NewServer(octopus)
+	defer server.Close()
+
+	fixer := NewOctopusFixer(server.Client(), server.URL, "API-WRITE", filepath.Join(t.TempDir(), "undo.jsonl"), false, strings.NewReader("n\n"), &bytes.Buffer{})
+
+	applied, err := fixer.Fix([]RemediationChange{lifecycleChange()})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if applied != 0 || len(octopus.puts) != 0 {
+		t.Fatal("Should not have applied the change")
+	}
+}
+
+func TestFixSkipsChecksNotWhitelisted(t *testing.T) {
+	octopus := &fakeOctopus{resource: `{"Id":"Feeds-1","FeedUri":"http://example.org"}`}
+	server := httptest.NewServer(octopus)
+	defer server.Close()
+
+	fixer := NewOctopusFixer(server.Client(), server.URL, "API-WRITE", filepath.Join(t.TempDir(), "undo.jsonl"), true, strings.NewReader(""), &bytes.Buffer{})
+
+	applied, err := fixer.Fix([]RemediationChange{{
+		CheckId: security.OctoLintInsecureFeeds,
+		ApiPath: "/api/Spaces-1/feeds/Feeds-1",
+		Patch:   []PatchOperation{{Op: "replace", Path: "/FeedUri", Value: "https://example.org"}},
+	}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if applied != 0 || len(octopus.puts) != 0 || len(octopus.apiKeys) != 0 {
+		t.Fatal("Should not have applied the change")
+	}
+}
@@ -0,0 +1,44 @@
+package remediation
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"go.uber.org/zap"
+)
+
+// OctopusRemediator proposes changes that fix the findings reported by a check.
+type OctopusRemediator interface {
+	// Id returns the ID of the check whose findings are fixed by this remediator
+	Id() string
+	// Plan returns the changes that fix the findings in the check result
+	Plan(result checks.OctopusCheckResult) ([]RemediationChange, error)
+}
+
+// PlanRemediations builds the changes for all the failed check results that have a remediator. Errors are
+// logged and skipped so one resource that can not be loaded does not prevent the other changes from being planned.
+func PlanRemediations(remediators []OctopusRemediator, results []checks.OctopusCheckResult) []RemediationChange {
+	changes := []RemediationChange{}
+
+	for _, result := range results {
+		if result == nil || result.Severity() < checks.Warning {
+			continue
+		}
+
+		for _, remediator := range remediators {
+			if remediator.Id() != result.Code() {
+				continue
+			}
+
+			checkChanges, err := remediator.Plan(result)
+
+			if err != nil {
+				zap.L().Error("Failed to plan the remediation for check "+result.Code(), logging.CheckId(result.Code()), zap.Error(err))
+				continue
+			}
+
+			changes = append(changes, checkChanges...)
+		}
+	}
+
+	return changes
+}
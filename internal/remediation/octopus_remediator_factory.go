@@ -0,0 +1,16 @@
+package remediation
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// BuildAllRemediators creates the remediators for the checks whose findings can be fixed mechanically.
+func BuildAllRemediators(client *client.Client, config *config.OctolintConfig) []OctopusRemediator {
+	return []OctopusRemediator{
+		NewLifecycleRetentionRemediator(client, config),
+		NewInsecureFeedsRemediator(client),
+		NewDefaultStepNamesRemediator(client),
+		NewUnusedVariablesRemediator(client),
+	}
+}
@@ -0,0 +1,50 @@
+package remediation
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// planIndexFile lists all the changes in the plan directory.
+const planIndexFile = "index.json"
+
+// WritePlan saves each change as a JSON Patch document, and a Terraform snippet where one is available, in a
+// directory per check. An index file describes the resource that each patch is applied to.
+func WritePlan(directory string, changes []RemediationChange) error {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		checkDirectory := filepath.Join(directory, change.CheckId)
+
+		if err := os.MkdirAll(checkDirectory, 0755); err != nil {
+			return err
+		}
+
+		patch, err := json.MarshalIndent(change.Patch, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(checkDirectory, change.ResourceId+".patch.json"), patch, 0644); err != nil {
+			return err
+		}
+
+		if change.Terraform != "" {
+			if err := os.WriteFile(filepath.Join(checkDirectory, change.ResourceId+".tf"), []byte(change.Terraform), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	index, err := json.MarshalIndent(changes, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(directory, planIndexFile), index, 0644)
+}
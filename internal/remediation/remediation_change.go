@@ -0,0 +1,61 @@
+package remediation
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// PatchOperation is a JSON Patch (RFC 6902) operation applied to an Octopus REST resource.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON writes the value of add, replace and test operations even when it is an empty string, zero, false or
+// null, as RFC 6902 requires. The value is left out of remove operations, which don't have one.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+
+	type operation PatchOperation
+	return json.Marshal(operation(o))
+}
+
+// RemediationChange describes a proposed change that fixes a finding. Changes are never applied by the planner.
+type RemediationChange struct {
+	// CheckId is the ID of the check that reported the finding
+	CheckId string `json:"checkId"`
+	// ResourceType is the type of the resource that is changed, using the checks.ResourceType constants
+	ResourceType string `json:"resourceType"`
+	// ResourceId is the Octopus ID of the resource that is changed
+	ResourceId string `json:"resourceId"`
+	// ResourceName is the name of the resource that is changed
+	ResourceName string `json:"resourceName"`
+	// ApiPath is the path of the REST resource that the patch is applied to, for example /api/Spaces-1/feeds/Feeds-1
+	ApiPath string `json:"apiPath"`
+	// Summary is the human-readable description of the change
+	Summary string `json:"summary"`
+	// Patch is the list of operations that fix the finding
+	Patch []PatchOperation `json:"patch"`
+	// Terraform is an optional snippet of Terraform configuration that fixes the finding
+	Terraform string `json:"-"`
+}
+
+var terraformNameInvalidChars = regexp.MustCompile("[^a-z0-9_]+")
+
+// terraformResourceName converts an Octopus resource name to a valid Terraform resource name.
+func terraformResourceName(name string) string {
+	sanitized := strings.Trim(terraformNameInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+
+	if sanitized == "" || (sanitized[0] >= '0' && sanitized[0] <= '9') {
+		sanitized = "resource_" + sanitized
+	}
+
+	return sanitized
+}
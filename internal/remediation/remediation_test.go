@@ -0,0 +1,238 @@
+package remediation
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+func TestLifecycleRetentionChange(t *testing.T) {
+	lifecycle := lifecycles.NewLifecycle("Default Lifecycle")
+	lifecycle.ID = "Lifecycles-1"
+	lifecycle.ReleaseRetentionPolicy = core.KeepForeverRetentionPeriod()
+	lifecycle.Phases = []*lifecycles.Phase{
+		{Name: "Dev"},
+		{Name: "Prod", TentacleRetentionPolicy: core.KeepForeverRetentionPeriod()},
+	}
+
+	change := lifecycleRetentionChange("Spaces-1", lifecycle, core.CountBasedRetentionPeriod(30, lifecycles.RetentionUnitDays))
+
+	if change == nil {
+		t.Fatal("Should have returned a change")
+	}
+
+	if change.ApiPath != "/api/Spaces-1/lifecycles/Lifecycles-1" {
+		t.Fatal("Should have patched the lifecycle resource")
+	}
+
+	if len(change.Patch) != 2 || change.Patch[0].Path != "/ReleaseRetentionPolicy" || change.Patch[1].Path != "/Phases/1/TentacleRetentionPolicy" {
+		t.Fatal("Should have replaced the retention policies that keep releases forever")
+	}
+
+	if !strings.Contains(change.Terraform, "resource \"octopusdeploy_lifecycle\" \"default_lifecycle\"") ||
+		!strings.Contains(change.Terraform, "name = \"Prod\"") ||
+		strings.Contains(change.Terraform, "name = \"Dev\"") {
+		t.Fatal("Should have generated the terraform snippet")
+	}
+}
+
+func TestLifecycleRetentionNoChange(t *testing.T) {
+	lifecycle := lifecycles.NewLifecycle("Default Lifecycle")
+
+	if lifecycleRetentionChange("Spaces-1", lifecycle, core.CountBasedRetentionPeriod(30, lifecycles.RetentionUnitDays)) != nil {
+		t.Fatal("Should not have returned a change")
+	}
+}
+
+func TestInsecureFeedChange(t *testing.T) {
+	feed, err := feeds.NewNuGetFeed("Local NuGet", "http://nuget.example.org/v3/index.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed.ID = "Feeds-1"
+
+	change := insecureFeedChange("Spaces-1", feed)
+
+	if change == nil {
+		t.Fatal("Should have returned a change")
+	}
+
+	if len(change.Patch) != 2 || change.Patch[0].Op != "test" || change.Patch[1].Value != "https://nuget.example.org/v3/index.json" {
+		t.Fatal("Should have replaced the feed URL")
+	}
+
+	if !strings.Contains(change.Terraform, "octopusdeploy_nuget_feed") {
+		t.Fatal("Should have generated the terraform snippet")
+	}
+}
+
+func TestDefaultStepNamesChange(t *testing.T) {
+	process := &deployments.DeploymentProcess{
+		ProjectID: "Projects-1",
+		Steps: []*deployments.DeploymentStep{
+			{
+				Name: "Deploy a Package",
+				Actions: []*deployments.DeploymentAction{
+					{Name: "Deploy a Package", Packages: []*packages.PackageReference{{PackageID: "MyApp"}}},
+				},
+			},
+			{
+				Name: "Smoke test",
+				Actions: []*deployments.DeploymentAction{
+					{Name: "Run a Script"},
+				},
+			},
+		},
+	}
+	process.ID = "deploymentprocess-Projects-1"
+
+	change := defaultStepNamesChange("Spaces-1", "My Project", process, nil)
+
+	if change == nil {
+		t.Fatal("Should have returned a change")
+	}
+
+	replacements := map[string]interface{}{}
+	for _, p := range change.Patch {
+		if p.Op == "replace" {
+			replacements[p.Path] = p.Value
+		}
+	}
+
+	if replacements["/Steps/0/Name"] != "Deploy a Package (MyApp)" ||
+		replacements["/Steps/0/Actions/0/Name"] != "Deploy a Package (MyApp)" ||
+		replacements["/Steps/1/Actions/0/Name"] != "Run a Script (step 2)" {
+		t.Fatal("Should have renamed the default steps")
+	}
+
+	if _, ok := replacements["/Steps/1/Name"]; ok {
+		t.Fatal("Should not have renamed the step with a custom name")
+	}
+}
+
+func TestDefaultStepNamesChangeSkipsReferencedSteps(t *testing.T) {
+	process := &deployments.DeploymentProcess{
+		ProjectID: "Projects-1",
+		Steps: []*deployments.DeploymentStep{
+			{
+				Name:    "Run a Script",
+				Actions: []*deployments.DeploymentAction{{Name: "Run a Script"}},
+			},
+			{
+				Name: "Deploy a Package",
+				Actions: []*deployments.DeploymentAction{
+					{Name: "Deploy a Package", Packages: []*packages.PackageReference{{PackageID: "MyApp"}}},
+				},
+			},
+			{
+				Name: "Notify",
+				Actions: []*deployments.DeploymentAction{{
+					Name: "Notify",
+					Properties: map[string]core.PropertyValue{
+						"Octopus.Action.Script.ScriptBody": core.NewPropertyValue("echo #{Octopus.Action[Run a Script].Output.Url}", false),
+					},
+				}},
+			},
+		},
+	}
+	process.ID = "deploymentprocess-Projects-1"
+
+	// The package step is referenced by a project variable
+	change := defaultStepNamesChange("Spaces-1", "My Project", process, []string{"#{Octopus.Step[deploy a package].Status.Code}"})
+
+	if change != nil {
+		t.Fatal("Should not have renamed steps that are referenced by name, got: " + change.Summary)
+	}
+
+	change = defaultStepNamesChange("Spaces-1", "My Project", process, nil)
+
+	if change == nil || strings.Contains(change.Summary, "Run a Script") || !strings.Contains(change.Summary, "Deploy a Package") {
+		t.Fatal("Should only have renamed the steps that are not referenced")
+	}
+}
+
+func TestPatchOperationJson(t *testing.T) {
+	content, err := json.Marshal([]PatchOperation{
+		{Op: "test", Path: "/Name", Value: ""},
+		{Op: "replace", Path: "/IsDisabled", Value: false},
+		{Op: "remove", Path: "/Variables/0"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != `[{"op":"test","path":"/Name","value":""},{"op":"replace","path":"/IsDisabled","value":false},{"op":"remove","path":"/Variables/0"}]` {
+		t.Fatal("Should have written the value of every operation except remove, got: " + string(content))
+	}
+}
+
+func TestWritePlan(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "plan")
+	changes := []RemediationChange{{
+		CheckId:    "OctoLintInsecureFeedsTargets",
+		ResourceId: "Feeds-1",
+		Patch:      []PatchOperation{{Op: "replace", Path: "/FeedUri", Value: "https://example.org"}},
+		Terraform:  "resource {}",
+	}}
+
+	if err := WritePlan(directory, changes); err != nil {
+		t.Fatal(err)
+	}
+
+	patch, err := os.ReadFile(filepath.Join(directory, "OctoLintInsecureFeedsTargets", "Feeds-1.patch.json"))
+
+	if err != nil {
+		t.Fatal("Should have written the patch")
+	}
+
+	operations := []PatchOperation{}
+	if err := json.Unmarshal(patch, &operations); err != nil || len(operations) != 1 {
+		t.Fatal("Should have written a JSON Patch document")
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, "OctoLintInsecureFeedsTargets", "Feeds-1.tf")); err != nil {
+		t.Fatal("Should have written the terraform snippet")
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, planIndexFile)); err != nil {
+		t.Fatal("Should have written the index")
+	}
+}
+
+func TestUnusedVariablesChange(t *testing.T) {
+	variableSet := &variables.VariableSet{
+		OwnerID: "Projects-1",
+		Variables: []*variables.Variable{
+			{Name: "Unused1", Resource: resources.Resource{ID: "a"}},
+			{Name: "Used", Resource: resources.Resource{ID: "b"}},
+			{Name: "Unused2", Resource: resources.Resource{ID: "c"}},
+		},
+	}
+	variableSet.ID = "variableset-Projects-1"
+
+	change := unusedVariablesChange("Spaces-1", "My Project", variableSet, []string{"a", "c"})
+
+	if change == nil {
+		t.Fatal("Should have returned a change")
+	}
+
+	if change.ApiPath != "/api/Spaces-1/variables/variableset-Projects-1" {
+		t.Fatal("Should have patched the variable set")
+	}
+
+	if len(change.Patch) != 4 || change.Patch[1].Path != "/Variables/2" || change.Patch[3].Path != "/Variables/0" {
+		t.Fatal("Should have removed the variables from the highest index to the lowest")
+	}
+}
@@ -0,0 +1,110 @@
+package remediation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// UnusedVariablesRemediator removes project variables that are not referenced by the project.
+type UnusedVariablesRemediator struct {
+	client *client.Client
+}
+
+func NewUnusedVariablesRemediator(client *client.Client) UnusedVariablesRemediator {
+	return UnusedVariablesRemediator{client: client}
+}
+
+func (o UnusedVariablesRemediator) Id() string {
+	return organization.OctoLintUnusedVariables
+}
+
+func (o UnusedVariablesRemediator) Plan(result checks.OctopusCheckResult) ([]RemediationChange, error) {
+	changes := []RemediationChange{}
+	variableIds := map[string][]string{}
+	projectIds := []string{}
+
+	for _, finding := range result.Findings() {
+		if finding.ResourceType != checks.ResourceTypeVariable || finding.ResourceId == "" || finding.ParentId == "" {
+			continue
+		}
+
+		if _, ok := variableIds[finding.ParentId]; !ok {
+			projectIds = append(projectIds, finding.ParentId)
+		}
+		variableIds[finding.ParentId] = append(variableIds[finding.ParentId], finding.ResourceId)
+	}
+
+	for _, projectId := range projectIds {
+		project, err := o.client.Projects.GetByID(projectId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Non-sensitive variables of a version controlled project are saved in Git rather than the REST API
+		if project.IsVersionControlled {
+			zap.L().Debug("Skipping the remediation of "+o.Id()+" for project "+project.Name+" because the variables are not in the database", logging.CheckId(o.Id()), logging.Project(project.Name))
+			continue
+		}
+
+		variableSet, err := o.client.Variables.GetAll(projectId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		change := unusedVariablesChange(o.client.GetSpaceID(), project.Name, &variableSet, variableIds[projectId])
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, nil
+}
+
+// unusedVariablesChange builds the change that removes the unused variables from a project variable set. Variables
+// are removed from the highest index to the lowest so the indexes of the remaining operations stay valid, and each
+// removal is preceded by a test of the variable ID.
+func unusedVariablesChange(spaceId string, projectName string, variableSet *variables.VariableSet, unusedIds []string) *RemediationChange {
+	indexes := []int{}
+	names := []string{}
+
+	for i, variable := range variableSet.Variables {
+		if variable != nil && slices.Index(unusedIds, variable.ID) != -1 {
+			indexes = append(indexes, i)
+			names = append(names, variable.Name)
+		}
+	}
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+
+	patch := []PatchOperation{}
+	for _, i := range indexes {
+		patch = append(patch,
+			PatchOperation{Op: "test", Path: fmt.Sprintf("/Variables/%d/Id", i), Value: variableSet.Variables[i].ID},
+			PatchOperation{Op: "remove", Path: fmt.Sprintf("/Variables/%d", i)})
+	}
+
+	return &RemediationChange{
+		CheckId:      organization.OctoLintUnusedVariables,
+		ResourceType: checks.ResourceTypeProject,
+		ResourceId:   variableSet.OwnerID,
+		ResourceName: projectName,
+		ApiPath:      "/api/" + spaceId + "/variables/" + variableSet.ID,
+		Summary:      "Remove the unused variables " + strings.Join(names, ", ") + " from project " + projectName,
+		Patch:        patch,
+	}
+}
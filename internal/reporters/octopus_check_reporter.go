@@ -1,8 +1,37 @@
 package reporters
 
-import "github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+import (
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
 
 // OctopusCheckReporter defines the contract used by reporters to print the result of lint checks.
 type OctopusCheckReporter interface {
 	Generate(results []checks.OctopusCheckResult) (string, error)
 }
+
+// partialCoverageChecks returns the codes of the checks that did not scan every available resource. This includes
+// passing checks, as a check that passed after scanning a sample of the space does not mean the space has no issues.
+func partialCoverageChecks(results []checks.OctopusCheckResult) []string {
+	codes := []string{}
+	for _, r := range results {
+		if r != nil && checks.IsPartialCoverage(r) {
+			codes = append(codes, r.Code())
+		}
+	}
+	return codes
+}
+
+// partialCoverageWarning returns a warning listing the checks that did not scan every available resource, or an
+// empty string if every check scanned every resource.
+func partialCoverageWarning(results []checks.OctopusCheckResult) string {
+	codes := partialCoverageChecks(results)
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return "Warning: the following checks did not scan every resource, so some issues may not have been reported: " +
+		strings.Join(codes, ", ")
+}
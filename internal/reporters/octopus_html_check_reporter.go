@@ -0,0 +1,222 @@
+package reporters
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+// OctopusHtmlCheckReporter prints the lint reports as a self-contained HTML document. The document embeds all
+// styles and scripts so it can be shared as a single file and viewed offline.
+type OctopusHtmlCheckReporter struct {
+	minSeverity int
+	url         string
+	space       string
+	weights     scoring.Weights
+}
+
+func NewOctopusHtmlCheckReporter(minSeverity int, url string, space string, weights scoring.Weights) OctopusHtmlCheckReporter {
+	return OctopusHtmlCheckReporter{minSeverity: minSeverity, url: url, space: space, weights: weights}
+}
+
+type htmlReport struct {
+	Generated  string
+	Url        string
+	Space      string
+	Score      scoring.Score
+	Partial    []string
+	Severities []string
+	Summary    []htmlSummaryRow
+	Checks     []htmlCheck
+}
+
+type htmlSummaryRow struct {
+	Category string
+	Counts   []int
+	Total    int
+}
+
+type htmlCheck struct {
+	Code          string
+	Category      string
+	Severity      string
+	Description   string
+	Documentation string
+	Coverage      string
+	Findings      []htmlFinding
+}
+
+type htmlFinding struct {
+	ResourceType string
+	ResourceName string
+	ParentName   string
+	Description  string
+	Link         string
+}
+
+var htmlSeverities = []int{checks.Error, checks.Warning, checks.Info, checks.Permission}
+
+func (o OctopusHtmlCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	report := htmlReport{
+		Generated: time.Now().UTC().Format(time.RFC1123),
+		Url:       o.url,
+		Space:     o.space,
+		Score:     scoring.Calculate(results, o.weights),
+		Partial:   partialCoverageChecks(results),
+	}
+
+	for _, severity := range htmlSeverities {
+		report.Severities = append(report.Severities, checks.SeverityName(severity))
+	}
+
+	summary := map[string]*htmlSummaryRow{}
+
+	for _, r := range results {
+		if r == nil || r.Severity() < o.minSeverity || r.Severity() == checks.Ok {
+			continue
+		}
+
+		row, ok := summary[r.Category()]
+		if !ok {
+			row = &htmlSummaryRow{Category: r.Category(), Counts: make([]int, len(htmlSeverities))}
+			summary[r.Category()] = row
+		}
+
+		check := htmlCheck{
+			Code:          r.Code(),
+			Category:      r.Category(),
+			Severity:      checks.SeverityName(r.Severity()),
+			Description:   r.Description(),
+			Documentation: CheckDocumentationLink(r),
+			Coverage:      checks.CoverageDescription(r),
+		}
+
+		if rank := severityRank(check.Severity); rank < len(htmlSeverities) {
+			row.Counts[rank]++
+		}
+		row.Total++
+
+		for _, f := range r.Findings() {
+			check.Findings = append(check.Findings, htmlFinding{
+				ResourceType: f.ResourceType,
+				ResourceName: f.ResourceName,
+				ParentName:   f.ParentName,
+				Description:  f.Description,
+				Link:         ResourceLink(o.url, o.space, f),
+			})
+		}
+
+		report.Checks = append(report.Checks, check)
+	}
+
+	for _, row := range summary {
+		report.Summary = append(report.Summary, *row)
+	}
+
+	sort.Slice(report.Summary, func(i, j int) bool {
+		return report.Summary[i].Category < report.Summary[j].Category
+	})
+
+	// Display the most severe checks first
+	sort.SliceStable(report.Checks, func(i, j int) bool {
+		return severityRank(report.Checks[i].Severity) < severityRank(report.Checks[j].Severity)
+	})
+
+	var output bytes.Buffer
+	if err := htmlReportTemplate.Execute(&output, report); err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+func severityRank(name string) int {
+	for i, severity := range htmlSeverities {
+		if checks.SeverityName(severity) == name {
+			return i
+		}
+	}
+	return len(htmlSeverities)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Octolint Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f303f; }
+h1 { font-size: 1.6em; }
+table { border-collapse: collapse; margin: 1em 0; width: 100%; }
+th, td { border: 1px solid #dae2e9; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f4f6f8; }
+table.sortable th { cursor: pointer; user-select: none; }
+table.sortable th:after { content: " \2195"; color: #9eaab4; }
+details { border: 1px solid #dae2e9; border-radius: 4px; margin: 0.5em 0; padding: 0.5em 1em; }
+summary { cursor: pointer; font-weight: bold; }
+pre { white-space: pre-wrap; background: #f4f6f8; padding: 0.6em; }
+.severity { border-radius: 3px; color: #fff; font-size: 0.85em; padding: 0.1em 0.5em; }
+.Error { background: #d63d3d; }
+.Warning { background: #d87b00; }
+.Info { background: #1a77ca; }
+.Permission { background: #66737f; }
+.meta { color: #66737f; }
+.partial { border-left: 4px solid #d87b00; background: #fff6e5; padding: 0.5em 1em; }
+</style>
+</head>
+<body>
+<h1>Octolint Report</h1>
+<p class="meta">Generated {{.Generated}}{{if .Url}} for {{.Url}}{{end}}{{if .Space}} ({{.Space}}){{end}}</p>
+<h2>Health score: {{.Score.Overall}}/100</h2>
+{{if .Score.Categories}}<table>
+<thead><tr>{{range .Score.Categories}}<th>{{.Category}}</th>{{end}}</tr></thead>
+<tbody><tr>{{range .Score.Categories}}<td>{{.Score}}</td>{{end}}</tr></tbody>
+</table>
+{{end}}{{if .Partial}}<p class="partial">The following checks did not scan every resource, so some issues may not have been reported: {{range $i, $code := .Partial}}{{if $i}}, {{end}}{{$code}}{{end}}</p>
+{{end}}{{if .Checks}}
+<h2>Summary</h2>
+<table>
+<thead><tr><th>Category</th>{{range .Severities}}<th>{{.}}</th>{{end}}<th>Total</th></tr></thead>
+<tbody>
+{{range .Summary}}<tr><td>{{.Category}}</td>{{range .Counts}}<td>{{.}}</td>{{end}}<td>{{.Total}}</td></tr>
+{{end}}</tbody>
+</table>
+<h2>Checks</h2>
+{{range .Checks}}<details>
+<summary><span class="severity {{.Severity}}">{{.Severity}}</span> {{.Code}} ({{.Category}}{{if .Findings}}, {{len .Findings}} findings{{end}})</summary>
+<p><a href="{{.Documentation}}">Documentation for {{.Code}}</a></p>
+{{if .Coverage}}<p class="meta">{{.Coverage}}</p>
+{{end}}{{if .Findings}}<table class="sortable">
+<thead><tr><th>Type</th><th>Name</th><th>Parent</th><th>Description</th></tr></thead>
+<tbody>
+{{range .Findings}}<tr><td>{{.ResourceType}}</td><td>{{if .Link}}<a href="{{.Link}}">{{.ResourceName}}</a>{{else}}{{.ResourceName}}{{end}}</td><td>{{.ParentName}}</td><td>{{.Description}}</td></tr>
+{{end}}</tbody>
+</table>
+{{else}}<pre>{{.Description}}</pre>
+{{end}}</details>
+{{end}}{{else}}<p>No issues detected</p>
+{{end}}<script>
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (header, column) {
+    header.addEventListener("click", function () {
+      var body = table.tBodies[0];
+      var ascending = header.getAttribute("data-order") !== "asc";
+      table.querySelectorAll("th").forEach(function (h) { h.removeAttribute("data-order"); });
+      header.setAttribute("data-order", ascending ? "asc" : "desc");
+      Array.from(body.rows)
+        .sort(function (a, b) {
+          var result = a.cells[column].textContent.localeCompare(b.cells[column].textContent, undefined, {numeric: true});
+          return ascending ? result : -result;
+        })
+        .forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
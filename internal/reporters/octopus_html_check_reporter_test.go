@@ -0,0 +1,54 @@
+package reporters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+func TestHtmlReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImplWithFindings("This check always fails", "OctoRecAlwaysFail", "", checks.Warning, checks.Organization, []checks.OctopusCheckFinding{
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "<Project 1>", Description: "<Project 1>"},
+	})
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	results, err := NewOctopusHtmlCheckReporter(checks.Warning, "https://example.octopus.app", "Spaces-1", scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{failedResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "https://example.octopus.app/app#/Spaces-1/projects/Projects-1") {
+		t.Fatal("Should have linked to the project")
+	}
+
+	if !strings.Contains(results, WikiUrl+"/OctoRecAlwaysFail") {
+		t.Fatal("Should have linked to the documentation")
+	}
+
+	if strings.Contains(results, "<Project 1>") || !strings.Contains(results, "&lt;Project 1&gt;") {
+		t.Fatal("Should have escaped the resource name")
+	}
+
+	if strings.Contains(results, "OctoRecAlwaysPass") {
+		t.Fatal("Should not have included the passing check")
+	}
+
+	if strings.Contains(results, "<link") || strings.Contains(results, "src=") {
+		t.Fatal("Should not have referenced external assets")
+	}
+}
+
+func TestHtmlReportNoIssues(t *testing.T) {
+	results, err := NewOctopusHtmlCheckReporter(checks.Warning, "", "", scoring.DefaultWeights()).Generate(nil)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "No issues detected") {
+		t.Fatal("Should have reported no issues")
+	}
+}
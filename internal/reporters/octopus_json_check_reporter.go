@@ -0,0 +1,170 @@
+package reporters
+
+import (
+	"encoding/json"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+// JsonReport is the document produced by the OctopusJsonCheckReporter.
+type JsonReport struct {
+	Score *JsonScore `json:"score,omitempty"`
+	// PartialCoverageChecks lists the checks that did not scan every resource, including checks that passed
+	PartialCoverageChecks []string          `json:"partialCoverageChecks,omitempty"`
+	Results               []JsonCheckResult `json:"results"`
+}
+
+// JsonScore is the serialized form of a scoring.Score.
+type JsonScore struct {
+	Overall    int            `json:"overall"`
+	Categories map[string]int `json:"categories"`
+}
+
+// JsonCheckResult is the serialized form of a checks.OctopusCheckResult.
+type JsonCheckResult struct {
+	Code        string        `json:"code"`
+	Description string        `json:"description"`
+	Link        string        `json:"link"`
+	Severity    int           `json:"severity"`
+	Category    string        `json:"category"`
+	Findings    []JsonFinding `json:"findings"`
+	// Coverage is the number of resources of each type the check scanned, if the check records it
+	Coverage        []JsonCoverage `json:"coverage,omitempty"`
+	PartialCoverage bool           `json:"partialCoverage"`
+}
+
+// JsonCoverage is the serialized form of a checks.ResourceCoverage.
+type JsonCoverage struct {
+	ResourceType string `json:"resourceType"`
+	Available    int    `json:"available"`
+	Scanned      int    `json:"scanned"`
+	Skipped      int    `json:"skipped"`
+}
+
+// JsonFinding is the serialized form of a checks.OctopusCheckFinding.
+type JsonFinding struct {
+	ResourceType string `json:"resourceType"`
+	ResourceId   string `json:"resourceId,omitempty"`
+	ResourceName string `json:"resourceName"`
+	ParentId     string `json:"parentId,omitempty"`
+	ParentName   string `json:"parentName,omitempty"`
+	RunbookId    string `json:"runbookId,omitempty"`
+	Description  string `json:"description"`
+	File         string `json:"file,omitempty"`
+	Line         int    `json:"line,omitempty"`
+}
+
+// ToFinding converts the serialized finding back to a checks.OctopusCheckFinding.
+func (o JsonFinding) ToFinding() checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{
+		ResourceType: o.ResourceType,
+		ResourceId:   o.ResourceId,
+		ResourceName: o.ResourceName,
+		ParentId:     o.ParentId,
+		ParentName:   o.ParentName,
+		RunbookId:    o.RunbookId,
+		Description:  o.Description,
+		File:         o.File,
+		Line:         o.Line,
+	}
+}
+
+// NewJsonCheckResult converts a check result to its serialized form.
+func NewJsonCheckResult(result checks.OctopusCheckResult) JsonCheckResult {
+	findings := []JsonFinding{}
+	for _, f := range result.Findings() {
+		findings = append(findings, JsonFinding{
+			ResourceType: f.ResourceType,
+			ResourceId:   f.ResourceId,
+			ResourceName: f.ResourceName,
+			ParentId:     f.ParentId,
+			ParentName:   f.ParentName,
+			RunbookId:    f.RunbookId,
+			Description:  f.Description,
+			File:         f.File,
+			Line:         f.Line,
+		})
+	}
+
+	coverage := []JsonCoverage{}
+	for _, c := range checks.Coverage(result) {
+		coverage = append(coverage, JsonCoverage{
+			ResourceType: c.ResourceType,
+			Available:    c.Available,
+			Scanned:      c.Scanned,
+			Skipped:      c.Skipped,
+		})
+	}
+
+	return JsonCheckResult{
+		Code:            result.Code(),
+		Description:     result.Description(),
+		Link:            result.Link(),
+		Severity:        result.Severity(),
+		Category:        result.Category(),
+		Findings:        findings,
+		Coverage:        coverage,
+		PartialCoverage: checks.IsPartialCoverage(result),
+	}
+}
+
+// ToResult converts the serialized result back to a check result.
+func (o JsonCheckResult) ToResult() checks.OctopusCheckResultImpl {
+	findings := []checks.OctopusCheckFinding{}
+	for _, f := range o.Findings {
+		findings = append(findings, f.ToFinding())
+	}
+
+	coverage := []checks.ResourceCoverage{}
+	for _, c := range o.Coverage {
+		coverage = append(coverage, checks.ResourceCoverage{
+			ResourceType: c.ResourceType,
+			Available:    c.Available,
+			Scanned:      c.Scanned,
+			Skipped:      c.Skipped,
+		})
+	}
+
+	return checks.NewOctopusCheckResultImplWithFindings(o.Description, o.Code, o.Link, o.Severity, o.Category, findings).
+		WithCoverage(coverage...)
+}
+
+// OctopusJsonCheckReporter prints the lint reports as a JSON document that can be consumed by other tools.
+type OctopusJsonCheckReporter struct {
+	minSeverity int
+	weights     scoring.Weights
+}
+
+func NewOctopusJsonCheckReporter(minSeverity int, weights scoring.Weights) OctopusJsonCheckReporter {
+	return OctopusJsonCheckReporter{minSeverity: minSeverity, weights: weights}
+}
+
+func (o OctopusJsonCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	score := scoring.Calculate(results, o.weights)
+
+	report := JsonReport{
+		Score:   &JsonScore{Overall: score.Overall, Categories: score.CategoryMap()},
+		Results: []JsonCheckResult{},
+	}
+
+	if codes := partialCoverageChecks(results); len(codes) != 0 {
+		report.PartialCoverageChecks = codes
+	}
+
+	for _, r := range results {
+		if r == nil || r.Severity() < o.minSeverity {
+			continue
+		}
+
+		report.Results = append(report.Results, NewJsonCheckResult(r))
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
@@ -0,0 +1,64 @@
+package reporters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+func TestJsonReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImplWithFindings("This check always fails", "OctoRecAlwaysFail", "", checks.Warning, checks.Organization, []checks.OctopusCheckFinding{
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project 1", Description: "Project 1"},
+	})
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	results, err := NewOctopusJsonCheckReporter(checks.Ok, scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{failedResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.Results) != 2 {
+		t.Fatal("Should have returned 2 results")
+	}
+
+	if len(report.Results[0].Findings) != 1 || report.Results[0].Findings[0].ToFinding().Identity() != "Project//Projects-1" {
+		t.Fatal("Should have returned the finding for Projects-1")
+	}
+
+	if report.Score == nil || report.Score.Overall != 70 || report.Score.Categories[checks.Organization] != 70 {
+		t.Fatal("Should have returned the health score")
+	}
+}
+
+func TestJsonReportCoverage(t *testing.T) {
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization).
+		WithCoverage(checks.NewResourceCoverage(checks.ResourceTypeProject, 250, 100, 0), checks.NewFullResourceCoverage(checks.ResourceTypeTarget, 10))
+
+	results, err := NewOctopusJsonCheckReporter(checks.Ok, scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.PartialCoverageChecks) != 1 || report.PartialCoverageChecks[0] != "OctoRecAlwaysPass" {
+		t.Fatal("Should have listed the check with partial coverage")
+	}
+
+	coverage := report.Results[0].Coverage
+	if !report.Results[0].PartialCoverage || len(coverage) != 2 || coverage[0].Available != 250 || coverage[0].Scanned != 100 || coverage[1].ResourceType != checks.ResourceTypeTarget {
+		t.Fatal("Should have returned the coverage of the check, got " + results)
+	}
+}
@@ -0,0 +1,126 @@
+package reporters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+// markdownFindingLimits are the maximum number of findings listed per check, tried in order until the report
+// fits within the maximum length. A negative value lists every finding.
+var markdownFindingLimits = []int{-1, 100, 50, 20, 10, 5, 1, 0}
+
+// OctopusMarkdownCheckReporter prints the lint reports as Markdown that can be posted to pull request comments
+// and CI job summaries. These services limit the size of a comment, so long lists of findings are truncated.
+type OctopusMarkdownCheckReporter struct {
+	minSeverity int
+	maxLength   int
+	weights     scoring.Weights
+}
+
+func NewOctopusMarkdownCheckReporter(minSeverity int, maxLength int, weights scoring.Weights) OctopusMarkdownCheckReporter {
+	return OctopusMarkdownCheckReporter{minSeverity: minSeverity, maxLength: maxLength, weights: weights}
+}
+
+func (o OctopusMarkdownCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	failed := []checks.OctopusCheckResult{}
+	for _, r := range results {
+		if r != nil && r.Severity() >= o.minSeverity && r.Severity() > checks.Ok {
+			failed = append(failed, r)
+		}
+	}
+
+	score := scoring.Calculate(results, o.weights)
+
+	if len(failed) == 0 {
+		report := "No issues detected\n\n"
+		if warning := partialCoverageWarning(results); warning != "" {
+			report += "> " + escapeMarkdown(warning) + "\n\n"
+		}
+		return report + "**" + score.String() + "**", nil
+	}
+
+	report := ""
+	for _, limit := range markdownFindingLimits {
+		report = o.buildReport(failed, partialCoverageWarning(results), score, limit)
+		if o.maxLength <= 0 || len(report) <= o.maxLength {
+			return report, nil
+		}
+	}
+
+	// Even the summary table is too long, so cut the report at the last complete line
+	truncated := report[:o.maxLength]
+	if index := strings.LastIndex(truncated, "\n"); index > 0 {
+		truncated = truncated[:index]
+	}
+	return truncated + "\n\n_The report was truncated._", nil
+}
+
+func (o OctopusMarkdownCheckReporter) buildReport(results []checks.OctopusCheckResult, warning string, score scoring.Score, findingLimit int) string {
+	var builder strings.Builder
+
+	builder.WriteString("## Octolint Report\n\n")
+	builder.WriteString("**" + score.String() + "**\n\n")
+
+	if warning != "" {
+		builder.WriteString("> " + escapeMarkdown(warning) + "\n\n")
+	}
+
+	builder.WriteString("| Check | Category | Severity | Findings |\n")
+	builder.WriteString("|-------|----------|----------|----------|\n")
+
+	for _, r := range results {
+		builder.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s | %d |\n",
+			r.Code(), CheckDocumentationLink(r), r.Category(), checks.SeverityName(r.Severity()), checks.FindingCount(r)))
+	}
+
+	for _, r := range results {
+		builder.WriteString("\n<details>\n<summary>" + r.Code() + "</summary>\n\n")
+
+		if coverage := checks.CoverageDescription(r); coverage != "" {
+			builder.WriteString("_" + escapeMarkdown(coverage) + "_\n\n")
+		}
+
+		findings := r.Findings()
+		if len(findings) == 0 {
+			builder.WriteString(escapeMarkdown(r.Description()) + "\n")
+		} else {
+			shown := findings
+			if findingLimit >= 0 && len(findings) > findingLimit {
+				shown = findings[:findingLimit]
+			}
+
+			for _, f := range shown {
+				builder.WriteString("* " + escapeMarkdown(f.Description) + "\n")
+			}
+
+			if len(shown) < len(findings) {
+				builder.WriteString(fmt.Sprintf("* _and %d more_\n", len(findings)-len(shown)))
+			}
+		}
+
+		builder.WriteString("\n</details>\n")
+	}
+
+	builder.WriteString("\nThe checks are documented at " + WikiUrl + "\n")
+
+	return builder.String()
+}
+
+// escapeMarkdown prevents descriptions from being interpreted as markdown or HTML.
+func escapeMarkdown(text string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"<", "&lt;",
+		">", "&gt;",
+		"*", "\\*",
+		"_", "\\_",
+		"|", "\\|",
+		"`", "\\`",
+		"[", "\\[",
+		"]", "\\]",
+		"\n", "  \n")
+	return replacer.Replace(text)
+}
@@ -0,0 +1,58 @@
+package reporters
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+func TestMarkdownReport(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImplWithFindings("This check always fails", "OctoRecAlwaysFail", "", checks.Warning, checks.Organization, []checks.OctopusCheckFinding{
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project_1", Description: "Project_1"},
+	})
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	results, err := NewOctopusMarkdownCheckReporter(checks.Warning, 0, scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{failedResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "| Organization | Warning | 1 |") {
+		t.Fatal("Should have included the summary table")
+	}
+
+	if !strings.Contains(results, "<details>") || !strings.Contains(results, "* Project\\_1") {
+		t.Fatal("Should have included the escaped finding")
+	}
+
+	if strings.Contains(results, "OctoRecAlwaysPass") {
+		t.Fatal("Should not have included the passing check")
+	}
+}
+
+func TestMarkdownReportTruncation(t *testing.T) {
+	findings := []checks.OctopusCheckFinding{}
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("Variable%d", i)
+		findings = append(findings, checks.OctopusCheckFinding{ResourceType: checks.ResourceTypeVariable, ResourceName: name, Description: name})
+	}
+	failedResult := checks.NewOctopusCheckResultImplWithFindings("Unused variables", "OctoLintUnusedVariables", "", checks.Warning, checks.Optimization, findings)
+
+	results, err := NewOctopusMarkdownCheckReporter(checks.Warning, 2000, scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{failedResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) > 2000 {
+		t.Fatal("Should have truncated the report")
+	}
+
+	if !strings.Contains(results, "| 1000 |") || !strings.Contains(results, "_and 900 more_") {
+		t.Fatal("Should have reported the number of truncated findings: " + results)
+	}
+}
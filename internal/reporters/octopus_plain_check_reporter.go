@@ -2,16 +2,18 @@ package reporters
 
 import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
 	"strings"
 )
 
 // OctopusPlainCheckReporter prints the lint reports in plain text to std out.
 type OctopusPlainCheckReporter struct {
 	minSeverity int
+	weights     scoring.Weights
 }
 
-func NewOctopusPlainCheckReporter(minSeverity int) OctopusPlainCheckReporter {
-	return OctopusPlainCheckReporter{minSeverity: minSeverity}
+func NewOctopusPlainCheckReporter(minSeverity int, weights scoring.Weights) OctopusPlainCheckReporter {
+	return OctopusPlainCheckReporter{minSeverity: minSeverity, weights: weights}
 }
 
 func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
@@ -26,13 +28,25 @@ func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult)
 			report = append(report, "====================================================================================================")
 			report = append(report, r.Code())
 			report = append(report, r.Description())
+
+			if coverage := checks.CoverageDescription(r); coverage != "" {
+				report = append(report, coverage)
+			}
 		}
 	}
 
+	score := scoring.Calculate(results, o.weights).String()
+
+	// A clean report is only trustworthy if every resource was scanned
+	if warning := partialCoverageWarning(results); warning != "" {
+		score = warning + "\n\n" + score
+	}
+
 	if len(report) == 0 {
-		return "No issues detected", nil
+		return "No issues detected\n\n" + score, nil
 	} else {
-		report = append(report, "The checks are documented at https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/wiki")
+		report = append(report, score)
+		report = append(report, "The checks are documented at "+WikiUrl)
 	}
 
 	return strings.Join(report[:], "\n\n"), nil
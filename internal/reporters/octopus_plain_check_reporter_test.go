@@ -62,3 +62,27 @@ func TestFailAndPassWithOkChecks(t *testing.T) {
 		t.Fatal("Should have returned 1 pass result")
 	}
 }
+
+func TestPartialCoverage(t *testing.T) {
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, "").
+		WithCoverage(checks.NewResourceCoverage(checks.ResourceTypeProject, 250, 100, 2))
+	results, err := OctopusPlainCheckReporter{minSeverity: checks.Ok}.Generate([]checks.OctopusCheckResult{passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "Coverage: Project 98 of 250 scanned, 2 skipped due to permission errors") {
+		t.Fatal("Should have reported the coverage of the check, got " + results)
+	}
+
+	results, err = OctopusPlainCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "No issues detected") || !strings.Contains(results, "did not scan every resource, so some issues may not have been reported: OctoRecAlwaysPass") {
+		t.Fatal("Should have warned that a clean report was based on a partial scan, got " + results)
+	}
+}
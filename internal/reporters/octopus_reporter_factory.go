@@ -0,0 +1,45 @@
+package reporters
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+const (
+	ReportFormatPlain    = "plain"
+	ReportFormatJson     = "json"
+	ReportFormatHtml     = "html"
+	ReportFormatMarkdown = "markdown"
+	ReportFormatWeb      = "web"
+	ReportFormatOctopus  = "octopus"
+)
+
+// ReportFormats lists the formats that can be selected with the reportFormat argument.
+var ReportFormats = []string{ReportFormatPlain, ReportFormatWeb, ReportFormatJson, ReportFormatHtml, ReportFormatMarkdown, ReportFormatOctopus}
+
+// BuildReporter creates the reporter selected by the reportFormat argument.
+func BuildReporter(octolintConfig *config.OctolintConfig) (OctopusCheckReporter, error) {
+	weights := scoring.NewWeights(octolintConfig)
+
+	switch strings.ToLower(strings.TrimSpace(octolintConfig.ReportFormat)) {
+	case "", ReportFormatPlain:
+		return NewOctopusPlainCheckReporter(checks.Warning, weights), nil
+	case ReportFormatJson:
+		// Machine-readable reports include passing checks so they can be compared with later reports
+		return NewOctopusJsonCheckReporter(checks.Ok, weights), nil
+	case ReportFormatHtml:
+		return NewOctopusHtmlCheckReporter(checks.Warning, octolintConfig.Url, octolintConfig.Space, weights), nil
+	case ReportFormatMarkdown:
+		return NewOctopusMarkdownCheckReporter(checks.Warning, octolintConfig.MarkdownMaxLength, weights), nil
+	case ReportFormatWeb:
+		return NewOctopusWebCheckReporter(checks.Warning, weights), nil
+	case ReportFormatOctopus:
+		return NewOctopusServiceMessageCheckReporter(checks.Warning, octolintConfig.OctopusArtifactFile, weights), nil
+	default:
+		return nil, errors.New("the report format " + octolintConfig.ReportFormat + " is not supported. Supported formats are " + strings.Join(ReportFormats, ", "))
+	}
+}
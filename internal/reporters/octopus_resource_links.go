@@ -0,0 +1,106 @@
+package reporters
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+// WikiUrl is the location of the documentation for the checks.
+const WikiUrl = "https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/wiki"
+
+// CheckDocumentationLink returns the link to the documentation for a check result.
+func CheckDocumentationLink(result checks.OctopusCheckResult) string {
+	if result.Link() != "" {
+		return result.Link()
+	}
+
+	return WikiUrl + "/" + url.PathEscape(result.Code())
+}
+
+// ResourceLink builds a link to the Octopus web UI for the resource identified by a finding. An empty string is
+// returned if the finding does not have enough information to build a link.
+func ResourceLink(octopusUrl string, space string, finding checks.OctopusCheckFinding) string {
+	if octopusUrl == "" || space == "" {
+		return ""
+	}
+
+	spaceUrl := strings.TrimSuffix(octopusUrl, "/") + "/app#/" + space
+
+	if finding.ResourceId == "" {
+		// Findings without an ID can still link to the project that owns them
+		if finding.ParentId != "" && strings.HasPrefix(finding.ParentId, "Projects-") {
+			return spaceUrl + "/projects/" + finding.ParentId
+		}
+		return ""
+	}
+
+	switch finding.ResourceType {
+	case checks.ResourceTypeProject:
+		return spaceUrl + "/projects/" + finding.ResourceId
+	case checks.ResourceTypeVariable:
+		if finding.ParentId == "" {
+			return ""
+		}
+		return spaceUrl + "/projects/" + finding.ParentId + "/variables"
+	case checks.ResourceTypeAction:
+		if finding.ParentId == "" {
+			return ""
+		}
+		if finding.RunbookId != "" {
+			return spaceUrl + "/projects/" + finding.ParentId + "/operations/runbooks/" + finding.RunbookId + "/process"
+		}
+		return spaceUrl + "/projects/" + finding.ParentId + "/deployments/process"
+	case checks.ResourceTypeDeployment:
+		if finding.ParentId == "" {
+			return ""
+		}
+		return spaceUrl + "/projects/" + finding.ParentId + "/deployments"
+	case checks.ResourceTypeProjectGroup:
+		return spaceUrl + "/projectGroups/" + finding.ResourceId + "/edit"
+	case checks.ResourceTypeEnvironment:
+		return spaceUrl + "/infrastructure/environments/" + finding.ResourceId
+	case checks.ResourceTypeTarget:
+		return spaceUrl + "/infrastructure/machines/" + finding.ResourceId + "/settings"
+	case checks.ResourceTypeWorker:
+		return spaceUrl + "/infrastructure/workers/" + finding.ResourceId + "/settings"
+	case checks.ResourceTypeWorkerPool:
+		return spaceUrl + "/infrastructure/workerpools/" + finding.ResourceId
+	case checks.ResourceTypeMachinePolicy:
+		return spaceUrl + "/infrastructure/machinepolicies/" + finding.ResourceId
+	case checks.ResourceTypeAccount:
+		return spaceUrl + "/infrastructure/accounts/" + finding.ResourceId
+	case checks.ResourceTypeFeed:
+		return spaceUrl + "/library/feeds/" + finding.ResourceId
+	case checks.ResourceTypeLibraryVariableSet:
+		return spaceUrl + "/library/variables/" + finding.ResourceId
+	case checks.ResourceTypeScriptModule:
+		return spaceUrl + "/library/scripts/" + finding.ResourceId
+	case checks.ResourceTypeCertificate:
+		return spaceUrl + "/library/certificates/" + finding.ResourceId
+	case checks.ResourceTypeGitCredential:
+		return spaceUrl + "/library/gitcredentials/" + finding.ResourceId
+	case checks.ResourceTypeTagSet:
+		return spaceUrl + "/library/tagsets/" + finding.ResourceId
+	case checks.ResourceTypeTag:
+		if finding.ParentId == "" {
+			return ""
+		}
+		return spaceUrl + "/library/tagsets/" + finding.ParentId
+	case checks.ResourceTypeLifecycle:
+		return spaceUrl + "/library/lifecycles/" + finding.ResourceId
+	case checks.ResourceTypeTenant:
+		return spaceUrl + "/tenants/" + finding.ResourceId + "/overview"
+	case checks.ResourceTypeSubscription:
+		return spaceUrl + "/configuration/subscriptions/" + finding.ResourceId
+	case checks.ResourceTypeApiKey:
+		if finding.ParentId == "" {
+			return ""
+		}
+		// API keys are managed on the user, which is not scoped to a space
+		return strings.TrimSuffix(octopusUrl, "/") + "/app#/users/" + finding.ParentId
+	}
+
+	return ""
+}
@@ -0,0 +1,22 @@
+package reporters
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+func TestResourceLinkActions(t *testing.T) {
+	deploymentAction := checks.OctopusCheckFinding{ResourceType: checks.ResourceTypeAction, ResourceId: "action-1", ParentId: "Projects-1"}
+
+	if link := ResourceLink("https://example.octopus.app/", "Spaces-1", deploymentAction); link != "https://example.octopus.app/app#/Spaces-1/projects/Projects-1/deployments/process" {
+		t.Fatal("Should have linked to the deployment process, got " + link)
+	}
+
+	runbookAction := deploymentAction
+	runbookAction.RunbookId = "Runbooks-1"
+
+	if link := ResourceLink("https://example.octopus.app/", "Spaces-1", runbookAction); link != "https://example.octopus.app/app#/Spaces-1/projects/Projects-1/operations/runbooks/Runbooks-1/process" {
+		t.Fatal("Should have linked to the runbook process, got " + link)
+	}
+}
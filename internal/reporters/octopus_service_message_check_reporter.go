@@ -0,0 +1,130 @@
+package reporters
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+var serviceMessageCategories = []string{checks.Organization, checks.Naming, checks.Security, checks.Performance, checks.Optimization, checks.GeneralError}
+var serviceMessageSeverities = []int{checks.Error, checks.Warning, checks.Info, checks.Permission}
+
+// OctopusServiceMessageCheckReporter prints the lint reports as Octopus service messages. This is used when octolint
+// is run from a step in Octopus. The number of failed checks is captured in output variables, the full report is
+// attached as an artifact, and the failed checks are highlighted in the task log.
+type OctopusServiceMessageCheckReporter struct {
+	minSeverity  int
+	artifactFile string
+	weights      scoring.Weights
+}
+
+func NewOctopusServiceMessageCheckReporter(minSeverity int, artifactFile string, weights scoring.Weights) OctopusServiceMessageCheckReporter {
+	return OctopusServiceMessageCheckReporter{minSeverity: minSeverity, artifactFile: artifactFile, weights: weights}
+}
+
+func (o OctopusServiceMessageCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	report := []string{}
+
+	categoryCounts := map[string]int{}
+	severityCounts := map[string]int{}
+	failed := 0
+	findings := 0
+
+	for _, r := range results {
+		if r == nil || r.Severity() < o.minSeverity || r.Severity() == checks.Ok {
+			continue
+		}
+
+		failed++
+		findings += checks.FindingCount(r)
+		categoryCounts[r.Category()]++
+		severityCounts[checks.SeverityName(r.Severity())]++
+
+		report = append(report, "##octopus[stdout-highlight]")
+		report = append(report, fmt.Sprintf("[%s] %s %s: %d findings", r.Code(), r.Category(), checks.SeverityName(r.Severity()), checks.FindingCount(r)))
+		report = append(report, "##octopus[stdout-default]")
+	}
+
+	if failed == 0 {
+		report = append(report, "No issues detected")
+	}
+
+	// Partial scans are highlighted, as a clean report is only trustworthy if every resource was scanned
+	partial := partialCoverageChecks(results)
+
+	if len(partial) != 0 {
+		report = append(report, "##octopus[stdout-warning]")
+		report = append(report, partialCoverageWarning(results))
+		report = append(report, "##octopus[stdout-default]")
+	}
+
+	score := scoring.Calculate(results, o.weights)
+	report = append(report, score.String())
+
+	report = append(report, setVariableMessage("Octolint.Total", fmt.Sprint(failed)))
+	report = append(report, setVariableMessage("Octolint.Findings", fmt.Sprint(findings)))
+	report = append(report, setVariableMessage("Octolint.Score", fmt.Sprint(score.Overall)))
+	report = append(report, setVariableMessage("Octolint.PartialCoverage", fmt.Sprint(len(partial))))
+
+	for _, category := range score.Categories {
+		report = append(report, setVariableMessage("Octolint.Score."+category.Category, fmt.Sprint(category.Score)))
+	}
+
+	for _, category := range serviceMessageCategories {
+		report = append(report, setVariableMessage("Octolint.Category."+category, fmt.Sprint(categoryCounts[category])))
+	}
+
+	for _, severity := range serviceMessageSeverities {
+		name := checks.SeverityName(severity)
+		report = append(report, setVariableMessage("Octolint.Severity."+name, fmt.Sprint(severityCounts[name])))
+	}
+
+	if o.artifactFile != "" {
+		artifact, err := o.writeArtifact(results)
+
+		if err != nil {
+			return "", err
+		}
+
+		report = append(report, artifact)
+	}
+
+	return strings.Join(report, "\n"), nil
+}
+
+// writeArtifact saves the full plain text report and returns the service message that attaches it to the task.
+func (o OctopusServiceMessageCheckReporter) writeArtifact(results []checks.OctopusCheckResult) (string, error) {
+	fullReport, err := NewOctopusPlainCheckReporter(o.minSeverity, o.weights).Generate(results)
+
+	if err != nil {
+		return "", err
+	}
+
+	path, err := filepath.Abs(o.artifactFile)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, []byte(fullReport), 0644); err != nil {
+		return "", err
+	}
+
+	return "##octopus[createArtifact path='" + encodeServiceMessageValue(path) +
+		"' name='" + encodeServiceMessageValue(filepath.Base(path)) +
+		"' length='" + encodeServiceMessageValue(fmt.Sprint(len(fullReport))) + "']", nil
+}
+
+func setVariableMessage(name string, value string) string {
+	return "##octopus[setVariable name='" + encodeServiceMessageValue(name) + "' value='" + encodeServiceMessageValue(value) + "']"
+}
+
+// encodeServiceMessageValue encodes service message values as base64, which allows them to contain any character.
+func encodeServiceMessageValue(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
@@ -0,0 +1,47 @@
+package reporters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
+)
+
+func TestServiceMessageReport(t *testing.T) {
+	artifact := filepath.Join(t.TempDir(), "report.txt")
+	failedResult := checks.NewOctopusCheckResultImplWithFindings("This check always fails", "OctoRecAlwaysFail", "", checks.Warning, checks.Security, []checks.OctopusCheckFinding{
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-1", ResourceName: "Project 1", Description: "Project 1"},
+		{ResourceType: checks.ResourceTypeProject, ResourceId: "Projects-2", ResourceName: "Project 2", Description: "Project 2"},
+	})
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	results, err := NewOctopusServiceMessageCheckReporter(checks.Warning, artifact, scoring.DefaultWeights()).Generate([]checks.OctopusCheckResult{failedResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, setVariableMessage("Octolint.Category.Security", "1")) ||
+		!strings.Contains(results, setVariableMessage("Octolint.Category.Organization", "0")) ||
+		!strings.Contains(results, setVariableMessage("Octolint.Severity.Warning", "1")) ||
+		!strings.Contains(results, setVariableMessage("Octolint.Findings", "2")) {
+		t.Fatal("Should have set the output variables")
+	}
+
+	if !strings.Contains(results, "##octopus[stdout-highlight]\n[OctoRecAlwaysFail] Security Warning: 2 findings") {
+		t.Fatal("Should have highlighted the failed check")
+	}
+
+	if !strings.Contains(results, "##octopus[createArtifact path='"+encodeServiceMessageValue(artifact)+"'") {
+		t.Fatal("Should have created the artifact")
+	}
+
+	content, err := os.ReadFile(artifact)
+
+	if err != nil || !strings.Contains(string(content), "This check always fails") {
+		t.Fatal("Should have saved the full report")
+	}
+}
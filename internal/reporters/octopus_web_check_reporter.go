@@ -2,16 +2,18 @@ package reporters
 
 import (
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/scoring"
 	"strings"
 )
 
 // OctopusWebCheckReporter prints the lint reports in plain text to std out.
 type OctopusWebCheckReporter struct {
 	minSeverity int
+	weights     scoring.Weights
 }
 
-func NewOctopusWebCheckReporter(minSeverity int) OctopusWebCheckReporter {
-	return OctopusWebCheckReporter{minSeverity: minSeverity}
+func NewOctopusWebCheckReporter(minSeverity int, weights scoring.Weights) OctopusWebCheckReporter {
+	return OctopusWebCheckReporter{minSeverity: minSeverity, weights: weights}
 }
 
 func (o OctopusWebCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
@@ -27,9 +29,17 @@ func (o OctopusWebCheckReporter) Generate(results []checks.OctopusCheckResult) (
 		}
 	}
 
+	score := scoring.Calculate(results, o.weights).String()
+
+	if warning := partialCoverageWarning(results); warning != "" {
+		score = warning + "\n\n" + score
+	}
+
 	if len(report) == 0 {
-		return "No issues detected", nil
+		return "No issues detected\n\n" + score, nil
 	}
 
+	report = append(report, score)
+
 	return strings.Join(report[:], "\n\n"), nil
 }
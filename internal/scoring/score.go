@@ -0,0 +1,213 @@
+// Package scoring summarises the results of a scan as a health score between 0 and 100, for the space as a whole
+// and for each category of check.
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+)
+
+// Categories are the categories that are always reported, in the order they are displayed. Checks in other
+// categories are scored with a weight of 1 and displayed after these categories.
+var Categories = []string{checks.Security, checks.Organization, checks.Naming, checks.Performance}
+
+// Weights defines how much each category contributes to the overall score, and how much of the points of a check
+// are lost for each severity.
+type Weights struct {
+	// Categories maps a category to the number of points each check in the category is worth
+	Categories map[string]float64
+	// Severities maps a severity to the fraction of the points of a check that are lost when every scanned resource
+	// fails the check
+	Severities map[int]float64
+}
+
+// DefaultWeights returns the weights used when none are configured.
+func DefaultWeights() Weights {
+	return Weights{
+		Categories: map[string]float64{
+			checks.Security:     defaults.ScoreWeightSecurity,
+			checks.Organization: defaults.ScoreWeightOrganization,
+			checks.Naming:       defaults.ScoreWeightNaming,
+			checks.Performance:  defaults.ScoreWeightPerformance,
+		},
+		Severities: map[int]float64{
+			checks.Error:   defaults.ScoreWeightError,
+			checks.Warning: defaults.ScoreWeightWarning,
+			checks.Info:    defaults.ScoreWeightInfo,
+		},
+	}
+}
+
+// NewWeights returns the weights defined by the scoreWeight arguments.
+func NewWeights(octolintConfig *config.OctolintConfig) Weights {
+	return Weights{
+		Categories: map[string]float64{
+			checks.Security:     octolintConfig.ScoreWeightSecurity,
+			checks.Organization: octolintConfig.ScoreWeightOrganization,
+			checks.Naming:       octolintConfig.ScoreWeightNaming,
+			checks.Performance:  octolintConfig.ScoreWeightPerformance,
+		},
+		Severities: map[int]float64{
+			checks.Error:   octolintConfig.ScoreWeightError,
+			checks.Warning: octolintConfig.ScoreWeightWarning,
+			checks.Info:    octolintConfig.ScoreWeightInfo,
+		},
+	}
+}
+
+func (o Weights) category(category string) float64 {
+	// The zero value of Weights doesn't score any checks
+	if o.Categories == nil {
+		return 0
+	}
+
+	if weight, ok := o.Categories[category]; ok {
+		return math.Max(weight, 0)
+	}
+
+	// Categories without a configured weight, like Optimization, are treated as equal to the defaults
+	return 1
+}
+
+func (o Weights) severity(severity int) float64 {
+	for _, level := range []int{checks.Error, checks.Warning, checks.Info} {
+		if severity >= level {
+			return math.Min(math.Max(o.Severities[level], 0), 1)
+		}
+	}
+
+	return 0
+}
+
+// CategoryScore is the score of the checks in a single category.
+type CategoryScore struct {
+	Category string
+	Score    int
+}
+
+// Score is the health score of a space. A space where every check passed scores 100.
+type Score struct {
+	Overall    int
+	Categories []CategoryScore
+}
+
+// String formats the score as a single line, like "Health score: 87/100 (Security 90, Organization 80)".
+func (o Score) String() string {
+	categories := []string{}
+	for _, category := range o.Categories {
+		categories = append(categories, fmt.Sprintf("%s %d", category.Category, category.Score))
+	}
+
+	score := fmt.Sprintf("Health score: %d/100", o.Overall)
+	if len(categories) != 0 {
+		score += " (" + strings.Join(categories, ", ") + ")"
+	}
+
+	return score
+}
+
+// CategoryMap returns the category scores indexed by category.
+func (o Score) CategoryMap() map[string]int {
+	categories := map[string]int{}
+	for _, category := range o.Categories {
+		categories[category.Category] = category.Score
+	}
+	return categories
+}
+
+// Calculate scores the results of a scan. Each check is worth the points assigned to its category, and loses a
+// fraction of those points based on its severity and the proportion of the scanned resources that failed the check.
+// The proportion is square rooted so a handful of findings in a large space still has a noticeable effect. Checks
+// that don't report how many resources they scanned lose the full fraction for their severity. Checks that could
+// not be run, because of permissions or errors, are not scored.
+//
+// The score of a category is the percentage of the points available to its checks that were kept, and the overall
+// score is the same percentage across all categories.
+func Calculate(results []checks.OctopusCheckResult, weights Weights) Score {
+	available := map[string]float64{}
+	kept := map[string]float64{}
+	others := []string{}
+
+	for _, r := range results {
+		// Checks that returned an error, such as a plugin that timed out, are reported in the GeneralError category
+		if r == nil || r.Category() == checks.GeneralError || (r.Severity() != checks.Ok && r.Severity() < checks.Info) {
+			continue
+		}
+
+		weight := weights.category(r.Category())
+
+		if weight == 0 {
+			continue
+		}
+
+		if _, ok := available[r.Category()]; !ok && !isCategory(r.Category()) {
+			others = append(others, r.Category())
+		}
+
+		available[r.Category()] += weight
+		kept[r.Category()] += weight * (1 - weights.severity(r.Severity())*impact(r))
+	}
+
+	score := Score{Overall: 100, Categories: []CategoryScore{}}
+	totalAvailable := 0.0
+	totalKept := 0.0
+
+	for _, category := range append(append([]string{}, Categories...), others...) {
+		if available[category] == 0 {
+			continue
+		}
+
+		totalAvailable += available[category]
+		totalKept += kept[category]
+		score.Categories = append(score.Categories, CategoryScore{
+			Category: category,
+			Score:    percentage(kept[category], available[category]),
+		})
+	}
+
+	if totalAvailable != 0 {
+		score.Overall = percentage(totalKept, totalAvailable)
+	}
+
+	return score
+}
+
+// impact returns the proportion of the scanned resources that failed a check, between 0 and 1.
+func impact(result checks.OctopusCheckResult) float64 {
+	if result.Severity() == checks.Ok {
+		return 0
+	}
+
+	scanned, ok := result.(checks.OctopusScannedCheckResult)
+
+	if !ok || scanned.ScannedResources() <= 0 {
+		return 1
+	}
+
+	// Results that failed without identifying individual resources are counted as a single finding
+	findings := len(result.Findings())
+	if findings == 0 {
+		findings = 1
+	}
+
+	return math.Sqrt(math.Min(float64(findings)/float64(scanned.ScannedResources()), 1))
+}
+
+// percentage is rounded down so that only a space without any issues scores 100.
+func percentage(value float64, total float64) int {
+	return int(math.Max(math.Floor(value/total*100), 0))
+}
+
+func isCategory(category string) bool {
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
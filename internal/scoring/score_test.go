@@ -0,0 +1,90 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+func TestNoResults(t *testing.T) {
+	score := Calculate(nil, DefaultWeights())
+
+	if score.Overall != 100 || len(score.Categories) != 0 {
+		t.Fatal("Should have scored an empty scan as 100")
+	}
+}
+
+func TestPassingChecks(t *testing.T) {
+	score := Calculate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("Passed", "OctoLintPass", "", checks.Ok, checks.Security),
+		checks.NewOctopusCheckResultImpl("Passed", "OctoLintPass", "", checks.Ok, checks.Naming),
+	}, DefaultWeights())
+
+	if score.String() != "Health score: 100/100 (Security 100, Naming 100)" {
+		t.Fatal("Should have scored passing checks as 100, got " + score.String())
+	}
+}
+
+func TestFailingChecks(t *testing.T) {
+	findings := []checks.OctopusCheckFinding{{ResourceName: "Project 1"}}
+
+	score := Calculate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("Failed", "OctoLintError", "", checks.Error, checks.Security),
+		checks.NewOctopusCheckResultImpl("Passed", "OctoLintPass", "", checks.Ok, checks.Security),
+		// 1 of 25 scanned projects failed, so the check loses the square root of 4% of the warning weight
+		checks.NewOctopusCheckResultImplWithFindings("Failed", "OctoLintWarning", "", checks.Warning, checks.Organization, findings).WithCoverage(checks.NewFullResourceCoverage(checks.ResourceTypeProject, 25)),
+		checks.NewOctopusCheckResultImpl("No permission", "OctoLintPermission", "", checks.Permission, checks.Naming),
+	}, DefaultWeights())
+
+	categories := score.CategoryMap()
+
+	if categories[checks.Security] != 50 {
+		t.Fatal("Should have scored the security checks as 50")
+	}
+
+	if categories[checks.Organization] != 88 {
+		t.Fatal("Should have scored the organization check as 88")
+	}
+
+	if _, ok := categories[checks.Naming]; ok {
+		t.Fatal("Should not have scored checks that could not be run")
+	}
+
+	// (0 + 1 + 0.88) / 3
+	if score.Overall != 62 {
+		t.Fatal("Should have scored the space as 62")
+	}
+}
+
+func TestChecksThatErrored(t *testing.T) {
+	score := Calculate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("Passed", "OctoLintPass", "", checks.Ok, checks.Security),
+		checks.NewOctopusCheckResultImpl("The check timed out", "OctoLintPlugin", "", checks.Error, checks.GeneralError),
+	}, DefaultWeights())
+
+	if score.String() != "Health score: 100/100 (Security 100)" {
+		t.Fatal("Should not have scored checks that returned an error, got " + score.String())
+	}
+}
+
+func TestCategoryWeights(t *testing.T) {
+	weights := DefaultWeights()
+	weights.Categories[checks.Security] = 3
+
+	score := Calculate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("Failed", "OctoLintError", "", checks.Error, checks.Security),
+		checks.NewOctopusCheckResultImpl("Passed", "OctoLintPass", "", checks.Ok, checks.Organization),
+	}, weights)
+
+	if score.Overall != 25 {
+		t.Fatal("Should have weighted the security check 3 times as much as the organization check")
+	}
+
+	weights.Categories[checks.Security] = 0
+
+	if Calculate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckResultImpl("Failed", "OctoLintError", "", checks.Error, checks.Security),
+	}, weights).Overall != 100 {
+		t.Fatal("Should have ignored checks in categories with a weight of 0")
+	}
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewOtlpExporter returns an exporter that sends spans to an OpenTelemetry collector with the OTLP/HTTP protocol. The
+// endpoint argument takes precedence over the standard OTEL_EXPORTER_OTLP_TRACES_ENDPOINT and
+// OTEL_EXPORTER_OTLP_ENDPOINT environment variables, and headers are read from OTEL_EXPORTER_OTLP_HEADERS.
+func NewOtlpExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	options := []otlptracehttp.Option{}
+	if endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpointURL(endpoint))
+	}
+
+	return otlptracehttp.New(ctx, options...)
+}
+
+// FileExporter writes each span to a file as a line of JSON.
+type FileExporter struct {
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+func NewFileExporter(writer io.Writer) *FileExporter {
+	return &FileExporter{writer: writer}
+}
+
+// FileSpan is the serialized form of a span written by the FileExporter.
+type FileSpan struct {
+	Name          string         `json:"name"`
+	TraceId       string         `json:"traceId"`
+	SpanId        string         `json:"spanId"`
+	ParentSpanId  string         `json:"parentSpanId,omitempty"`
+	Kind          string         `json:"kind"`
+	StartTime     time.Time      `json:"startTime"`
+	EndTime       time.Time      `json:"endTime"`
+	DurationMs    float64        `json:"durationMs"`
+	Attributes    map[string]any `json:"attributes,omitempty"`
+	Status        string         `json:"status"`
+	StatusMessage string         `json:"statusMessage,omitempty"`
+}
+
+func (o *FileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	encoder := json.NewEncoder(o.writer)
+	for _, span := range spans {
+		fileSpan := FileSpan{
+			Name:          span.Name(),
+			TraceId:       span.SpanContext().TraceID().String(),
+			SpanId:        span.SpanContext().SpanID().String(),
+			Kind:          span.SpanKind().String(),
+			StartTime:     span.StartTime().UTC(),
+			EndTime:       span.EndTime().UTC(),
+			DurationMs:    float64(span.EndTime().Sub(span.StartTime()).Microseconds()) / 1000,
+			Attributes:    map[string]any{},
+			Status:        span.Status().Code.String(),
+			StatusMessage: span.Status().Description,
+		}
+
+		if span.Parent().IsValid() {
+			fileSpan.ParentSpanId = span.Parent().SpanID().String()
+		}
+
+		for _, attr := range span.Attributes() {
+			fileSpan.Attributes[string(attr.Key)] = attr.Value.AsInterface()
+		}
+
+		if err := encoder.Encode(fileSpan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *FileExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// timing is the total and longest duration of the spans with the same name.
+type timing struct {
+	name    string
+	count   int
+	total   time.Duration
+	longest time.Duration
+}
+
+// SummaryProcessor records the duration of the check and API request spans, which are printed as a table of the
+// slowest checks and endpoints at the end of a scan.
+type SummaryProcessor struct {
+	lock      sync.Mutex
+	checks    map[string]*timing
+	endpoints map[string]*timing
+}
+
+func NewSummaryProcessor() *SummaryProcessor {
+	return &SummaryProcessor{checks: map[string]*timing{}, endpoints: map[string]*timing{}}
+}
+
+func (o *SummaryProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+}
+
+func (o *SummaryProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	var timings map[string]*timing
+	name := s.Name()
+
+	for _, attr := range s.Attributes() {
+		switch string(attr.Key) {
+		case AttributeCheckId:
+			timings = o.checks
+			name = attr.Value.AsString()
+		case AttributeUrlTemplate:
+			timings = o.endpoints
+		}
+	}
+
+	if timings == nil {
+		return
+	}
+
+	duration := s.EndTime().Sub(s.StartTime())
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if _, ok := timings[name]; !ok {
+		timings[name] = &timing{name: name}
+	}
+
+	timings[name].count++
+	timings[name].total += duration
+	timings[name].longest = max(timings[name].longest, duration)
+}
+
+func (o *SummaryProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (o *SummaryProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// Table returns the checks that took the longest to run, and the API endpoints that the most time was spent
+// waiting for, limited to the slowest limit of each.
+func (o *SummaryProcessor) Table(limit int) string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if len(o.checks) == 0 && len(o.endpoints) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString("Slowest checks:\n")
+	builder.WriteString(fmt.Sprintf("%-50s %10s\n", "Check", "Duration"))
+	for _, t := range slowest(o.checks, limit) {
+		builder.WriteString(fmt.Sprintf("%-50s %10s\n", t.name, t.total.Round(time.Millisecond)))
+	}
+
+	builder.WriteString("\nSlowest endpoints:\n")
+	builder.WriteString(fmt.Sprintf("%-60s %8s %10s %10s %10s\n", "Endpoint", "Requests", "Total", "Average", "Longest"))
+	for _, t := range slowest(o.endpoints, limit) {
+		builder.WriteString(fmt.Sprintf("%-60s %8d %10s %10s %10s\n",
+			t.name,
+			t.count,
+			t.total.Round(time.Millisecond),
+			(t.total / time.Duration(t.count)).Round(time.Millisecond),
+			t.longest.Round(time.Millisecond)))
+	}
+
+	return builder.String()
+}
+
+// slowest sorts the timings by total duration, longest first.
+func slowest(timings map[string]*timing, limit int) []*timing {
+	sorted := []*timing{}
+	for _, t := range timings {
+		sorted = append(sorted, t)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].total == sorted[j].total {
+			return sorted[i].name < sorted[j].name
+		}
+		return sorted[i].total > sorted[j].total
+	})
+
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	return sorted
+}
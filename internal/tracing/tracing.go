@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterOtlp = "otlp"
+	ExporterFile = "file"
+)
+
+// Attributes recorded on the spans created by octolint. The HTTP attributes follow the OpenTelemetry semantic
+// conventions, so they are understood by most tracing backends.
+const (
+	AttributeSpace          = "octolint.space"
+	AttributeCheckId        = "octolint.check.id"
+	AttributeCheckAttempts  = "octolint.check.attempts"
+	AttributeCheckSeverity  = "octolint.check.severity"
+	AttributeCheckFindings  = "octolint.check.findings"
+	AttributeMethod         = "http.request.method"
+	AttributeUrlTemplate    = "url.template"
+	AttributeStatusCode     = "http.response.status_code"
+	AttributeResendCount    = "http.request.resend_count"
+	AttributeServiceVersion = "service.version"
+)
+
+const tracerName = "github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine"
+
+// Tracing holds the tracer provider used for a scan. The zero value, returned when tracing is disabled, records
+// nothing.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+	summary  *SummaryProcessor
+	file     *os.File
+}
+
+// Tracer returns the tracer used to create the spans of a scan. Spans are discarded until Setup installs a tracer
+// provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Setup installs a tracer provider if the traceExporter argument is set, or if a summary of the slowest checks and
+// API requests is printed by the verbose argument. Spans are sent to an OTLP/HTTP endpoint, or written to a file as
+// lines of JSON.
+func Setup(octolintConfig *config.OctolintConfig, version string) (*Tracing, error) {
+	if octolintConfig.TraceExporter == "" && !octolintConfig.Verbose {
+		return &Tracing{}, nil
+	}
+
+	tracing := &Tracing{summary: NewSummaryProcessor()}
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(tracing.summary),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "octolint"),
+			attribute.String(AttributeServiceVersion, version))),
+	}
+
+	switch octolintConfig.TraceExporter {
+	case "":
+	case ExporterOtlp:
+		exporter, err := NewOtlpExporter(context.Background(), octolintConfig.TraceEndpoint)
+
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterFile:
+		file, err := os.Create(octolintConfig.TraceFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tracing.file = file
+		options = append(options, sdktrace.WithBatcher(NewFileExporter(file)))
+	default:
+		return nil, errors.New("The -traceExporter argument must be " + ExporterOtlp + " or " + ExporterFile)
+	}
+
+	tracing.provider = sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(tracing.provider)
+
+	return tracing, nil
+}
+
+// Enabled returns true if spans are being recorded.
+func (o *Tracing) Enabled() bool {
+	return o != nil && o.provider != nil
+}
+
+// Summary returns a table of the slowest checks and API endpoints, or an empty string if tracing is disabled.
+func (o *Tracing) Summary(limit int) string {
+	if o == nil || o.summary == nil {
+		return ""
+	}
+
+	return o.summary.Table(limit)
+}
+
+// Shutdown exports any spans that have not been sent yet and closes the trace file.
+func (o *Tracing) Shutdown(ctx context.Context) error {
+	if !o.Enabled() {
+		return nil
+	}
+
+	err := o.provider.Shutdown(ctx)
+
+	if o.file != nil {
+		err = errors.Join(err, o.file.Close())
+	}
+
+	return err
+}
@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordSpans installs a tracer provider that records the spans created by a test.
+func recordSpans(t *testing.T) (*tracetest.SpanRecorder, *SummaryProcessor) {
+	recorder := tracetest.NewSpanRecorder()
+	summary := NewSummaryProcessor()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithSpanProcessor(summary))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder, summary
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, attr := range span.Attributes() {
+		if string(attr.Key) == key {
+			return attr.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestPathTemplate(t *testing.T) {
+	paths := map[string]string{
+		"/api/Spaces-1/projects/Projects-22/variables":                   "/api/{id}/projects/{id}/variables",
+		"/api/Spaces-1/deploymentprocesses/deploymentprocess-Projects-3": "/api/{id}/deploymentprocesses/{id}",
+		"/api/Spaces-1/projects":                                         "/api/{id}/projects",
+		"/api/users/me":                                                  "/api/users/me",
+	}
+
+	for path, expected := range paths {
+		if actual := PathTemplate(path); actual != expected {
+			t.Fatal("Should have templated " + path + " as " + expected + ", got " + actual)
+		}
+	}
+}
+
+func TestTransportSpans(t *testing.T) {
+	recorder, summary := recordSpans(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "Projects-2") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx, scanSpan := Tracer().Start(context.Background(), "scan")
+	client := &http.Client{Transport: NewTransport(nil, ctx)}
+
+	for _, path := range []string{"/api/Spaces-1/projects/Projects-1", "/api/Spaces-1/projects/Projects-1", "/api/Spaces-1/projects/Projects-2"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal("Should not have returned an error: " + err.Error())
+		}
+		resp.Body.Close()
+	}
+
+	scanSpan.End()
+
+	spans := recorder.Ended()
+
+	if len(spans) != 4 {
+		t.Fatal("Should have created a span for each request and the scan")
+	}
+
+	for _, span := range spans[:3] {
+		if span.Name() != "GET /api/{id}/projects/{id}" {
+			t.Fatal("Should have named the span after the path template, got " + span.Name())
+		}
+
+		if span.Parent().SpanID() != scanSpan.SpanContext().SpanID() {
+			t.Fatal("Should have created the request span under the scan span")
+		}
+	}
+
+	if attributeValue(spans[0], AttributeResendCount).Type() != attribute.INVALID || attributeValue(spans[1], AttributeResendCount).AsInt64() != 1 ||
+		attributeValue(spans[2], AttributeResendCount).Type() != attribute.INVALID {
+		t.Fatal("Should have recorded that the second request for Projects-1 was resent")
+	}
+
+	if attributeValue(spans[2], AttributeStatusCode).AsInt64() != http.StatusNotFound || spans[2].Status().Code.String() != "Error" {
+		t.Fatal("Should have recorded the failed request")
+	}
+
+	if !strings.Contains(summary.Table(10), "GET /api/{id}/projects/{id}") {
+		t.Fatal("Should have summarised the endpoint")
+	}
+}
+
+func TestSummaryTable(t *testing.T) {
+	_, summary := recordSpans(t)
+
+	start := time.Now()
+	for id, duration := range map[string]time.Duration{"OctoLintFast": time.Second, "OctoLintSlow": time.Minute} {
+		_, span := Tracer().Start(context.Background(), id, trace.WithTimestamp(start), trace.WithAttributes(attribute.String(AttributeCheckId, id)))
+		span.End(trace.WithTimestamp(start.Add(duration)))
+	}
+
+	table := summary.Table(1)
+
+	if !strings.HasPrefix(table, "Slowest checks:\n") || !strings.Contains(table, "OctoLintSlow") || strings.Contains(table, "OctoLintFast") {
+		t.Fatal("Should have limited the table to the slowest check, got " + table)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	recorder, _ := recordSpans(t)
+
+	_, span := Tracer().Start(context.Background(), "OctoLintEmptyProject")
+	span.SetAttributes(attribute.String(AttributeCheckId, "OctoLintEmptyProject"), attribute.Int(AttributeCheckFindings, 2))
+	span.End()
+
+	buffer := bytes.Buffer{}
+	if err := NewFileExporter(&buffer).ExportSpans(context.Background(), recorder.Ended()); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	fileSpan := FileSpan{}
+	if err := json.Unmarshal(buffer.Bytes(), &fileSpan); err != nil {
+		t.Fatal("Should have written the span as a line of JSON: " + err.Error())
+	}
+
+	if fileSpan.Name != "OctoLintEmptyProject" || fileSpan.TraceId == "" || fileSpan.Attributes[AttributeCheckFindings] != float64(2) {
+		t.Fatal("Should have written the span name, IDs and attributes")
+	}
+}
+
+func TestOtlpExporter(t *testing.T) {
+	recorder, _ := recordSpans(t)
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://unused:4318/v1/traces")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer token")
+
+	_, span := Tracer().Start(context.Background(), "OctoLintEmptyProject")
+	span.SetAttributes(attribute.Int(AttributeCheckFindings, 2))
+	span.End()
+
+	exporter, err := NewOtlpExporter(context.Background(), server.URL+"/v1/traces")
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if err := exporter.ExportSpans(context.Background(), recorder.Ended()); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	request := <-requests
+	if request.URL.Path != "/v1/traces" || request.Header.Get("Authorization") != "Bearer token" {
+		t.Fatal("Should have sent the spans to the traceEndpoint argument with the OTLP headers")
+	}
+
+	exported := &collectortrace.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(<-bodies, exported); err != nil {
+		t.Fatal("Should have sent an OTLP request: " + err.Error())
+	}
+
+	spans := exported.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 || spans[0].Name != "OctoLintEmptyProject" || spans[0].Attributes[0].Value.GetIntValue() != 2 {
+		t.Fatal("Should have sent the span and its attributes")
+	}
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// resourceId matches the IDs in the path of an API request, like "Spaces-1" or "Projects-123", and the IDs of the
+// documents owned by another resource, like "deploymentprocess-Projects-123".
+var resourceId = regexp.MustCompile(`^([a-z]+-)?[A-Za-z]+-\d+$`)
+
+// Transport creates a span for each request sent to the Octopus API. The Octopus client does not pass a context
+// with its requests, so the spans are created under the span held by Parent. A client is created for each check
+// while tracing, so the requests sent by a check are children of the span of the check.
+//
+// The Octopus client does not retry requests, so a request is only retried when a check sends it again. Requests
+// with the same method and URL as an earlier request are recorded with the number of times they were resent.
+type Transport struct {
+	Transport http.RoundTripper
+	// Parent is the context holding the span that each request span is created under
+	Parent context.Context
+
+	mutex sync.Mutex
+	sent  map[string]int
+}
+
+func NewTransport(transport http.RoundTripper, parent context.Context) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Transport{Transport: transport, Parent: parent}
+}
+
+// resendCount records that the request was sent, and returns the number of times it was sent before.
+func (o *Transport) resendCount(req *http.Request) int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.sent == nil {
+		o.sent = map[string]int{}
+	}
+
+	key := req.Method + " " + req.URL.String()
+	count := o.sent[key]
+	o.sent[key] = count + 1
+
+	return count
+}
+
+func (o *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	template := PathTemplate(req.URL.Path)
+
+	_, span := Tracer().Start(
+		o.Parent,
+		req.Method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(AttributeMethod, req.Method),
+			attribute.String(AttributeUrlTemplate, template)))
+	defer span.End()
+
+	if resendCount := o.resendCount(req); resendCount != 0 {
+		span.SetAttributes(attribute.Int(AttributeResendCount, resendCount))
+	}
+
+	resp, err := o.Transport.RoundTrip(req)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int(AttributeStatusCode, resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// PathTemplate replaces the resource IDs in a path with placeholders, so requests for different resources of the
+// same type are grouped together. For example, "/api/Spaces-1/projects/Projects-2/variables" becomes
+// "/api/{id}/projects/{id}/variables".
+func PathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if resourceId.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,135 @@
+package octolint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/tracing"
+)
+
+// NewHttpClient returns the HTTP client used to call the Octopus API when a Scanner is not supplied with one. Requests
+// are logged, sent through the redirector if the config enables it, and recorded as spans under the span held by ctx.
+func NewHttpClient(ctx context.Context, octolintConfig *Config) (*http.Client, error) {
+	transport, err := newTransport(octolintConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{Transport: tracing.NewTransport(transport, ctx)}, nil
+}
+
+// newTransport returns the transport that logs the API requests, and sends them through the redirector if the config
+// enables it.
+func newTransport(octolintConfig *Config) (http.RoundTripper, error) {
+	if octolintConfig.UseRedirector {
+		parsedUrl, err := url.Parse(octolintConfig.Url)
+
+		if err != nil {
+			return nil, err
+		}
+
+		headers := map[string]string{
+			"X_REDIRECTION_UPSTREAM_HOST":   parsedUrl.Hostname(),
+			"X_REDIRECTION_REDIRECTIONS":    octolintConfig.RedirectorRedirections,
+			"X_REDIRECTION_API_KEY":         octolintConfig.RedirecrtorApiKey,
+			"X_REDIRECTION_SERVICE_API_KEY": octolintConfig.RedirectorServiceApiKey,
+		}
+
+		return &client_wrapper.HeaderRoundTripper{
+			Transport: logging.NewTransport(http.DefaultTransport),
+			Headers:   headers,
+		}, nil
+	}
+
+	return logging.NewTransport(http.DefaultTransport), nil
+}
+
+// NewClient returns an Octopus client for the space in the config, authenticated with the API key or access token.
+// The space must be a space ID.
+func NewClient(httpClient *http.Client, octolintConfig *Config) (*client.Client, error) {
+
+	parsedUrl, err := getHost(octolintConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if octolintConfig.ApiKey != "" {
+		return createClientApiKey(httpClient, parsedUrl, octolintConfig.Space, octolintConfig.ApiKey)
+	}
+
+	return createClientAccessToken(httpClient, parsedUrl, octolintConfig.Space, octolintConfig.AccessToken)
+}
+
+// getHost returns the host that API requests are sent to. The redirector forwards requests to the Octopus host in the
+// X_REDIRECTION_UPSTREAM_HOST header.
+func getHost(octolintConfig *Config) (*url.URL, error) {
+	if octolintConfig.UseRedirector {
+		return url.Parse("https://" + octolintConfig.RedirectorHost)
+	}
+
+	return url.Parse(octolintConfig.Url)
+}
+
+func createClientApiKey(httpClient *http.Client, apiURL *url.URL, spaceId string, apiKey string) (*client.Client, error) {
+	apiKeyCredential, err := client.NewApiKey(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewClientWithCredentials(httpClient, apiURL, apiKeyCredential, spaceId, "")
+}
+
+func createClientAccessToken(httpClient *http.Client, apiURL *url.URL, spaceId string, accessToken string) (*client.Client, error) {
+	accessTokenCredential, err := client.NewAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewClientWithCredentials(httpClient, apiURL, accessTokenCredential, spaceId, "")
+}
+
+func lookupSpaceAsName(httpClient *http.Client, octopusUrl string, spaceName string, apiKey string, accessToken string) (string, error) {
+	if len(strings.TrimSpace(spaceName)) == 0 {
+		return "", errors.New("space can not be empty")
+	}
+
+	headers := map[string]string{}
+	if apiKey != "" {
+		headers["X-Octopus-ApiKey"] = apiKey
+	} else if accessToken != "" {
+		headers["Authorization"] = "Bearer " + accessToken
+	}
+
+	session := &newclient.HttpSession{HttpClient: httpClient, DefaultHeaders: headers}
+	requestURL := fmt.Sprintf("%s/api/Spaces?partialName=%s", strings.TrimSuffix(octopusUrl, "/"), url.QueryEscape(spaceName))
+
+	// The partial name can match many spaces, so pages are read until the space with the exact name is found
+	for space, err := range client_wrapper.NewPager[spaces.Space](session, requestURL, 0, 0).Items() {
+		if err != nil {
+			// A space that can't be looked up by name is treated as a space ID
+			var apiError *core.APIError
+			if errors.As(err, &apiError) {
+				return "", nil
+			}
+
+			return "", err
+		}
+
+		if space.Name == spaceName {
+			return space.ID, nil
+		}
+	}
+
+	return "", errors.New("did not find space with name " + spaceName)
+}
@@ -0,0 +1,469 @@
+// Package octolint scans an Octopus space, or the Config-as-Code files of its projects, with the octolint checks.
+// It is the library used by the octolint CLI and Azure function, and can be embedded in other tools.
+//
+// A Scanner does not exit the process, replace the global zap logger, or write to stdout and stderr. Log lines are
+// written to the global zap logger, and spans are recorded by the global OpenTelemetry tracer provider, so both are
+// discarded unless the embedding tool installs them.
+package octolint
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/factory"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/excluder"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/executor"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/incremental"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/ocl"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/preflight"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/tracing"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Report is the result of a scan.
+type Report struct {
+	// Results holds the result of each check, including the checks that were skipped or failed to run
+	Results []CheckResult
+	// Space is the ID of the scanned space, which is empty when Config-as-Code files were scanned
+	Space string
+	// SkippedChecks lists the checks that were not run because the API key is missing permissions they require
+	SkippedChecks []SkippedCheck
+	// StartTime is the time the checks started running
+	StartTime time.Time
+	// Duration is the time taken to run the checks
+	Duration time.Duration
+}
+
+// Scanner runs the built-in checks, and any checks supplied by the caller, against an Octopus space. The config
+// selects the checks and their settings in the same way as the CLI arguments.
+type Scanner struct {
+	config       *Config
+	client       *client.Client
+	httpClient   *http.Client
+	checks       []Check
+	builtIns     bool
+	errorHandler func(Check, error) error
+	version      string
+	// checkRequestSpans creates the spans of the API requests sent by a built-in check under the span of the check
+	checkRequestSpans bool
+}
+
+// NewScanner returns a scanner that runs the built-in checks with the supplied config.
+func NewScanner(octolintConfig *Config) Scanner {
+	return Scanner{config: octolintConfig, builtIns: true, version: "development", checkRequestSpans: true}
+}
+
+// WithClient returns a scanner that uses the supplied Octopus client. The client must be scoped to the space in the
+// config, which must be a space ID, and the API key or access token in the config is not required.
+func (o Scanner) WithClient(octopusClient *client.Client) Scanner {
+	o.client = octopusClient
+	return o
+}
+
+// WithHttpClient returns a scanner that sends the API requests with the supplied HTTP client. This is ignored when
+// an Octopus client is supplied with WithClient.
+func (o Scanner) WithHttpClient(httpClient *http.Client) Scanner {
+	o.httpClient = httpClient
+	return o
+}
+
+// WithChecks returns a scanner that runs the supplied checks in addition to the built-in checks. Checks that
+// implement OfflineCheck are also run against Config-as-Code files.
+func (o Scanner) WithChecks(extraChecks ...Check) Scanner {
+	o.checks = append(append([]Check{}, o.checks...), extraChecks...)
+	return o
+}
+
+// WithoutBuiltInChecks returns a scanner that only runs the checks supplied with WithChecks.
+func (o Scanner) WithoutBuiltInChecks() Scanner {
+	o.builtIns = false
+	return o
+}
+
+// WithErrorHandler returns a scanner that calls handleError when a check fails after its retries. The scan is
+// stopped if handleError returns an error. By default, the error is logged and the scan continues.
+func (o Scanner) WithErrorHandler(handleError func(Check, error) error) Scanner {
+	o.errorHandler = handleError
+	return o
+}
+
+// WithVersion returns a scanner that records the supplied version in the incremental cache, so the cache is not
+// reused by a scan with a different version of the checks.
+func (o Scanner) WithVersion(version string) Scanner {
+	o.version = version
+	return o
+}
+
+// WithCheckRequestSpans returns a scanner that creates the spans of the API requests sent by each built-in check under
+// the span of the check when enabled is true, which is the default. The Octopus client does not pass a context with
+// its requests, so each built-in check is run with its own client while spans are being recorded, or while debug log
+// lines are written. When enabled is false, the spans of the API requests are created under the span of the scan.
+// This is ignored when an Octopus or HTTP client is supplied.
+func (o Scanner) WithCheckRequestSpans(enabled bool) Scanner {
+	o.checkRequestSpans = enabled
+	return o
+}
+
+// Scan runs the checks and returns the results. Config-as-Code files are scanned instead of a space when the
+// OclDirectory setting is set. The span of the scan is created under the span held by ctx.
+func (o Scanner) Scan(ctx context.Context) (*Report, error) {
+	if o.config == nil {
+		return nil, errors.New("The scanner requires a config")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The config is copied so the space name resolved to an ID is not written to the caller's config
+	octolintConfig := *o.config
+
+	if octolintConfig.OclDirectory != "" {
+		return o.scanOcl(&octolintConfig)
+	}
+
+	if err := o.validate(&octolintConfig); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(octolintConfig.Space, "Spaces-") && o.client == nil {
+		lookupClient := o.httpClient
+		if lookupClient == nil {
+			var err error
+			lookupClient, err = NewHttpClient(ctx, &octolintConfig)
+
+			if err != nil {
+				return nil, errors.New("Failed to create the HTTP client. Check that the url is correct.\nThe error was: " + err.Error())
+			}
+		}
+
+		// The space is looked up through the same host as the other API requests, which is the redirector if it is enabled
+		host, err := getHost(&octolintConfig)
+
+		if err == nil {
+			var spaceId string
+			spaceId, err = lookupSpaceAsName(lookupClient, host.String(), octolintConfig.Space, octolintConfig.ApiKey, octolintConfig.AccessToken)
+			octolintConfig.Space = spaceId
+		}
+
+		if err != nil {
+			return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+		}
+	}
+
+	ctx, scanSpan := tracing.Tracer().Start(ctx, "scan", trace.WithAttributes(attribute.String(tracing.AttributeSpace, octolintConfig.Space)))
+	defer scanSpan.End()
+
+	transport, err := newTransport(&octolintConfig)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the HTTP client. Check that the url is correct.\nThe error was: " + err.Error())
+	}
+
+	// The API root and space documents are read once, and shared by the clients created for each check
+	transport = client_wrapper.NewRootCacheRoundTripper(transport)
+
+	octopusClient, err := o.octopusClient(&octolintConfig, &http.Client{Transport: tracing.NewTransport(transport, ctx)})
+
+	if err != nil {
+		return nil, err
+	}
+
+	checkFactory := factory.NewOctopusCheckFactory(octopusClient, octolintConfig.Url, octolintConfig.Space)
+	checkCollection, err := o.buildChecks(checkFactory, &octolintConfig)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the checks.\nThe error was: " + err.Error())
+	}
+
+	checkCollection, skippedChecks := preflightChecks(octopusClient, checkCollection)
+
+	// Resources modified after the scan starts are inspected again by the next incremental scan
+	scanTime := time.Now()
+	var incrementalScan *incremental.Scan
+
+	if octolintConfig.Incremental {
+		checkCollection, incrementalScan = o.incrementalChecks(octopusClient, &octolintConfig, checkFactory, checkCollection)
+	}
+
+	handleError := o.errorHandler
+	if handleError == nil {
+		handleError = func(check Check, err error) error {
+			logging.Check(check.Id()).Error("Failed to execute check "+check.Id(), zap.Error(err))
+			return nil
+		}
+	}
+
+	startTime := time.Now()
+	checkExecutor := executor.NewOctopusCheckExecutor().WithContext(ctx)
+
+	// Each built-in check is run with its own client while its API requests are traced or logged
+	tracingRequests := o.checkRequestSpans && scanSpan.IsRecording()
+	loggingRequests := zap.L().Core().Enabled(zap.DebugLevel)
+
+	if o.client == nil && o.httpClient == nil && (tracingRequests || loggingRequests) {
+		checkExecutor = checkExecutor.WithCheckContext(o.checkClient(ctx, tracingRequests, checkFactory, &octolintConfig, incrementalScan, transport))
+	}
+
+	results, err := checkExecutor.ExecuteChecks(checkCollection, handleError)
+
+	if err != nil {
+		return nil, errors.New("Failed to run the checks")
+	}
+
+	duration := time.Since(startTime)
+
+	for _, skipped := range skippedChecks {
+		results = append(results, skipped.Result())
+	}
+
+	if octolintConfig.Incremental {
+		results = incrementalScan.Merge(results)
+		o.saveIncrementalCache(&octolintConfig, scanTime, results)
+	}
+
+	return &Report{
+		Results:       results,
+		Space:         octolintConfig.Space,
+		SkippedChecks: skippedChecks,
+		StartTime:     startTime,
+		Duration:      duration,
+	}, nil
+}
+
+// validate checks the settings required to connect to Octopus.
+func (o Scanner) validate(octolintConfig *Config) error {
+	if octolintConfig.Url == "" {
+		return errors.New("You must specify the URL with the -url argument")
+	}
+
+	if _, err := url.ParseRequestURI(octolintConfig.Url); err != nil {
+		return errors.New("The URL \"" + octolintConfig.Url + "\" is not valid")
+	}
+
+	if o.client == nil && octolintConfig.ApiKey == "" && octolintConfig.AccessToken == "" {
+		return errors.New("You must specify the API key with the -apiKey argument")
+	}
+
+	if octolintConfig.Space == "" {
+		return errors.New("You must specify the space key with the -space argument")
+	}
+
+	if o.client != nil && !strings.HasPrefix(octolintConfig.Space, "Spaces-") {
+		return errors.New("The space \"" + octolintConfig.Space + "\" must be a space ID when an Octopus client is supplied")
+	}
+
+	return nil
+}
+
+// octopusClient returns the supplied Octopus client, or creates a client with the HTTP client supplied to the
+// scanner, falling back to defaultHttpClient.
+func (o Scanner) octopusClient(octolintConfig *Config, defaultHttpClient *http.Client) (*client.Client, error) {
+	if o.client != nil {
+		return o.client, nil
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = defaultHttpClient
+	}
+
+	octopusClient, err := NewClient(httpClient, octolintConfig)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+	}
+
+	return octopusClient, nil
+}
+
+// checkClient returns a function that builds a built-in check again with a client that logs its API requests with the
+// check ID, and creates the spans of its API requests under the span of the check when checkSpans is true, or under
+// the span of the scan held by scanCtx. The Octopus client does not pass a context with its requests, so a client
+// shared by the checks can not tell which check sent a request. The clients share the transport of the scan, which
+// caches the API root and space documents, so creating a client does not send any requests. Plugin checks, and
+// checks supplied by the caller, are run with the client they were built with, even if their ID matches a built-in
+// check.
+func (o Scanner) checkClient(scanCtx context.Context, checkSpans bool, checkFactory factory.OctopusCheckFactory, octolintConfig *Config, incrementalScan *incremental.Scan, transport http.RoundTripper) func(ctx context.Context, check Check) Check {
+	return func(ctx context.Context, check Check) Check {
+		checkConfig := incrementalScan.CheckConfig(check.Id(), octolintConfig)
+
+		if builtIn, found := checkFactory.BuildCheck(check.Id(), checkConfig); !found || reflect.TypeOf(builtIn) != reflect.TypeOf(check) {
+			return check
+		}
+
+		parent := scanCtx
+		if checkSpans {
+			parent = ctx
+		}
+
+		octopusClient, err := NewClient(&http.Client{Transport: tracing.NewTransport(logging.NewCheckTransport(transport, check.Id()), parent)}, octolintConfig)
+
+		if err != nil {
+			zap.L().Debug("Failed to create the Octopus client for the check "+check.Id()+", so it shares the client of the scan", zap.Error(err))
+			return check
+		}
+
+		if checkWithClient, found := factory.NewOctopusCheckFactory(octopusClient, octolintConfig.Url, octolintConfig.Space).BuildCheck(check.Id(), checkConfig); found {
+			return checkWithClient
+		}
+
+		return check
+	}
+}
+
+// buildChecks returns the built-in checks selected by the onlyTests and skipTests settings, followed by the checks
+// supplied by the caller.
+func (o Scanner) buildChecks(checkFactory factory.OctopusCheckFactory, octolintConfig *Config) ([]Check, error) {
+	checkCollection := []Check{}
+
+	if o.builtIns {
+		builtIns, err := checkFactory.BuildAllChecks(octolintConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		checkCollection = append(checkCollection, builtIns...)
+	}
+
+	return append(checkCollection, o.checks...), nil
+}
+
+// preflightChecks removes the checks that require permissions the API key has not been granted in the space. All the
+// checks are run if the permissions can not be read. The space ID is read from the client, as the space in the config
+// is not looked up when the client is supplied by the caller.
+func preflightChecks(octopusClient *client.Client, checkCollection []Check) ([]Check, []SkippedCheck) {
+	granted, err := preflight.GetGrantedPermissions(octopusClient, octopusClient.GetSpaceID())
+
+	if err != nil {
+		zap.L().Warn("Failed to read the permissions of the API key, so all checks will be run", zap.Error(err))
+		return checkCollection, nil
+	}
+
+	return preflight.Filter(checkCollection, granted)
+}
+
+// incrementalChecks limits the checks that support incremental scans to the resources that changed since the scan
+// saved in the incremental cache. Every check is run in full if the cache was saved by a scan of another space, or
+// with other settings.
+func (o Scanner) incrementalChecks(octopusClient *client.Client, octolintConfig *Config, checkFactory factory.OctopusCheckFactory, checkCollection []Check) ([]Check, *incremental.Scan) {
+	settings, err := incremental.Settings(octolintConfig, o.version)
+
+	if err != nil {
+		zap.L().Warn("Failed to read the settings of the scan, so all checks will be run in full", zap.Error(err))
+		return checkCollection, nil
+	}
+
+	cache, err := incremental.LoadCache(octolintConfig.IncrementalCache)
+
+	if err != nil {
+		zap.L().Warn("Failed to read the incremental cache "+octolintConfig.IncrementalCache+", so all checks will be run in full", zap.Error(err))
+		return checkCollection, nil
+	}
+
+	if !cache.Matches(octolintConfig.Url, octolintConfig.Space, settings) {
+		zap.L().Info("The incremental cache " + octolintConfig.IncrementalCache + " does not contain a scan of this space with the same settings, so all checks will be run in full")
+		return checkCollection, nil
+	}
+
+	scope, err := incremental.FindChanges(octopusClient, octolintConfig.Space, cache.ScanTime, octolintConfig.PageSize)
+
+	if err != nil {
+		zap.L().Warn("Failed to read the events since the last scan, so all checks will be run in full", zap.Error(err))
+		return checkCollection, nil
+	}
+
+	scopedConfig := *octolintConfig
+	scopedConfig.Scope = scope
+	scopedChecks, err := o.buildChecks(checkFactory, &scopedConfig)
+
+	if err != nil {
+		zap.L().Warn("Failed to create the incremental checks, so all checks will be run in full", zap.Error(err))
+		return checkCollection, nil
+	}
+
+	scan := incremental.NewScan(cache, scope)
+	checkCollection = scan.Checks(checkCollection, scopedChecks)
+	scoped, reused := scan.ScopedChecks()
+
+	zap.L().Info("Found resources that changed since "+cache.ScanTime.Format(time.RFC3339)+". Checks that support incremental scans will only inspect these resources, and the cached results of unaffected checks will be reused",
+		zap.Int("projects", len(scope.ProjectIds)),
+		zap.Int("targets", len(scope.TargetIds)),
+		zap.Int("tenants", len(scope.TenantIds)),
+		zap.Int("libraryVariableSets", len(scope.LibraryVariableSetIds)),
+		zap.Int("scopedChecks", scoped),
+		zap.Int("reusedChecks", reused))
+
+	return checkCollection, scan
+}
+
+// saveIncrementalCache saves the results for the next incremental scan.
+func (o Scanner) saveIncrementalCache(octolintConfig *Config, scanTime time.Time, results []CheckResult) {
+	settings, err := incremental.Settings(octolintConfig, o.version)
+
+	if err == nil {
+		err = incremental.NewCache(octolintConfig.Url, octolintConfig.Space, settings, scanTime, results).Save(octolintConfig.IncrementalCache)
+	}
+
+	if err != nil {
+		zap.L().Error("Failed to save the incremental cache "+octolintConfig.IncrementalCache, zap.Error(err))
+	}
+}
+
+// scanOcl runs the offline checks against the Config-as-Code files saved in the OclDirectory.
+func (o Scanner) scanOcl(octolintConfig *Config) (*Report, error) {
+	offlineProjects, err := ocl.LoadProjects(octolintConfig.OclDirectory)
+
+	if err != nil {
+		return nil, errors.New("Failed to load the Config-as-Code files in " + octolintConfig.OclDirectory + ".\nThe error was: " + err.Error())
+	}
+
+	defaultExcluder := excluder.DefaultExcluder{}
+	offlineProjects = lo.Filter(offlineProjects, func(item OfflineProject, index int) bool {
+		return !defaultExcluder.IsResourceExcluded(item.Project.Name, false, octolintConfig.ExcludeProjects, octolintConfig.ExcludeProjectsExcept)
+	})
+
+	if len(offlineProjects) == 0 {
+		return nil, errors.New("Did not find any Config-as-Code files in " + octolintConfig.OclDirectory)
+	}
+
+	checkCollection, err := o.buildChecks(factory.NewOctopusCheckFactory(nil, "", ""), octolintConfig)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the checks")
+	}
+
+	startTime := time.Now()
+	results := []CheckResult{}
+	for _, check := range checkCollection {
+		offlineCheck, ok := check.(OfflineCheck)
+
+		if !ok {
+			continue
+		}
+
+		result, err := offlineCheck.ExecuteOffline(offlineProjects)
+
+		if err != nil {
+			return nil, errors.New("Failed to execute check " + check.Id() + ".\nThe error was: " + err.Error())
+		}
+
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return &Report{Results: results, StartTime: startTime, Duration: time.Since(startTime)}, nil
+}
@@ -0,0 +1,322 @@
+package octolint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/logging"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type stubCheck struct {
+	id  string
+	err error
+}
+
+func (o stubCheck) Id() string {
+	return o.id
+}
+
+func (o stubCheck) Execute(concurrency int) (CheckResult, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	return NewCheckResult("The check found a problem", o.id, "", SeverityWarning, CategoryOrganization, []Finding{
+		{ResourceType: "Project", ResourceId: "Projects-1", ResourceName: "Web"},
+	}), nil
+}
+
+// newOctopusServer returns a server that responds to the requests made when a client is created, and looks up the
+// Default space by name. All other requests fail. The path of each request is appended to paths if it is not nil.
+func newOctopusServer(t *testing.T, paths *[]string) *httptest.Server {
+	var mutex sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if paths != nil {
+			mutex.Lock()
+			*paths = append(*paths, r.URL.Path)
+			mutex.Unlock()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/", "/api/Spaces-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Links": map[string]string{}})
+		case "/api/Spaces":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]any{{"Id": "Spaces-1", "Name": "Default"}}, "TotalResults": 1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestScanWithCustomChecks(t *testing.T) {
+	server := newOctopusServer(t, nil)
+
+	octolintConfig := DefaultConfig()
+	octolintConfig.Url = server.URL
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Default"
+
+	report, err := NewScanner(octolintConfig).
+		WithHttpClient(server.Client()).
+		WithoutBuiltInChecks().
+		WithChecks(stubCheck{id: "CustomPassing"}, stubCheck{id: "CustomFailing", err: errors.New("boom")}).
+		Scan(context.Background())
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if report.Space != "Spaces-1" || octolintConfig.Space != "Default" {
+		t.Fatal("Should have looked up the space ID without modifying the config")
+	}
+
+	if len(report.Results) != 2 {
+		t.Fatal("Should have returned a result for each check")
+	}
+
+	for _, result := range report.Results {
+		switch result.Code() {
+		case "CustomPassing":
+			if len(result.Findings()) != 1 || result.Findings()[0].ResourceId != "Projects-1" {
+				t.Fatal("Should have returned the findings of the check")
+			}
+		case "CustomFailing":
+			if result.Category() != CategoryGeneralError {
+				t.Fatal("Should have reported the check that failed as a general error")
+			}
+		default:
+			t.Fatal("Should not have run the built-in check " + result.Code())
+		}
+	}
+}
+
+func TestScanWithRedirector(t *testing.T) {
+	upstreamHosts := []string{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamHosts = append(upstreamHosts, r.Header.Get("X_REDIRECTION_UPSTREAM_HOST"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/", "/api/Spaces-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Links": map[string]string{}})
+		case "/api/Spaces":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]any{{"Id": "Spaces-1", "Name": "Default"}}, "TotalResults": 1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	// The redirector client wraps the default transport, which must trust the certificate of the test server
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	octolintConfig := DefaultConfig()
+	octolintConfig.Url = "https://example.octopus.app"
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Default"
+	octolintConfig.UseRedirector = true
+	octolintConfig.RedirectorHost = strings.TrimPrefix(server.URL, "https://")
+
+	report, err := NewScanner(octolintConfig).
+		WithoutBuiltInChecks().
+		WithChecks(stubCheck{id: "CustomPassing"}).
+		Scan(context.Background())
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if report.Space != "Spaces-1" {
+		t.Fatal("Should have looked up the space through the redirector")
+	}
+
+	if len(upstreamHosts) == 0 || lo.ContainsBy(upstreamHosts, func(host string) bool { return host != "example.octopus.app" }) {
+		t.Fatal("Should have sent every request to the redirector with the upstream host")
+	}
+}
+
+func TestScanTracesRequestsUnderCheck(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	paths := []string{}
+	server := newOctopusServer(t, &paths)
+
+	octolintConfig := DefaultConfig()
+	octolintConfig.Url = server.URL
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Spaces-1"
+	octolintConfig.OnlyTests = "OctoLintEnvironmentCount,OctoLintEmptyProject"
+
+	// A plugin with the ID of a built-in check is run as it was supplied
+	report, err := NewScanner(octolintConfig).WithChecks(stubCheck{id: "OctoLintEnvironmentCount"}).Scan(context.Background())
+
+	if err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	if !lo.ContainsBy(report.Results, func(result CheckResult) bool { return result.Description() == "The check found a problem" }) {
+		t.Fatal("Should have run the supplied check rather than replacing it with the built-in check")
+	}
+
+	if lo.Count(paths, "/api/") != 1 || lo.Count(paths, "/api/Spaces-1") != 1 {
+		t.Fatal("Should have read the root documents once, rather than once for each check")
+	}
+
+	checkSpan, found := lo.Find(recorder.Ended(), func(span sdktrace.ReadOnlySpan) bool {
+		return span.Name() == "OctoLintEnvironmentCount" && lo.ContainsBy(recorder.Ended(), func(child sdktrace.ReadOnlySpan) bool {
+			return child.Parent().SpanID() == span.SpanContext().SpanID()
+		})
+	})
+	if !found {
+		t.Fatal("Should have created a span for the check")
+	}
+
+	requestSpans := lo.Filter(recorder.Ended(), func(span sdktrace.ReadOnlySpan, index int) bool {
+		return strings.HasSuffix(span.Name(), "/environments")
+	})
+
+	if len(requestSpans) == 0 {
+		t.Fatal("Should have created a span for the request sent by the check")
+	}
+
+	for _, span := range requestSpans {
+		if span.Parent().SpanID() != checkSpan.SpanContext().SpanID() {
+			t.Fatal("Should have created the request span under the span of the check")
+		}
+	}
+}
+
+func TestScanWithoutCheckRequestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := newOctopusServer(t, nil)
+
+	octolintConfig := DefaultConfig()
+	octolintConfig.Url = server.URL
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Spaces-1"
+	octolintConfig.OnlyTests = "OctoLintEnvironmentCount"
+
+	if _, err := NewScanner(octolintConfig).WithCheckRequestSpans(false).Scan(context.Background()); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	scanSpan, found := lo.Find(recorder.Ended(), func(span sdktrace.ReadOnlySpan) bool { return span.Name() == "scan" })
+	if !found {
+		t.Fatal("Should have created a span for the scan")
+	}
+
+	requestSpans := lo.Filter(recorder.Ended(), func(span sdktrace.ReadOnlySpan, index int) bool {
+		return strings.HasSuffix(span.Name(), "/environments")
+	})
+
+	if len(requestSpans) == 0 || lo.ContainsBy(requestSpans, func(span sdktrace.ReadOnlySpan) bool {
+		return span.Parent().SpanID() != scanSpan.SpanContext().SpanID()
+	}) {
+		t.Fatal("Should have created the request spans under the span of the scan")
+	}
+}
+
+func TestScanLogsRequestsWithCheckId(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	t.Cleanup(zap.ReplaceGlobals(zap.New(core)))
+
+	server := newOctopusServer(t, nil)
+
+	octolintConfig := DefaultConfig()
+	octolintConfig.Url = server.URL
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Spaces-1"
+	octolintConfig.OnlyTests = "OctoLintEnvironmentCount"
+
+	if _, err := NewScanner(octolintConfig).Scan(context.Background()); err != nil {
+		t.Fatal("Should not have returned an error: " + err.Error())
+	}
+
+	requestLogs := lo.Filter(logs.FilterMessage("Octopus API request").All(), func(entry observer.LoggedEntry, index int) bool {
+		return strings.HasSuffix(entry.ContextMap()[logging.FieldRequestPath].(string), "/environments")
+	})
+
+	if len(requestLogs) == 0 {
+		t.Fatal("Should have logged the request sent by the check")
+	}
+
+	for _, entry := range requestLogs {
+		if entry.ContextMap()[logging.FieldCheckId] != "OctoLintEnvironmentCount" {
+			t.Fatal("Should have logged the request with the ID of the check that sent it")
+		}
+	}
+}
+
+func TestScanRequiresUrl(t *testing.T) {
+	octolintConfig := DefaultConfig()
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Spaces-1"
+
+	if _, err := NewScanner(octolintConfig).Scan(context.Background()); err == nil {
+		t.Fatal("Should have returned an error when the URL is missing")
+	}
+}
+
+func TestScanWithClientRequiresSpaceId(t *testing.T) {
+	server := newOctopusServer(t, nil)
+
+	octolintConfig := DefaultConfig()
+	octolintConfig.Url = server.URL
+	octolintConfig.ApiKey = "API-TEST"
+	octolintConfig.Space = "Spaces-1"
+
+	client, err := NewClient(server.Client(), octolintConfig)
+
+	if err != nil {
+		t.Fatal("Should have created the client: " + err.Error())
+	}
+
+	octolintConfig.ApiKey = ""
+	octolintConfig.Space = "Default"
+
+	if _, err := NewScanner(octolintConfig).WithClient(client).Scan(context.Background()); err == nil {
+		t.Fatal("Should have returned an error when the space is not an ID")
+	}
+
+	octolintConfig.Space = "Spaces-1"
+
+	report, err := NewScanner(octolintConfig).WithClient(client).WithoutBuiltInChecks().WithChecks(stubCheck{id: "CustomPassing"}).Scan(context.Background())
+
+	if err != nil || len(report.Results) != 1 {
+		t.Fatal("Should have scanned the space with the supplied client")
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	if DefaultConfig().MaxEnvironments == 0 {
+		t.Fatal("Should have set the default value of each setting")
+	}
+}
@@ -0,0 +1,59 @@
+package octolint
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/preflight"
+)
+
+// Config holds the settings of a scan. Each field matches a command line argument of the octolint CLI.
+type Config = config.OctolintConfig
+
+// Check is implemented by the built-in checks, and by the checks supplied to a Scanner with WithChecks.
+type Check = checks.OctopusCheck
+
+// OfflineCheck is implemented by checks that can also inspect the projects loaded from Config-as-Code files.
+type OfflineCheck = checks.OctopusOfflineCheck
+
+// OfflineProject is a project loaded from Config-as-Code files.
+type OfflineProject = checks.OctopusOfflineProject
+
+// CheckResult is the result of a check.
+type CheckResult = checks.OctopusCheckResult
+
+// Finding identifies an individual resource reported by a check.
+type Finding = checks.OctopusCheckFinding
+
+// SkippedCheck is a check that was not run because the API key is missing the permissions it requires.
+type SkippedCheck = preflight.SkippedCheck
+
+// The severities of a CheckResult.
+const (
+	SeverityError      = checks.Error
+	SeverityWarning    = checks.Warning
+	SeverityInfo       = checks.Info
+	SeverityPermission = checks.Permission
+	SeverityOk         = checks.Ok
+)
+
+// The categories of a CheckResult.
+const (
+	CategoryOrganization = checks.Organization
+	CategoryNaming       = checks.Naming
+	CategorySecurity     = checks.Security
+	CategoryPerformance  = checks.Performance
+	CategoryOptimization = checks.Optimization
+	CategoryGeneralError = checks.GeneralError
+)
+
+// NewCheckResult creates the result returned by a check.
+func NewCheckResult(description string, code string, link string, severity int, category string, findings []Finding) CheckResult {
+	return checks.NewOctopusCheckResultImplWithFindings(description, code, link, severity, category, findings)
+}
+
+// DefaultConfig returns a config with the default value of each setting, which matches running the CLI without any
+// arguments, config file, or environment variables.
+func DefaultConfig() *Config {
+	return args.DefaultConfig()
+}
@@ -38,7 +38,7 @@ resource "octopusdeploy_user_role" "octolintrole" {
     "EventView", "FeedView", "GitCredentialView", "InsightsReportView", "InterruptionView", "LibraryVariableSetView",
     "LifecycleView", "MachinePolicyView", "MachineView", "ProcessView", "ProjectGroupView", "ProjectView", "ProxyView",
     "ReleaseView", "RunbookRunView", "RunbookView", "SubscriptionView", "TaskView", "TeamView", "TenantView",
-    "TriggerView", "VariableView", "VariableViewUnscoped", "WorkerView"
+    "TriggerView", "UserView", "VariableView", "VariableViewUnscoped", "WorkerView"
   ]
   granted_system_permissions    = []
   name                          = "Octolint"
@@ -0,0 +1,5 @@
+terraform {
+  required_providers {
+    octopusdeploy = { source = "OctopusDeployLabs/octopusdeploy", version = "0.30.4" }
+  }
+}
@@ -0,0 +1,3 @@
+output "octopus_space_id" {
+  value = var.octopus_space_id
+}